@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Actor is the user a write is attributed to. RoomingHouseID is the house the
+// request works on, or uuid.Nil when there is none.
+type Actor struct {
+	UserID         uuid.UUID
+	Role           string
+	RoomingHouseID uuid.UUID
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx that attributes writes made with it to actor.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by WithActor, if any.
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	if ctx == nil {
+		return Actor{}, false
+	}
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	return actor, ok
+}
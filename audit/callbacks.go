@@ -0,0 +1,388 @@
+package audit
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const beforeRowsKey = "audit:before_rows"
+
+// skippedTables are never audited: the log itself, and auth bookkeeping that
+// only holds token hashes.
+var skippedTables = map[string]bool{
+	"audit_logs":       true,
+	"sessions":         true,
+	"password_resets":  true,
+	"login_challenges": true,
+	"login_attempts":   true,
+}
+
+// redactedColumns are logged as changed without their values.
+var redactedColumns = map[string]bool{
+	"password":           true,
+	"refresh_token_hash": true,
+	"token_hash":         true,
+	"secret":             true,
+	"code_hash":          true,
+}
+
+// ignoredColumns are left out of update diffs.
+var ignoredColumns = map[string]bool{
+	"updated_at":     true,
+	"last_used_step": true,
+}
+
+// Register installs callbacks on db that write an audit log entry for every
+// created, updated and deleted row. The actor is read from the statement
+// context, so repositories must pass the request context with WithContext.
+func Register(db *gorm.DB) error {
+	if err := db.Callback().Create().After("gorm:create").Register("audit:after_create", afterCreate); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("audit:before_update", loadBeforeRows); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("audit:after_update", afterUpdate); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().Before("gorm:delete").Register("audit:before_delete", loadBeforeRows); err != nil {
+		return err
+	}
+	return db.Callback().Delete().After("gorm:delete").Register("audit:after_delete", afterDelete)
+}
+
+func audited(db *gorm.DB) bool {
+	return db.Error == nil && db.Statement.Table != "" && !skippedTables[db.Statement.Table]
+}
+
+func afterCreate(db *gorm.DB) {
+	if !audited(db) || db.Statement.Schema == nil {
+		return
+	}
+
+	var entries []models.AuditLog
+	for _, row := range createdRows(db) {
+		entries = append(entries, newEntry(db, constants.AuditActionCreate, row, nil, row))
+	}
+
+	saveEntries(db, entries)
+}
+
+// loadBeforeRows stores the rows the update or delete is about to touch.
+func loadBeforeRows(db *gorm.DB) {
+	if !audited(db) {
+		return
+	}
+
+	rows, err := findRows(db, nil)
+	if err != nil {
+		return
+	}
+
+	db.InstanceSet(beforeRowsKey, rows)
+}
+
+func afterUpdate(db *gorm.DB) {
+	if !audited(db) || db.RowsAffected == 0 {
+		return
+	}
+
+	beforeRows := instanceRows(db)
+	if len(beforeRows) == 0 {
+		return
+	}
+
+	var ids []interface{}
+	for _, row := range beforeRows {
+		if id, ok := row["id"]; ok {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return
+	}
+
+	afterRows, err := findRows(db, ids)
+	if err != nil {
+		return
+	}
+
+	afterByID := map[interface{}]map[string]interface{}{}
+	for _, row := range afterRows {
+		afterByID[row["id"]] = row
+	}
+
+	var entries []models.AuditLog
+	for _, before := range beforeRows {
+		after, ok := afterByID[before["id"]]
+		if !ok {
+			continue
+		}
+
+		changedBefore, changedAfter := diffRows(before, after)
+		if len(changedAfter) == 0 {
+			continue
+		}
+
+		entries = append(entries, newEntry(db, constants.AuditActionUpdate, after, changedBefore, changedAfter))
+	}
+
+	saveEntries(db, entries)
+}
+
+func afterDelete(db *gorm.DB) {
+	if !audited(db) || db.RowsAffected == 0 {
+		return
+	}
+
+	var entries []models.AuditLog
+	for _, row := range instanceRows(db) {
+		entries = append(entries, newEntry(db, constants.AuditActionDelete, row, row, nil))
+	}
+
+	saveEntries(db, entries)
+}
+
+// findRows loads the rows matched by the statement's conditions, or the rows
+// with the given ids.
+func findRows(db *gorm.DB, ids []interface{}) ([]map[string]interface{}, error) {
+	stmt := db.Statement
+	query := db.Session(&gorm.Session{NewDB: true}).Table(stmt.Table)
+
+	if ids != nil {
+		query = query.Where("id IN ?", ids)
+	} else {
+		whereClause, ok := stmt.Clauses["WHERE"]
+		if ok {
+			query = query.Clauses(whereClause.Expression)
+		}
+
+		conditions := 0
+		if where, isWhere := whereClause.Expression.(clause.Where); ok && isWhere {
+			conditions = len(where.Exprs)
+		}
+
+		// Conditions taken from the model's primary key are only added by gorm itself later on
+		if stmt.Schema != nil && stmt.ReflectValue.Kind() == reflect.Struct {
+			for _, field := range stmt.Schema.PrimaryFields {
+				if value, isZero := field.ValueOf(stmt.Context, stmt.ReflectValue); !isZero {
+					query = query.Where(clause.Eq{Column: clause.Column{Name: field.DBName}, Value: value})
+					conditions++
+				}
+			}
+		}
+
+		// Never log a whole table for a write gorm would refuse anyway
+		if conditions == 0 {
+			return nil, nil
+		}
+
+		if stmt.Schema != nil && !stmt.Unscoped {
+			if field := stmt.Schema.LookUpField("deleted_at"); field != nil && field.FieldType == reflect.TypeOf(gorm.DeletedAt{}) {
+				query = query.Where("deleted_at IS NULL")
+			}
+		}
+	}
+
+	var rows []map[string]interface{}
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		for column, value := range row {
+			row[column] = normalizeValue(value)
+		}
+	}
+
+	return rows, nil
+}
+
+func createdRows(db *gorm.DB) []map[string]interface{} {
+	stmt := db.Statement
+	var values []reflect.Value
+
+	switch stmt.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < stmt.ReflectValue.Len(); i++ {
+			values = append(values, reflect.Indirect(stmt.ReflectValue.Index(i)))
+		}
+	case reflect.Struct:
+		values = append(values, stmt.ReflectValue)
+	}
+
+	var rows []map[string]interface{}
+	for _, value := range values {
+		row := map[string]interface{}{}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" || !field.Readable {
+				continue
+			}
+			fieldValue, _ := field.ValueOf(stmt.Context, value)
+			row[field.DBName] = normalizeValue(fieldValue)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+func instanceRows(db *gorm.DB) []map[string]interface{} {
+	value, ok := db.InstanceGet(beforeRowsKey)
+	if !ok {
+		return nil
+	}
+	rows, _ := value.([]map[string]interface{})
+	return rows
+}
+
+func diffRows(before map[string]interface{}, after map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	changedBefore := map[string]interface{}{}
+	changedAfter := map[string]interface{}{}
+
+	for column, afterValue := range after {
+		if ignoredColumns[column] {
+			continue
+		}
+
+		beforeValue := before[column]
+		if reflect.DeepEqual(beforeValue, afterValue) {
+			continue
+		}
+
+		changedBefore[column] = beforeValue
+		changedAfter[column] = afterValue
+	}
+
+	return changedBefore, changedAfter
+}
+
+func newEntry(db *gorm.DB, action string, row map[string]interface{}, before map[string]interface{}, after map[string]interface{}) models.AuditLog {
+	entry := models.AuditLog{
+		ActorRole:  constants.AuditSystemRole,
+		EntityType: db.Statement.Table,
+		EntityID:   stringValue(row["id"]),
+		Action:     action,
+		Before:     marshalRow(before),
+		After:      marshalRow(after),
+	}
+
+	actor, hasActor := ActorFromContext(db.Statement.Context)
+	if hasActor {
+		entry.ActorID = &actor.UserID
+		entry.ActorRole = actor.Role
+	}
+
+	if id := uuidValue(row["rooming_house_id"]); id != nil {
+		entry.RoomingHouseID = id
+	} else if db.Statement.Table == "rooming_houses" {
+		entry.RoomingHouseID = uuidValue(row["id"])
+	} else if hasActor && actor.RoomingHouseID != uuid.Nil {
+		roomingHouseID := actor.RoomingHouseID
+		entry.RoomingHouseID = &roomingHouseID
+	}
+
+	switch {
+	case uuidValue(row["owner_id"]) != nil:
+		entry.OwnerID = uuidValue(row["owner_id"])
+	case db.Statement.Table == "owners":
+		entry.OwnerID = uuidValue(row["id"])
+	case entry.RoomingHouseID != nil:
+		var ownerIDs []string
+		db.Session(&gorm.Session{NewDB: true}).Table("rooming_houses").
+			Where("id = ?", *entry.RoomingHouseID).
+			Pluck("owner_id", &ownerIDs)
+		if len(ownerIDs) > 0 {
+			entry.OwnerID = uuidValue(ownerIDs[0])
+		}
+	case hasActor && actor.Role == "owner":
+		entry.OwnerID = &actor.UserID
+	}
+
+	return entry
+}
+
+// saveEntries writes the entries on the statement's connection, so they are
+// rolled back together with a failed transaction.
+func saveEntries(db *gorm.DB, entries []models.AuditLog) {
+	if len(entries) == 0 {
+		return
+	}
+
+	if err := db.Session(&gorm.Session{NewDB: true}).Create(&entries).Error; err != nil {
+		db.Logger.Error(db.Statement.Context, "audit: failed to save entries: %v", err)
+	}
+}
+
+func marshalRow(row map[string]interface{}) json.RawMessage {
+	if row == nil {
+		return nil
+	}
+
+	redacted := map[string]interface{}{}
+	for column, value := range row {
+		if redactedColumns[column] {
+			value = "[redacted]"
+		}
+		redacted[column] = value
+	}
+
+	data, err := json.Marshal(redacted)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// normalizeValue turns driver and model types into plain JSON friendly values
+// so rows read back from the database compare equal.
+func normalizeValue(value interface{}) interface{} {
+	if valuer, ok := value.(driver.Valuer); ok {
+		if reflect.ValueOf(valuer).Kind() == reflect.Ptr && reflect.ValueOf(valuer).IsNil() {
+			return nil
+		}
+		converted, err := valuer.Value()
+		if err != nil {
+			return nil
+		}
+		value = converted
+	}
+
+	if bytes, ok := value.([]byte); ok {
+		return string(bytes)
+	}
+
+	return value
+}
+
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+func uuidValue(value interface{}) *uuid.UUID {
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return nil
+	}
+
+	id, err := uuid.Parse(s)
+	if err != nil || id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
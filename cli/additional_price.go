@@ -2,6 +2,7 @@ package cli
 
 import (
 	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/controllers"
 	"rooming-house-cms-be/middlewares"
 	"rooming-house-cms-be/repositories"
@@ -18,9 +19,9 @@ func AdditionalPriceRoutes(e *echo.Echo) {
 	additionalPriceController := controllers.NewAdditionalPriceController(additionalPriceRepo, additionalPeriodRepo, periodRepo, roomingHouseRepo)
 
 	additionalPrice := e.Group("/additionals")
-	additionalPrice.GET("/:id", additionalPriceController.FindAdditionalPriceByID, middlewares.JWTAuth)
-	additionalPrice.GET("", additionalPriceController.FindAllAdditionalPrices, middlewares.JWTAuth)
-	additionalPrice.POST("", additionalPriceController.CreateAdditionalPrice, middlewares.JWTAuth, middlewares.Authz)
-	additionalPrice.PUT("/:id", additionalPriceController.UpdateAdditionalPriceByID, middlewares.JWTAuth, middlewares.Authz)
-	additionalPrice.DELETE("/:id", additionalPriceController.DeleteAdditionalPriceByID, middlewares.JWTAuth, middlewares.Authz)
+	additionalPrice.GET("/:id", additionalPriceController.FindAdditionalPriceByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourcePricing, constants.ActionRead))
+	additionalPrice.GET("", additionalPriceController.FindAllAdditionalPrices, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourcePricing, constants.ActionRead))
+	additionalPrice.POST("", additionalPriceController.CreateAdditionalPrice, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourcePricing, constants.ActionWrite))
+	additionalPrice.PUT("/:id", additionalPriceController.UpdateAdditionalPriceByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourcePricing, constants.ActionWrite))
+	additionalPrice.DELETE("/:id", additionalPriceController.DeleteAdditionalPriceByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourcePricing, constants.ActionDelete))
 }
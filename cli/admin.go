@@ -1,8 +1,11 @@
 package cli
 
 import (
+	"os"
 	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/controllers"
+	"rooming-house-cms-be/mailer"
 	"rooming-house-cms-be/middlewares"
 	"rooming-house-cms-be/repositories"
 
@@ -13,9 +16,22 @@ func AdminRoutes(e *echo.Echo) {
 	adminRepo := repositories.NewAdminRepository(config.DB)
 	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
 
-	adminController := controllers.NewAdminController(adminRepo, roomingHouseRepo)
+	sessionRepo := repositories.NewSessionRepository(config.DB)
+	roleRepo := repositories.NewRoleRepository(config.DB)
+	passwordResetRepo := repositories.NewPasswordResetRepository(config.DB)
+	outbox := mailer.NewLogMailer(os.Getenv("MAIL_OUTBOX_PATH"))
 
-	admin := e.Group("/admins", middlewares.JWTAuth, middlewares.Authz)
-	admin.GET("", adminController.GetAllAdmin)
-	admin.DELETE("/:id", adminController.DeleteAdminByID)
+	adminController := controllers.NewAdminController(adminRepo, roomingHouseRepo, sessionRepo, roleRepo, passwordResetRepo, outbox)
+
+	admin := e.Group("/admins", middlewares.JWTAuth)
+	admin.GET("", adminController.GetAllAdmin, middlewares.RequirePermission(constants.ResourceAdmins, constants.ActionRead))
+	admin.PUT("/:id", adminController.UpdateAdminByID, middlewares.RequirePermission(constants.ResourceAdmins, constants.ActionWrite))
+	admin.POST("/:id/disable", adminController.DisableAdmin, middlewares.RequirePermission(constants.ResourceAdmins, constants.ActionWrite))
+	admin.POST("/:id/enable", adminController.EnableAdmin, middlewares.RequirePermission(constants.ResourceAdmins, constants.ActionWrite))
+	admin.POST("/:id/password-reset", adminController.ResetAdminPassword, middlewares.RequirePermission(constants.ResourceAdmins, constants.ActionWrite))
+	admin.POST("/:id/move", adminController.MoveAdminRoomingHouse, middlewares.RequirePermission(constants.ResourceAdmins, constants.ActionWrite))
+	admin.DELETE("/:id", adminController.DeleteAdminByID, middlewares.RequirePermission(constants.ResourceAdmins, constants.ActionDelete))
+	admin.POST("/:id/rooming-houses", adminController.AssignAdminRoomingHouse, middlewares.RequirePermission(constants.ResourceAdmins, constants.ActionWrite))
+	admin.PUT("/:id/rooming-houses/:roomingHouseID", adminController.UpdateAdminRoomingHouseRole, middlewares.RequirePermission(constants.ResourceAdmins, constants.ActionWrite))
+	admin.DELETE("/:id/rooming-houses/:roomingHouseID", adminController.UnassignAdminRoomingHouse, middlewares.RequirePermission(constants.ResourceAdmins, constants.ActionWrite))
 }
@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"os"
+	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/controllers"
+	"rooming-house-cms-be/middlewares"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/storage"
+
+	"github.com/labstack/echo/v4"
+)
+
+func AttachmentRoutes(e *echo.Echo) {
+	attachmentRepo := repositories.NewAttachmentRepository(config.DB)
+	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
+	fileStorage := storage.NewLocalStorage(os.Getenv("STORAGE_PATH"))
+
+	attachmentController := controllers.NewAttachmentController(attachmentRepo, roomingHouseRepo, fileStorage)
+
+	attachment := e.Group("/attachments", middlewares.JWTAuth)
+	attachment.POST("", attachmentController.UploadAttachment, middlewares.RequirePermission(constants.ResourceAttachments, constants.ActionWrite))
+	attachment.GET("", attachmentController.FindAllAttachments, middlewares.RequirePermission(constants.ResourceAttachments, constants.ActionRead))
+	attachment.GET("/:id", attachmentController.DownloadAttachment, middlewares.RequirePermission(constants.ResourceAttachments, constants.ActionRead))
+	attachment.DELETE("/:id", attachmentController.DeleteAttachmentByID, middlewares.RequirePermission(constants.ResourceAttachments, constants.ActionDelete))
+}
@@ -0,0 +1,21 @@
+package cli
+
+import (
+	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/controllers"
+	"rooming-house-cms-be/middlewares"
+	"rooming-house-cms-be/repositories"
+
+	"github.com/labstack/echo/v4"
+)
+
+func AuditLogRoutes(e *echo.Echo) {
+	auditLogRepo := repositories.NewAuditLogRepository(config.DB)
+	loginAttemptRepo := repositories.NewLoginAttemptRepository(config.DB)
+
+	auditLogController := controllers.NewAuditLogController(auditLogRepo, loginAttemptRepo)
+
+	e.GET("/audit", auditLogController.FindAllAuditLogs, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceAudit, constants.ActionRead))
+	e.GET("/audit/logins", auditLogController.FindAllLoginAttempts, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceAudit, constants.ActionRead))
+}
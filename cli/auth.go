@@ -1,8 +1,10 @@
 package cli
 
 import (
+	"os"
 	"rooming-house-cms-be/config"
 	"rooming-house-cms-be/controllers"
+	"rooming-house-cms-be/mailer"
 	"rooming-house-cms-be/middlewares"
 	"rooming-house-cms-be/repositories"
 
@@ -13,9 +15,27 @@ func Auth(e *echo.Echo) {
 	adminRepo := repositories.NewAdminRepository(config.DB)
 	ownerRepo := repositories.NewOwnerRepository(config.DB)
 	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
-	userController := controllers.NewUserController(ownerRepo, adminRepo, roomingHouseRepo)
+	tenantAccountRepo := repositories.NewTenantAccountRepository(config.DB)
+	sessionRepo := repositories.NewSessionRepository(config.DB)
+	passwordResetRepo := repositories.NewPasswordResetRepository(config.DB)
+	roleRepo := repositories.NewRoleRepository(config.DB)
+	twoFactorRepo := repositories.NewTwoFactorRepository(config.DB)
+	challengeRepo := repositories.NewLoginChallengeRepository(config.DB)
+	loginAttemptRepo := repositories.NewLoginAttemptRepository(config.DB)
+	outbox := mailer.NewLogMailer(os.Getenv("MAIL_OUTBOX_PATH"))
+	userController := controllers.NewUserController(ownerRepo, adminRepo, roomingHouseRepo, tenantAccountRepo, sessionRepo, passwordResetRepo, outbox, roleRepo, twoFactorRepo, challengeRepo, loginAttemptRepo)
 
 	e.POST("/login", userController.Login)
+	e.POST("/login/verify", userController.VerifyLogin)
+	e.POST("/refresh", userController.RefreshToken)
+	e.POST("/logout", userController.Logout, middlewares.AnyJWTAuth)
+	e.PUT("/password", userController.ChangePassword, middlewares.AnyJWTAuth)
+	e.POST("/password/forgot", userController.ForgotPassword)
+	e.POST("/password/reset", userController.ResetPassword)
+	e.GET("/2fa", userController.GetTwoFactorStatus, middlewares.AnyJWTAuth)
+	e.POST("/2fa/setup", userController.SetupTwoFactor, middlewares.AnyJWTAuth)
+	e.POST("/2fa/enable", userController.EnableTwoFactor, middlewares.AnyJWTAuth)
+	e.POST("/2fa/recovery-codes", userController.RegenerateRecoveryCodes, middlewares.AnyJWTAuth)
+	e.DELETE("/2fa", userController.DisableTwoFactor, middlewares.AnyJWTAuth)
 	e.POST("/registerowner", userController.RegisterOwner)
-	e.POST("/registeradmin", userController.RegisterAdmin, middlewares.JWTAuth, middlewares.Authz)
 }
@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/controllers"
+	"rooming-house-cms-be/middlewares"
+	"rooming-house-cms-be/repositories"
+
+	"github.com/labstack/echo/v4"
+)
+
+func BudgetRoutes(e *echo.Echo) {
+	budgetRepo := repositories.NewBudgetRepository(config.DB)
+	transactionRepo := repositories.NewTransactionRepository(config.DB)
+	transactionCategoryRepo := repositories.NewTransactionCategoryRepository(config.DB)
+	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
+
+	budgetController := controllers.NewBudgetController(budgetRepo, transactionRepo, transactionCategoryRepo, roomingHouseRepo)
+
+	budget := e.Group("/budgets", middlewares.JWTAuth)
+	budget.GET("", budgetController.FindAllBudgets, middlewares.RequirePermission(constants.ResourceBudgets, constants.ActionRead))
+	budget.GET("/variance", budgetController.Variance, middlewares.RequirePermission(constants.ResourceBudgets, constants.ActionRead))
+	budget.GET("/alerts", budgetController.Alerts, middlewares.RequirePermission(constants.ResourceBudgets, constants.ActionRead))
+	budget.PUT("", budgetController.SetBudget, middlewares.RequirePermission(constants.ResourceBudgets, constants.ActionWrite))
+	budget.DELETE("/:id", budgetController.DeleteBudgetByID, middlewares.RequirePermission(constants.ResourceBudgets, constants.ActionDelete))
+}
@@ -2,7 +2,9 @@ package cli
 
 import (
 	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/controllers"
+	"rooming-house-cms-be/middlewares"
 	"rooming-house-cms-be/repositories"
 
 	"github.com/labstack/echo/v4"
@@ -10,9 +12,13 @@ import (
 
 func FacilityRoutes(e *echo.Echo) {
 	facilityRepo := repositories.NewFacilityRepository(config.DB)
+	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
 
-	facilityController := controllers.NewFacilityController(facilityRepo)
+	facilityController := controllers.NewFacilityController(facilityRepo, roomingHouseRepo)
 
 	facility := e.Group("/facilities")
-	facility.GET("", facilityController.GetAllFacilities)
+	facility.GET("", facilityController.GetAllFacilities, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceFacilities, constants.ActionRead))
+	facility.POST("", facilityController.CreateFacility, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceFacilities, constants.ActionWrite))
+	facility.PUT("/:id", facilityController.UpdateFacilityByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceFacilities, constants.ActionWrite))
+	facility.DELETE("/:id", facilityController.DeleteFacilityByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceFacilities, constants.ActionDelete))
 }
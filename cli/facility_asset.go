@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/controllers"
+	"rooming-house-cms-be/middlewares"
+	"rooming-house-cms-be/repositories"
+
+	"github.com/labstack/echo/v4"
+)
+
+func FacilityAssetRoutes(e *echo.Echo) {
+	facilityAssetRepo := repositories.NewFacilityAssetRepository(config.DB)
+	facilityRepo := repositories.NewFacilityRepository(config.DB)
+	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
+	roomRepo := repositories.NewRoomRepository(config.DB)
+
+	facilityAssetController := controllers.NewFacilityAssetController(facilityAssetRepo, facilityRepo, roomingHouseRepo, roomRepo)
+
+	facilityAsset := e.Group("/facility-assets", middlewares.JWTAuth)
+	facilityAsset.POST("", facilityAssetController.CreateFacilityAsset, middlewares.RequirePermission(constants.ResourceAssets, constants.ActionWrite))
+	facilityAsset.GET("", facilityAssetController.FindAllFacilityAssets, middlewares.RequirePermission(constants.ResourceAssets, constants.ActionRead))
+	facilityAsset.GET("/depreciation", facilityAssetController.FindDepreciationReport, middlewares.RequirePermission(constants.ResourceAssets, constants.ActionRead))
+	facilityAsset.GET("/:id", facilityAssetController.FindFacilityAssetByID, middlewares.RequirePermission(constants.ResourceAssets, constants.ActionRead))
+	facilityAsset.PUT("/:id", facilityAssetController.UpdateFacilityAssetByID, middlewares.RequirePermission(constants.ResourceAssets, constants.ActionWrite))
+	facilityAsset.PUT("/:id/move", facilityAssetController.MoveFacilityAsset, middlewares.RequirePermission(constants.ResourceAssets, constants.ActionWrite))
+	facilityAsset.GET("/:id/movements", facilityAssetController.FindFacilityAssetMovements, middlewares.RequirePermission(constants.ResourceAssets, constants.ActionRead))
+	facilityAsset.DELETE("/:id", facilityAssetController.DeleteFacilityAssetByID, middlewares.RequirePermission(constants.ResourceAssets, constants.ActionDelete))
+}
@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"os"
+	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/controllers"
+	"rooming-house-cms-be/mailer"
+	"rooming-house-cms-be/middlewares"
+	"rooming-house-cms-be/repositories"
+
+	"github.com/labstack/echo/v4"
+)
+
+func InvitationRoutes(e *echo.Echo) {
+	invitationRepo := repositories.NewInvitationRepository(config.DB)
+	adminRepo := repositories.NewAdminRepository(config.DB)
+	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
+	roleRepo := repositories.NewRoleRepository(config.DB)
+	outbox := mailer.NewLogMailer(os.Getenv("MAIL_OUTBOX_PATH"))
+
+	invitationController := controllers.NewInvitationController(invitationRepo, adminRepo, roomingHouseRepo, roleRepo, outbox, os.Getenv("INVITATION_URL"))
+
+	e.POST("/invitations/accept", invitationController.AcceptInvitation)
+
+	invitation := e.Group("/invitations", middlewares.JWTAuth)
+	invitation.GET("", invitationController.GetAllPendingInvitations, middlewares.RequirePermission(constants.ResourceAdmins, constants.ActionRead))
+	invitation.POST("", invitationController.CreateInvitation, middlewares.RequirePermission(constants.ResourceAdmins, constants.ActionWrite))
+	invitation.DELETE("/:id", invitationController.RevokeInvitation, middlewares.RequirePermission(constants.ResourceAdmins, constants.ActionWrite))
+}
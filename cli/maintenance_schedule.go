@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/controllers"
+	"rooming-house-cms-be/middlewares"
+	"rooming-house-cms-be/repositories"
+
+	"github.com/labstack/echo/v4"
+)
+
+func MaintenanceScheduleRoutes(e *echo.Echo) {
+	maintenanceScheduleRepo := repositories.NewMaintenanceScheduleRepository(config.DB)
+	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
+	roomRepo := repositories.NewRoomRepository(config.DB)
+	roomingHouseFacilityRepo := repositories.NewRoomingHouseFacilityRepository(config.DB)
+
+	maintenanceScheduleController := controllers.NewMaintenanceScheduleController(maintenanceScheduleRepo, roomingHouseRepo, roomRepo, roomingHouseFacilityRepo)
+
+	maintenance := e.Group("/maintenance-schedules", middlewares.JWTAuth)
+	maintenance.POST("", maintenanceScheduleController.CreateMaintenanceSchedule, middlewares.RequirePermission(constants.ResourceMaintenance, constants.ActionWrite))
+	maintenance.GET("", maintenanceScheduleController.FindAllMaintenanceSchedules, middlewares.RequirePermission(constants.ResourceMaintenance, constants.ActionRead))
+	maintenance.GET("/upcoming", maintenanceScheduleController.FindUpcomingMaintenance, middlewares.RequirePermission(constants.ResourceMaintenance, constants.ActionRead))
+	maintenance.GET("/history", maintenanceScheduleController.FindMaintenanceLogs, middlewares.RequirePermission(constants.ResourceMaintenance, constants.ActionRead))
+	maintenance.GET("/:id", maintenanceScheduleController.FindMaintenanceScheduleByID, middlewares.RequirePermission(constants.ResourceMaintenance, constants.ActionRead))
+	maintenance.PUT("/:id", maintenanceScheduleController.UpdateMaintenanceScheduleByID, middlewares.RequirePermission(constants.ResourceMaintenance, constants.ActionWrite))
+	maintenance.POST("/:id/complete", maintenanceScheduleController.CompleteMaintenanceSchedule, middlewares.RequirePermission(constants.ResourceMaintenance, constants.ActionWrite))
+	maintenance.DELETE("/:id", maintenanceScheduleController.DeleteMaintenanceScheduleByID, middlewares.RequirePermission(constants.ResourceMaintenance, constants.ActionDelete))
+}
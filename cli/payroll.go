@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/controllers"
+	"rooming-house-cms-be/middlewares"
+	"rooming-house-cms-be/repositories"
+
+	"github.com/labstack/echo/v4"
+)
+
+func PayrollRoutes(e *echo.Echo) {
+	payrollRepo := repositories.NewPayrollRepository(config.DB)
+	staffRepo := repositories.NewStaffRepository(config.DB)
+	transactionCategoryRepo := repositories.NewTransactionCategoryRepository(config.DB)
+	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
+
+	payrollController := controllers.NewPayrollController(payrollRepo, staffRepo, transactionCategoryRepo, roomingHouseRepo)
+
+	payroll := e.Group("/payroll", middlewares.JWTAuth)
+	payroll.GET("/payslips/me", payrollController.FindMyPayslips)
+	payroll.GET("/payslips/:id", payrollController.FindPayslipByID, middlewares.RequirePermission(constants.ResourcePayroll, constants.ActionRead))
+	payroll.GET("", payrollController.FindAllPayrollRuns, middlewares.RequirePermission(constants.ResourcePayroll, constants.ActionRead))
+	payroll.GET("/:id", payrollController.FindPayrollRunByID, middlewares.RequirePermission(constants.ResourcePayroll, constants.ActionRead))
+	payroll.POST("", payrollController.CreatePayrollRun, middlewares.RequirePermission(constants.ResourcePayroll, constants.ActionWrite))
+	payroll.POST("/:id/approve", payrollController.ApprovePayrollRun, middlewares.RequirePermission(constants.ResourcePayroll, constants.ActionApprove))
+	payroll.DELETE("/:id", payrollController.DeletePayrollRun, middlewares.RequirePermission(constants.ResourcePayroll, constants.ActionDelete))
+}
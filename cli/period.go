@@ -2,6 +2,7 @@ package cli
 
 import (
 	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/controllers"
 	"rooming-house-cms-be/middlewares"
 	"rooming-house-cms-be/repositories"
@@ -11,9 +12,13 @@ import (
 
 func PeriodRoute(e *echo.Echo) {
 	periodRepo := repositories.NewPeriodRepository(config.DB)
+	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
 
-	periodController := controllers.NewPeriodController(periodRepo)
+	periodController := controllers.NewPeriodController(periodRepo, roomingHouseRepo)
 
 	period := e.Group("/periods")
-	period.GET("", periodController.GetAllPeriods, middlewares.JWTAuth)
+	period.GET("", periodController.GetAllPeriods, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourcePeriods, constants.ActionRead))
+	period.POST("", periodController.CreatePeriod, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourcePeriods, constants.ActionWrite))
+	period.PUT("/:id", periodController.UpdatePeriodByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourcePeriods, constants.ActionWrite))
+	period.DELETE("/:id", periodController.DeletePeriodByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourcePeriods, constants.ActionDelete))
 }
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/controllers"
 	"rooming-house-cms-be/middlewares"
 	"rooming-house-cms-be/repositories"
@@ -18,8 +19,8 @@ func PricingPackageRoutes(e *echo.Echo) {
 	pricingPackageController := controllers.NewPricingPackageController(pricingPackageRepo, periodRepo, periodPackageRepo, roomingHouseRepo)
 
 	pricingPackage := e.Group("/packages")
-	pricingPackage.POST("", pricingPackageController.CreatePricingPackage, middlewares.JWTAuth, middlewares.Authz)
-	pricingPackage.GET("", pricingPackageController.GetAllPricingPackages, middlewares.JWTAuth)
-	pricingPackage.PUT("/:id", pricingPackageController.UpdatePricingPackage, middlewares.JWTAuth, middlewares.Authz)
-	pricingPackage.DELETE("/:id", pricingPackageController.DeletePricingPackage, middlewares.JWTAuth, middlewares.Authz)
+	pricingPackage.POST("", pricingPackageController.CreatePricingPackage, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourcePricing, constants.ActionWrite))
+	pricingPackage.GET("", pricingPackageController.GetAllPricingPackages, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourcePricing, constants.ActionRead))
+	pricingPackage.PUT("/:id", pricingPackageController.UpdatePricingPackage, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourcePricing, constants.ActionWrite))
+	pricingPackage.DELETE("/:id", pricingPackageController.DeletePricingPackage, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourcePricing, constants.ActionDelete))
 }
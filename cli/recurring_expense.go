@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/controllers"
+	"rooming-house-cms-be/middlewares"
+	"rooming-house-cms-be/repositories"
+
+	"github.com/labstack/echo/v4"
+)
+
+func RecurringExpenseRoutes(e *echo.Echo) {
+	recurringExpenseRepo := repositories.NewRecurringExpenseRepository(config.DB)
+	transactionCategoryRepo := repositories.NewTransactionCategoryRepository(config.DB)
+	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
+
+	recurringExpenseController := controllers.NewRecurringExpenseController(recurringExpenseRepo, transactionCategoryRepo, roomingHouseRepo)
+
+	recurringExpense := e.Group("/recurring-expenses", middlewares.JWTAuth)
+	recurringExpense.GET("", recurringExpenseController.FindAllRecurringExpenses, middlewares.RequirePermission(constants.ResourceRecurringExpenses, constants.ActionRead))
+	recurringExpense.GET("/drafts", recurringExpenseController.FindAllRecurringExpenseDrafts, middlewares.RequirePermission(constants.ResourceRecurringExpenses, constants.ActionRead))
+	recurringExpense.POST("/drafts/:id/confirm", recurringExpenseController.ConfirmRecurringExpenseDraft, middlewares.RequirePermission(constants.ResourceTransactions, constants.ActionWrite))
+	recurringExpense.POST("/drafts/:id/dismiss", recurringExpenseController.DismissRecurringExpenseDraft, middlewares.RequirePermission(constants.ResourceTransactions, constants.ActionWrite))
+	recurringExpense.GET("/:id", recurringExpenseController.FindRecurringExpenseByID, middlewares.RequirePermission(constants.ResourceRecurringExpenses, constants.ActionRead))
+	recurringExpense.POST("", recurringExpenseController.CreateRecurringExpense, middlewares.RequirePermission(constants.ResourceRecurringExpenses, constants.ActionWrite))
+	recurringExpense.PUT("/:id", recurringExpenseController.UpdateRecurringExpenseByID, middlewares.RequirePermission(constants.ResourceRecurringExpenses, constants.ActionWrite))
+	recurringExpense.DELETE("/:id", recurringExpenseController.DeleteRecurringExpenseByID, middlewares.RequirePermission(constants.ResourceRecurringExpenses, constants.ActionDelete))
+}
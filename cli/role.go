@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/controllers"
+	"rooming-house-cms-be/middlewares"
+	"rooming-house-cms-be/repositories"
+
+	"github.com/labstack/echo/v4"
+)
+
+func RoleRoutes(e *echo.Echo) {
+	roleRepo := repositories.NewRoleRepository(config.DB)
+
+	roleController := controllers.NewRoleController(roleRepo)
+
+	role := e.Group("/roles", middlewares.JWTAuth)
+	role.GET("", roleController.GetAllRoles, middlewares.RequirePermission(constants.ResourceRoles, constants.ActionRead))
+	role.GET("/permissions", roleController.GetAllPermissions, middlewares.RequirePermission(constants.ResourceRoles, constants.ActionRead))
+	role.POST("", roleController.CreateRole, middlewares.RequirePermission(constants.ResourceRoles, constants.ActionWrite))
+	role.PUT("/:id", roleController.UpdateRoleByID, middlewares.RequirePermission(constants.ResourceRoles, constants.ActionWrite))
+	role.DELETE("/:id", roleController.DeleteRoleByID, middlewares.RequirePermission(constants.ResourceRoles, constants.ActionDelete))
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/controllers"
 	"rooming-house-cms-be/middlewares"
 	"rooming-house-cms-be/repositories"
@@ -20,9 +21,11 @@ func RoomRoutes(e *echo.Echo) {
 	roomController := controllers.NewRoomController(roomRepo, roomFacilityRepo, roomingHouseRepo, sizeRepo, packageRepo, facilityRepo)
 
 	room := e.Group("/rooms")
-	room.POST("", roomController.CreateRoom, middlewares.JWTAuth)
-	room.GET("", roomController.GetAllRooms, middlewares.JWTAuth)
-	room.GET("/:id", roomController.GetRoomByID, middlewares.JWTAuth)
-	room.PUT("/:id", roomController.UpdateRoomByID, middlewares.JWTAuth)
-	room.DELETE("/:id", roomController.DeleteRoomByID, middlewares.JWTAuth, middlewares.Authz)
+	room.POST("", roomController.CreateRoom, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceRooms, constants.ActionWrite))
+	room.GET("", roomController.GetAllRooms, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceRooms, constants.ActionRead))
+	room.GET("/:id", roomController.GetRoomByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceRooms, constants.ActionRead))
+	room.PUT("/:id", roomController.UpdateRoomByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceRooms, constants.ActionWrite))
+	room.PUT("/:id/status", roomController.UpdateRoomStatus, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceRooms, constants.ActionWrite))
+	room.GET("/:id/status-histories", roomController.GetRoomStatusHistories, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceRooms, constants.ActionRead))
+	room.DELETE("/:id", roomController.DeleteRoomByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceRooms, constants.ActionDelete))
 }
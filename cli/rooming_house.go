@@ -2,6 +2,7 @@ package cli
 
 import (
 	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/controllers"
 	"rooming-house-cms-be/middlewares"
 	"rooming-house-cms-be/repositories"
@@ -13,13 +14,17 @@ func RoomingHouseRoutes(e *echo.Echo) {
 	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
 	roomingHouseFacilityRepo := repositories.NewRoomingHouseFacilityRepository(config.DB)
 	facilityRepo := repositories.NewFacilityRepository(config.DB)
+	roomingHouseOwnerRepo := repositories.NewRoomingHouseOwnerRepository(config.DB)
+	ownerRepo := repositories.NewOwnerRepository(config.DB)
 
-	roomingHouseController := controllers.NewRoomingHouseController(roomingHouseRepo, roomingHouseFacilityRepo, facilityRepo)
+	roomingHouseController := controllers.NewRoomingHouseController(roomingHouseRepo, roomingHouseFacilityRepo, facilityRepo, roomingHouseOwnerRepo, ownerRepo)
 
 	roomingHouse := e.Group("/roominghouses")
-	roomingHouse.GET("/:id", roomingHouseController.GetRoomingHouseByID, middlewares.JWTAuth)
-	roomingHouse.GET("", roomingHouseController.GetAllRoomingHouse, middlewares.JWTAuth)
-	roomingHouse.POST("", roomingHouseController.CreateRoomingHouse, middlewares.JWTAuth, middlewares.Authz)
-	roomingHouse.PUT("/:id", roomingHouseController.UpdateRoomingHouseByID, middlewares.JWTAuth, middlewares.Authz)
-	roomingHouse.DELETE("/:id", roomingHouseController.DeleteRoomingHouseByID, middlewares.JWTAuth, middlewares.Authz)
+	roomingHouse.GET("/:id", roomingHouseController.GetRoomingHouseByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceRoomingHouses, constants.ActionRead))
+	roomingHouse.GET("", roomingHouseController.GetAllRoomingHouse, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceRoomingHouses, constants.ActionRead))
+	roomingHouse.POST("", roomingHouseController.CreateRoomingHouse, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceRoomingHouses, constants.ActionWrite))
+	roomingHouse.PUT("/:id", roomingHouseController.UpdateRoomingHouseByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceRoomingHouses, constants.ActionWrite))
+	roomingHouse.GET("/:id/owners", roomingHouseController.GetRoomingHouseOwners, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceRoomingHouses, constants.ActionRead))
+	roomingHouse.PUT("/:id/owners", roomingHouseController.UpdateRoomingHouseOwners, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceRoomingHouses, constants.ActionWrite))
+	roomingHouse.DELETE("/:id", roomingHouseController.DeleteRoomingHouseByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceRoomingHouses, constants.ActionDelete))
 }
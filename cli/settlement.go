@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/controllers"
+	"rooming-house-cms-be/middlewares"
+	"rooming-house-cms-be/repositories"
+
+	"github.com/labstack/echo/v4"
+)
+
+func SettlementRoutes(e *echo.Echo) {
+	settlementRepo := repositories.NewSettlementRepository(config.DB)
+	transactionRepo := repositories.NewTransactionRepository(config.DB)
+	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
+	roomingHouseOwnerRepo := repositories.NewRoomingHouseOwnerRepository(config.DB)
+
+	settlementController := controllers.NewSettlementController(settlementRepo, transactionRepo, roomingHouseRepo, roomingHouseOwnerRepo)
+
+	e.GET("/roominghouses/:id/management-fee", settlementController.GetManagementFee, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceSettlements, constants.ActionRead))
+	e.PUT("/roominghouses/:id/management-fee", settlementController.UpdateManagementFee, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceRoomingHouses, constants.ActionWrite))
+
+	settlement := e.Group("/settlements", middlewares.JWTAuth)
+	settlement.GET("", settlementController.FindAllSettlements, middlewares.RequirePermission(constants.ResourceSettlements, constants.ActionRead))
+	settlement.GET("/:id", settlementController.FindSettlementStatement, middlewares.RequirePermission(constants.ResourceSettlements, constants.ActionRead))
+	settlement.POST("", settlementController.CreateSettlement, middlewares.RequirePermission(constants.ResourceSettlements, constants.ActionWrite))
+	settlement.POST("/:id/payouts/:payoutID/pay", settlementController.RecordPayout, middlewares.RequirePermission(constants.ResourceSettlements, constants.ActionWrite))
+	settlement.DELETE("/:id", settlementController.DeleteSettlement, middlewares.RequirePermission(constants.ResourceSettlements, constants.ActionDelete))
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/controllers"
 	"rooming-house-cms-be/middlewares"
 	"rooming-house-cms-be/repositories"
@@ -16,9 +17,9 @@ func SizeRoutes(e *echo.Echo) {
 	sizeController := controllers.NewSizeController(sizeRepo, roomingHouseRepo)
 
 	size := e.Group("/sizes")
-	size.GET("", sizeController.FindAllSizes, middlewares.JWTAuth)
-	size.GET("/:id", sizeController.FindSizeByID, middlewares.JWTAuth)
-	size.POST("", sizeController.CreateSize, middlewares.JWTAuth, middlewares.Authz)
-	size.PUT("/:id", sizeController.UpdateSizeByID, middlewares.JWTAuth, middlewares.Authz)
-	size.DELETE("/:id", sizeController.DeleteSizeByID, middlewares.JWTAuth, middlewares.Authz)
+	size.GET("", sizeController.FindAllSizes, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceSizes, constants.ActionRead))
+	size.GET("/:id", sizeController.FindSizeByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceSizes, constants.ActionRead))
+	size.POST("", sizeController.CreateSize, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceSizes, constants.ActionWrite))
+	size.PUT("/:id", sizeController.UpdateSizeByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceSizes, constants.ActionWrite))
+	size.DELETE("/:id", sizeController.DeleteSizeByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceSizes, constants.ActionDelete))
 }
@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/controllers"
+	"rooming-house-cms-be/middlewares"
+	"rooming-house-cms-be/repositories"
+
+	"github.com/labstack/echo/v4"
+)
+
+func StaffRoutes(e *echo.Echo) {
+	staffRepo := repositories.NewStaffRepository(config.DB)
+	payrollRepo := repositories.NewPayrollRepository(config.DB)
+	adminRepo := repositories.NewAdminRepository(config.DB)
+	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
+
+	staffController := controllers.NewStaffController(staffRepo, payrollRepo, adminRepo, roomingHouseRepo)
+
+	staff := e.Group("/staff", middlewares.JWTAuth)
+	staff.GET("", staffController.FindAllStaff, middlewares.RequirePermission(constants.ResourcePayroll, constants.ActionRead))
+	staff.GET("/:id", staffController.FindStaffByID, middlewares.RequirePermission(constants.ResourcePayroll, constants.ActionRead))
+	staff.GET("/:id/payslips", staffController.FindStaffPayslips, middlewares.RequirePermission(constants.ResourcePayroll, constants.ActionRead))
+	staff.POST("", staffController.CreateStaff, middlewares.RequirePermission(constants.ResourcePayroll, constants.ActionWrite))
+	staff.PUT("/:id", staffController.UpdateStaffByID, middlewares.RequirePermission(constants.ResourcePayroll, constants.ActionWrite))
+	staff.DELETE("/:id", staffController.DeleteStaffByID, middlewares.RequirePermission(constants.ResourcePayroll, constants.ActionDelete))
+}
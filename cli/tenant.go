@@ -2,6 +2,7 @@ package cli
 
 import (
 	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/controllers"
 	"rooming-house-cms-be/middlewares"
 	"rooming-house-cms-be/repositories"
@@ -14,12 +15,19 @@ func TenantRoutes(e *echo.Echo) {
 	tenantAdditionalRepo := repositories.NewTenantAdditionalRepository(config.DB)
 	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
 	roomRepo := repositories.NewRoomRepository(config.DB)
+	tenantVehicleRepo := repositories.NewTenantVehicleRepository(config.DB)
+	tenantBlacklistRepo := repositories.NewTenantBlacklistRepository(config.DB)
+	tenantAccountRepo := repositories.NewTenantAccountRepository(config.DB)
+	sessionRepo := repositories.NewSessionRepository(config.DB)
 
-	tenantController := controllers.NewTenantController(tenantRepo, tenantAdditionalRepo, roomingHouseRepo, roomRepo)
+	tenantController := controllers.NewTenantController(tenantRepo, tenantAdditionalRepo, roomingHouseRepo, roomRepo, tenantVehicleRepo, tenantBlacklistRepo, tenantAccountRepo, sessionRepo)
 
 	tenant := e.Group("/tenants", middlewares.JWTAuth)
-	tenant.POST("", tenantController.CreateTenant)
-	tenant.GET("", tenantController.FindAllTenants)
-	tenant.GET("/:id", tenantController.FindTenantByID)
-	tenant.DELETE("/:id", tenantController.DeleteTenantByID)
+	tenant.POST("", tenantController.CreateTenant, middlewares.RequirePermission(constants.ResourceTenants, constants.ActionWrite))
+	tenant.GET("", tenantController.FindAllTenants, middlewares.RequirePermission(constants.ResourceTenants, constants.ActionRead))
+	tenant.GET("/:id", tenantController.FindTenantByID, middlewares.RequirePermission(constants.ResourceTenants, constants.ActionRead))
+	tenant.PUT("/:id", tenantController.UpdateTenantByID, middlewares.RequirePermission(constants.ResourceTenants, constants.ActionWrite))
+	tenant.DELETE("/:id", tenantController.DeleteTenantByID, middlewares.RequirePermission(constants.ResourceTenants, constants.ActionDelete))
+	tenant.POST("/:id/account", tenantController.CreateTenantAccount, middlewares.RequirePermission(constants.ResourceTenants, constants.ActionWrite))
+	tenant.DELETE("/:id/account", tenantController.DeleteTenantAccount, middlewares.RequirePermission(constants.ResourceTenants, constants.ActionDelete))
 }
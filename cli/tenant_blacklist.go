@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/controllers"
+	"rooming-house-cms-be/middlewares"
+	"rooming-house-cms-be/repositories"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TenantBlacklistRoutes(e *echo.Echo) {
+	tenantBlacklistRepo := repositories.NewTenantBlacklistRepository(config.DB)
+	tenantRepo := repositories.NewTenantRepository(config.DB)
+	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
+
+	tenantBlacklistController := controllers.NewTenantBlacklistController(tenantBlacklistRepo, tenantRepo, roomingHouseRepo)
+
+	blacklist := e.Group("/blacklists", middlewares.JWTAuth)
+	blacklist.GET("", tenantBlacklistController.FindAllTenantBlacklists, middlewares.RequirePermission(constants.ResourceBlacklist, constants.ActionRead))
+	blacklist.GET("/check", tenantBlacklistController.CheckTenant, middlewares.RequirePermission(constants.ResourceBlacklist, constants.ActionRead))
+	blacklist.GET("/overrides", tenantBlacklistController.FindAllScreeningOverrides, middlewares.RequirePermission(constants.ResourceBlacklist, constants.ActionOverride))
+	blacklist.GET("/:id", tenantBlacklistController.FindTenantBlacklistByID, middlewares.RequirePermission(constants.ResourceBlacklist, constants.ActionRead))
+	blacklist.POST("", tenantBlacklistController.CreateTenantBlacklist, middlewares.RequirePermission(constants.ResourceBlacklist, constants.ActionWrite))
+	blacklist.DELETE("/:id", tenantBlacklistController.DeleteTenantBlacklistByID, middlewares.RequirePermission(constants.ResourceBlacklist, constants.ActionDelete))
+}
@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/controllers"
+	"rooming-house-cms-be/middlewares"
+	"rooming-house-cms-be/repositories"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TenantPortalRoutes(e *echo.Echo) {
+	tenantRepo := repositories.NewTenantRepository(config.DB)
+	roomRepo := repositories.NewRoomRepository(config.DB)
+	transactionRepo := repositories.NewTransactionRepository(config.DB)
+	periodRepo := repositories.NewPeriodRepository(config.DB)
+	periodPackageRepo := repositories.NewPeriodPackageRepository(config.DB)
+
+	tenantPortalController := controllers.NewTenantPortalController(tenantRepo, roomRepo, transactionRepo, periodRepo, periodPackageRepo)
+
+	portal := e.Group("/portal", middlewares.TenantJWTAuth)
+	portal.GET("/profile", tenantPortalController.GetProfile)
+	portal.GET("/room", tenantPortalController.GetRoom)
+	portal.GET("/charges", tenantPortalController.GetCharges)
+	portal.GET("/transactions", tenantPortalController.GetTransactions)
+	portal.GET("/transactions/:id/receipt", tenantPortalController.GetReceipt)
+}
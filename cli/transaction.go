@@ -2,6 +2,7 @@ package cli
 
 import (
 	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/controllers"
 	"rooming-house-cms-be/middlewares"
 	"rooming-house-cms-be/repositories"
@@ -17,11 +18,13 @@ func TransactionRoutes(e *echo.Echo) {
 	periodPackageRepo := repositories.NewPeriodPackageRepository(config.DB)
 	periodRepo := repositories.NewPeriodRepository(config.DB)
 	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
+	roomingHouseOwnerRepo := repositories.NewRoomingHouseOwnerRepository(config.DB)
 
-	transactionController := controllers.NewTransactionController(transactionRepo, transactionCategoryRepo, tenantRepo, periodPackageRepo, periodRepo, roomRepo, roomingHouseRepo)
+	transactionController := controllers.NewTransactionController(transactionRepo, transactionCategoryRepo, tenantRepo, periodPackageRepo, periodRepo, roomRepo, roomingHouseRepo, roomingHouseOwnerRepo)
 
 	transaction := e.Group("/transactions")
-	transaction.POST("", transactionController.CreateTransaction, middlewares.JWTAuth)
-	transaction.GET("", transactionController.FindAllTransactions, middlewares.JWTAuth)
-	transaction.GET("/dashboard", transactionController.Dashboard, middlewares.JWTAuth)
+	transaction.POST("", transactionController.CreateTransaction, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceTransactions, constants.ActionWrite))
+	transaction.GET("", transactionController.FindAllTransactions, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceTransactions, constants.ActionRead))
+	transaction.GET("/dashboard", transactionController.Dashboard, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceTransactions, constants.ActionRead))
+	transaction.GET("/profit-loss", transactionController.ProfitLoss, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceTransactions, constants.ActionRead))
 }
@@ -2,7 +2,9 @@ package cli
 
 import (
 	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/controllers"
+	"rooming-house-cms-be/middlewares"
 	"rooming-house-cms-be/repositories"
 
 	"github.com/labstack/echo/v4"
@@ -11,12 +13,14 @@ import (
 func TransactionCategoryRoutes(e *echo.Echo) {
 	transactionCategoryRepo := repositories.NewTransactionCategoryRepository(config.DB)
 
-	transactionCategoryController := controllers.NewTransactionCategoryController(transactionCategoryRepo)
+	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
+
+	transactionCategoryController := controllers.NewTransactionCategoryController(transactionCategoryRepo, roomingHouseRepo)
 
 	transactionCategory := e.Group("/transaction-categories")
-	transactionCategory.POST("", transactionCategoryController.CreateTransactionCategory)
-	transactionCategory.GET("/:id", transactionCategoryController.FindTransactionCategoryByID)
-	transactionCategory.GET("", transactionCategoryController.FindAllTransactionCategories)
-	transactionCategory.PUT("/:id", transactionCategoryController.UpdateTransactionCategoryByID)
-	transactionCategory.DELETE("/:id", transactionCategoryController.DeleteTransactionCategoryByID)
+	transactionCategory.POST("", transactionCategoryController.CreateTransactionCategory, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceTransactionCategories, constants.ActionWrite))
+	transactionCategory.GET("/:id", transactionCategoryController.FindTransactionCategoryByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceTransactionCategories, constants.ActionRead))
+	transactionCategory.GET("", transactionCategoryController.FindAllTransactionCategories, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceTransactionCategories, constants.ActionRead))
+	transactionCategory.PUT("/:id", transactionCategoryController.UpdateTransactionCategoryByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceTransactionCategories, constants.ActionWrite))
+	transactionCategory.DELETE("/:id", transactionCategoryController.DeleteTransactionCategoryByID, middlewares.JWTAuth, middlewares.RequirePermission(constants.ResourceTransactionCategories, constants.ActionDelete))
 }
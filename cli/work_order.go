@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/controllers"
+	"rooming-house-cms-be/middlewares"
+	"rooming-house-cms-be/repositories"
+
+	"github.com/labstack/echo/v4"
+)
+
+func WorkOrderRoutes(e *echo.Echo) {
+	workOrderRepo := repositories.NewWorkOrderRepository(config.DB)
+	roomingHouseRepo := repositories.NewRoomingHouseRepository(config.DB)
+	roomRepo := repositories.NewRoomRepository(config.DB)
+	roomingHouseFacilityRepo := repositories.NewRoomingHouseFacilityRepository(config.DB)
+	transactionCategoryRepo := repositories.NewTransactionCategoryRepository(config.DB)
+	tenantRepo := repositories.NewTenantRepository(config.DB)
+	maintenanceScheduleRepo := repositories.NewMaintenanceScheduleRepository(config.DB)
+
+	workOrderController := controllers.NewWorkOrderController(workOrderRepo, roomingHouseRepo, roomRepo, roomingHouseFacilityRepo, transactionCategoryRepo, tenantRepo, maintenanceScheduleRepo)
+
+	workOrder := e.Group("/work-orders", middlewares.JWTAuth)
+	workOrder.POST("", workOrderController.CreateWorkOrder, middlewares.RequirePermission(constants.ResourceWorkOrders, constants.ActionWrite))
+	workOrder.GET("", workOrderController.FindAllWorkOrders, middlewares.RequirePermission(constants.ResourceWorkOrders, constants.ActionRead))
+	workOrder.GET("/:id", workOrderController.FindWorkOrderByID, middlewares.RequirePermission(constants.ResourceWorkOrders, constants.ActionRead))
+	workOrder.PUT("/:id", workOrderController.UpdateWorkOrderByID, middlewares.RequirePermission(constants.ResourceWorkOrders, constants.ActionWrite))
+	workOrder.PUT("/:id/status", workOrderController.UpdateWorkOrderStatus, middlewares.RequirePermission(constants.ResourceWorkOrders, constants.ActionWrite))
+	workOrder.DELETE("/:id", workOrderController.DeleteWorkOrderByID, middlewares.RequirePermission(constants.ResourceWorkOrders, constants.ActionDelete))
+
+	portal := e.Group("/portal/work-orders", middlewares.TenantJWTAuth)
+	portal.POST("", workOrderController.ReportWorkOrder)
+	portal.GET("", workOrderController.FindTenantWorkOrders)
+}
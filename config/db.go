@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"rooming-house-cms-be/audit"
 	"rooming-house-cms-be/models"
 
 	"gorm.io/driver/mysql"
@@ -32,7 +33,11 @@ func InitDB() {
 	DB.AutoMigrate(
 		&models.Owner{},
 		&models.RoomingHouse{},
+		&models.RoomingHouseOwner{},
 		&models.Admin{},
+		&models.AdminRoomingHouse{},
+		&models.Role{},
+		&models.RolePermission{},
 		&models.PricingPackage{},
 		&models.Period{},
 		&models.PeriodPackage{},
@@ -47,7 +52,43 @@ func InitDB() {
 		&models.AdditionalPeriod{},
 		&models.Tenant{},
 		&models.TenantAdditionalPrice{},
+		&models.Attachment{},
+		&models.TenantVehicle{},
+		&models.TenantBlacklist{},
+		&models.TenantScreeningOverride{},
+		&models.TenantAccount{},
+		&models.WorkOrder{},
+		&models.RoomStatusHistory{},
+		&models.MaintenanceSchedule{},
+		&models.MaintenanceLog{},
+		&models.FacilityAsset{},
+		&models.FacilityAssetMovement{},
+		&models.Session{},
+		&models.PasswordReset{},
+		&models.PasswordResetRequest{},
+		&models.TwoFactor{},
+		&models.TwoFactorRecoveryCode{},
+		&models.LoginChallenge{},
+		&models.LoginAttempt{},
+		&models.Invitation{},
+		&models.AuditLog{},
+		&models.ManagementFee{},
+		&models.Settlement{},
+		&models.SettlementLine{},
+		&models.SettlementPayout{},
+		&models.Staff{},
+		&models.StaffPayComponent{},
+		&models.PayrollRun{},
+		&models.Payslip{},
+		&models.PayslipItem{},
+		&models.RecurringExpense{},
+		&models.RecurringExpenseDraft{},
+		&models.Budget{},
 	)
 
+	if err := audit.Register(DB); err != nil {
+		log.Fatal("Failed to register audit callbacks: ", err)
+	}
+
 	log.Println("Success connecting to DB")
 }
@@ -0,0 +1,24 @@
+package constants
+
+const (
+	AttachmentEntityTenant      = "tenant"
+	AttachmentEntityRoom        = "room"
+	AttachmentEntityTransaction = "transaction"
+	AttachmentEntityBlacklist   = "blacklist"
+)
+
+// AttachmentMaxSize is the largest file accepted by the attachment upload endpoint (5 MB).
+const AttachmentMaxSize = 5 << 20
+
+var AttachmentEntityTables = map[string]string{
+	AttachmentEntityTenant:      "tenants",
+	AttachmentEntityRoom:        "rooms",
+	AttachmentEntityTransaction: "transactions",
+	AttachmentEntityBlacklist:   "tenant_blacklists",
+}
+
+var AttachmentAllowedMimeTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
+}
@@ -0,0 +1,18 @@
+package constants
+
+// Actions recorded in the audit log.
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// AuditSystemRole is recorded as the actor role of writes made outside a
+// request, such as seeders and background jobs.
+const AuditSystemRole = "system"
+
+// AuditDefaultLimit and AuditMaxLimit bound the entries returned by GET /audit.
+const (
+	AuditDefaultLimit = 100
+	AuditMaxLimit     = 500
+)
@@ -0,0 +1,60 @@
+package constants
+
+import "time"
+
+// AccessTokenTTL is how long an access token is accepted by JWTAuth.
+const AccessTokenTTL = 15 * time.Minute
+
+// RefreshTokenTTL is how long a session can go without being refreshed.
+const RefreshTokenTTL = 30 * 24 * time.Hour
+
+// RoomingHouseHeader selects which assigned rooming house an admin request works on.
+const RoomingHouseHeader = "X-Rooming-House-ID"
+
+// LoginAttemptWindow is how far back failed logins count towards throttling.
+const LoginAttemptWindow = 30 * time.Minute
+
+// LoginFreeAttempts is how many failed logins of an account are allowed
+// before each further attempt has to wait.
+const LoginFreeAttempts = 3
+
+// LoginDelayBase is the wait after the first failure past LoginFreeAttempts.
+// It doubles with every further failure up to LoginDelayMax.
+const LoginDelayBase = 2 * time.Second
+
+// LoginDelayMax caps the progressive wait between failed logins.
+const LoginDelayMax = 5 * time.Minute
+
+// LoginLockoutThreshold is how many failed logins lock an account.
+const LoginLockoutThreshold = 10
+
+// LoginIPLockoutThreshold is how many failed logins from one IP address,
+// across all accounts, lock that address out.
+const LoginIPLockoutThreshold = 50
+
+// LoginLockoutDuration is how long a locked account or IP address has to wait.
+const LoginLockoutDuration = 15 * time.Minute
+
+// Login failure reasons recorded in the login history
+const (
+	LoginFailureInvalidCredentials = "invalid_credentials"
+	LoginFailureInvalidCode        = "invalid_two_factor_code"
+	LoginFailureThrottled          = "throttled"
+	LoginFailureDisabled           = "disabled"
+)
+
+// PasswordResetRequestWindow is how far back password reset requests count
+// towards throttling.
+const PasswordResetRequestWindow = time.Hour
+
+// PasswordResetAccountLimit is how many password resets an account may
+// request within PasswordResetRequestWindow.
+const PasswordResetAccountLimit = 3
+
+// PasswordResetIPLimit is how many password resets one IP address may
+// request, across all accounts, within PasswordResetRequestWindow.
+const PasswordResetIPLimit = 20
+
+// DummyPasswordHash is compared against when the email is unknown so a failed
+// login takes as long whether or not the account exists.
+const DummyPasswordHash = "$2a$14$JNUWELhPfv/0eu8fRRlsf.JSEGGVJUWlf/p7oj0DUt10ZoeTN97yS"
@@ -0,0 +1,5 @@
+package constants
+
+// BudgetDefaultAlertThreshold is the share of a budget, in percent, that
+// spending has to reach before it is flagged when no threshold is set.
+const BudgetDefaultAlertThreshold = 100.0
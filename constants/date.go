@@ -0,0 +1,4 @@
+package constants
+
+// DateLayout is the format used for date-only fields in request bodies and query params.
+const DateLayout = "2006-01-02"
@@ -0,0 +1,15 @@
+package constants
+
+const (
+	AssetConditionGood   = "good"
+	AssetConditionFair   = "fair"
+	AssetConditionPoor   = "poor"
+	AssetConditionBroken = "broken"
+)
+
+var AssetConditions = map[string]bool{
+	AssetConditionGood:   true,
+	AssetConditionFair:   true,
+	AssetConditionPoor:   true,
+	AssetConditionBroken: true,
+}
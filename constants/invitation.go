@@ -0,0 +1,10 @@
+package constants
+
+import "time"
+
+// InvitationTTL is how long a staff invitation can be accepted.
+const InvitationTTL = 7 * 24 * time.Hour
+
+// DefaultInvitationURL is the accept page linked from invitation emails when
+// INVITATION_URL is not set.
+const DefaultInvitationURL = "http://localhost:3000/invitations/accept"
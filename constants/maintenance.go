@@ -0,0 +1,12 @@
+package constants
+
+import "time"
+
+// MaintenanceJobInterval is how often due maintenance schedules are turned into work orders.
+const MaintenanceJobInterval = time.Hour
+
+// MaintenanceUpcomingDays is the default look-ahead window of the upcoming maintenance list.
+const MaintenanceUpcomingDays = 30
+
+// MaintenanceReporterRole marks work orders generated by the maintenance job.
+const MaintenanceReporterRole = "system"
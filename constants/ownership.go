@@ -0,0 +1,8 @@
+package constants
+
+// OwnershipTotalPercentage is what the ownership shares of a rooming house add up to.
+const OwnershipTotalPercentage = 100
+
+// OwnershipPercentageTolerance absorbs rounding when shares are summed, such
+// as three owners of 33.33% each.
+const OwnershipPercentageTolerance = 0.01
@@ -0,0 +1,9 @@
+package constants
+
+import "time"
+
+// PasswordMinLength is the shortest password accepted by change and reset.
+const PasswordMinLength = 8
+
+// PasswordResetTTL is how long a password reset token can be used.
+const PasswordResetTTL = time.Hour
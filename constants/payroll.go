@@ -0,0 +1,20 @@
+package constants
+
+// Kinds of a staff member's recurring pay components.
+const (
+	PayComponentAllowance = "allowance"
+	PayComponentDeduction = "deduction"
+)
+
+// PayComponentKinds are the accepted pay component kinds.
+var PayComponentKinds = map[string]bool{
+	PayComponentAllowance: true,
+	PayComponentDeduction: true,
+}
+
+// Statuses of a payroll run. A draft run can be deleted and generated again;
+// an approved run has posted its Salary transactions and is final.
+const (
+	PayrollStatusDraft    = "draft"
+	PayrollStatusApproved = "approved"
+)
@@ -0,0 +1,16 @@
+package constants
+
+const (
+	PeriodUnitDay   = "day"
+	PeriodUnitWeek  = "week"
+	PeriodUnitMonth = "month"
+	PeriodUnitYear  = "year"
+)
+
+// PeriodUnits are the units understood by utils.AddPeriod.
+var PeriodUnits = map[string]bool{
+	PeriodUnitDay:   true,
+	PeriodUnitWeek:  true,
+	PeriodUnitMonth: true,
+	PeriodUnitYear:  true,
+}
@@ -0,0 +1,162 @@
+package constants
+
+// Resources that permissions are granted on.
+const (
+	ResourceRoomingHouses         = "rooming_houses"
+	ResourceRooms                 = "rooms"
+	ResourceSizes                 = "sizes"
+	ResourcePricing               = "pricing"
+	ResourcePeriods               = "periods"
+	ResourceFacilities            = "facilities"
+	ResourceTenants               = "tenants"
+	ResourceBlacklist             = "blacklist"
+	ResourceTransactions          = "transactions"
+	ResourceTransactionCategories = "transaction_categories"
+	ResourceAttachments           = "attachments"
+	ResourceWorkOrders            = "work_orders"
+	ResourceMaintenance           = "maintenance"
+	ResourceAssets                = "assets"
+	ResourceAdmins                = "admins"
+	ResourceRoles                 = "roles"
+	ResourceAudit                 = "audit"
+	ResourceSettlements           = "settlements"
+	ResourcePayroll               = "payroll"
+	ResourceRecurringExpenses     = "recurring_expenses"
+	ResourceBudgets               = "budgets"
+)
+
+// Actions that can be granted on a resource. Write covers create and update.
+const (
+	ActionRead     = "read"
+	ActionWrite    = "write"
+	ActionDelete   = "delete"
+	ActionOverride = "override"
+	ActionApprove  = "approve"
+)
+
+// PermissionActions lists the actions that exist for every resource.
+var PermissionActions = map[string][]string{
+	ResourceRoomingHouses:         {ActionRead, ActionWrite, ActionDelete},
+	ResourceRooms:                 {ActionRead, ActionWrite, ActionDelete},
+	ResourceSizes:                 {ActionRead, ActionWrite, ActionDelete},
+	ResourcePricing:               {ActionRead, ActionWrite, ActionDelete},
+	ResourcePeriods:               {ActionRead, ActionWrite, ActionDelete},
+	ResourceFacilities:            {ActionRead, ActionWrite, ActionDelete},
+	ResourceTenants:               {ActionRead, ActionWrite, ActionDelete},
+	ResourceBlacklist:             {ActionRead, ActionWrite, ActionDelete, ActionOverride},
+	ResourceTransactions:          {ActionRead, ActionWrite},
+	ResourceTransactionCategories: {ActionRead, ActionWrite, ActionDelete},
+	ResourceAttachments:           {ActionRead, ActionWrite, ActionDelete},
+	ResourceWorkOrders:            {ActionRead, ActionWrite, ActionDelete},
+	ResourceMaintenance:           {ActionRead, ActionWrite, ActionDelete},
+	ResourceAssets:                {ActionRead, ActionWrite, ActionDelete},
+	ResourceAdmins:                {ActionRead, ActionWrite, ActionDelete},
+	ResourceRoles:                 {ActionRead, ActionWrite, ActionDelete},
+	ResourceAudit:                 {ActionRead},
+	ResourceSettlements:           {ActionRead, ActionWrite, ActionDelete},
+	ResourcePayroll:               {ActionRead, ActionWrite, ActionDelete, ActionApprove},
+	ResourceRecurringExpenses:     {ActionRead, ActionWrite, ActionDelete},
+	ResourceBudgets:               {ActionRead, ActionWrite, ActionDelete},
+}
+
+// OwnerOnlyPermissions cannot be granted to a role. They cover the owner's
+// catalogues and staff management, which are shared by all rooming houses.
+var OwnerOnlyPermissions = map[string]bool{
+	Permission(ResourceRoomingHouses, ActionWrite):  true,
+	Permission(ResourceRoomingHouses, ActionDelete): true,
+	Permission(ResourcePeriods, ActionWrite):        true,
+	Permission(ResourcePeriods, ActionDelete):       true,
+	Permission(ResourceFacilities, ActionWrite):     true,
+	Permission(ResourceFacilities, ActionDelete):    true,
+	Permission(ResourceAdmins, ActionRead):          true,
+	Permission(ResourceAdmins, ActionWrite):         true,
+	Permission(ResourceAdmins, ActionDelete):        true,
+	Permission(ResourceRoles, ActionRead):           true,
+	Permission(ResourceRoles, ActionWrite):          true,
+	Permission(ResourceRoles, ActionDelete):         true,
+	Permission(ResourceAudit, ActionRead):           true,
+}
+
+// Codes of the built-in roles every owner can assign.
+const (
+	RoleCodeManager   = "manager"
+	RoleCodeCashier   = "cashier"
+	RoleCodeFrontDesk = "front_desk"
+	RoleCodeViewer    = "viewer"
+)
+
+// SystemRoleNames maps each built-in role code to its display name.
+var SystemRoleNames = map[string]string{
+	RoleCodeManager:   "Manager",
+	RoleCodeCashier:   "Cashier",
+	RoleCodeFrontDesk: "Front Desk",
+	RoleCodeViewer:    "Viewer",
+}
+
+// SystemRolePermissions are the grants of the built-in roles. Manager matches
+// what admins could do before roles existed and is the default for new staff.
+var SystemRolePermissions = map[string][]string{
+	RoleCodeManager: {
+		Permission(ResourceRoomingHouses, ActionRead),
+		Permission(ResourceRooms, ActionRead), Permission(ResourceRooms, ActionWrite),
+		Permission(ResourceSizes, ActionRead),
+		Permission(ResourcePricing, ActionRead),
+		Permission(ResourcePeriods, ActionRead),
+		Permission(ResourceFacilities, ActionRead),
+		Permission(ResourceTenants, ActionRead), Permission(ResourceTenants, ActionWrite), Permission(ResourceTenants, ActionDelete),
+		Permission(ResourceBlacklist, ActionRead),
+		Permission(ResourceTransactions, ActionRead), Permission(ResourceTransactions, ActionWrite),
+		Permission(ResourceTransactionCategories, ActionRead), Permission(ResourceTransactionCategories, ActionWrite), Permission(ResourceTransactionCategories, ActionDelete),
+		Permission(ResourceAttachments, ActionRead), Permission(ResourceAttachments, ActionWrite), Permission(ResourceAttachments, ActionDelete),
+		Permission(ResourceWorkOrders, ActionRead), Permission(ResourceWorkOrders, ActionWrite),
+		Permission(ResourceMaintenance, ActionRead), Permission(ResourceMaintenance, ActionWrite),
+		Permission(ResourceAssets, ActionRead), Permission(ResourceAssets, ActionWrite),
+		Permission(ResourceSettlements, ActionRead), Permission(ResourceSettlements, ActionWrite),
+		Permission(ResourcePayroll, ActionRead), Permission(ResourcePayroll, ActionWrite),
+		Permission(ResourceRecurringExpenses, ActionRead), Permission(ResourceRecurringExpenses, ActionWrite), Permission(ResourceRecurringExpenses, ActionDelete),
+		Permission(ResourceBudgets, ActionRead),
+	},
+	RoleCodeCashier: {
+		Permission(ResourceRoomingHouses, ActionRead),
+		Permission(ResourceTransactions, ActionRead), Permission(ResourceTransactions, ActionWrite),
+		Permission(ResourceTransactionCategories, ActionRead),
+		Permission(ResourceRecurringExpenses, ActionRead),
+	},
+	RoleCodeFrontDesk: {
+		Permission(ResourceRoomingHouses, ActionRead),
+		Permission(ResourceRooms, ActionRead), Permission(ResourceRooms, ActionWrite),
+		Permission(ResourceSizes, ActionRead),
+		Permission(ResourcePricing, ActionRead),
+		Permission(ResourcePeriods, ActionRead),
+		Permission(ResourceFacilities, ActionRead),
+		Permission(ResourceTenants, ActionRead), Permission(ResourceTenants, ActionWrite),
+		Permission(ResourceBlacklist, ActionRead),
+		Permission(ResourceAttachments, ActionRead), Permission(ResourceAttachments, ActionWrite),
+		Permission(ResourceWorkOrders, ActionRead), Permission(ResourceWorkOrders, ActionWrite),
+	},
+	RoleCodeViewer: {
+		Permission(ResourceRoomingHouses, ActionRead),
+		Permission(ResourceRooms, ActionRead),
+		Permission(ResourceSizes, ActionRead),
+		Permission(ResourcePricing, ActionRead),
+		Permission(ResourcePeriods, ActionRead),
+		Permission(ResourceFacilities, ActionRead),
+		Permission(ResourceTenants, ActionRead),
+		Permission(ResourceBlacklist, ActionRead),
+		Permission(ResourceTransactions, ActionRead),
+		Permission(ResourceTransactionCategories, ActionRead),
+		Permission(ResourceAttachments, ActionRead),
+		Permission(ResourceWorkOrders, ActionRead),
+		Permission(ResourceMaintenance, ActionRead),
+		Permission(ResourceAssets, ActionRead),
+		Permission(ResourceSettlements, ActionRead),
+		Permission(ResourceRecurringExpenses, ActionRead),
+		Permission(ResourceBudgets, ActionRead),
+	},
+}
+
+// Permission joins a resource and an action into the "resource:action" form
+// used in role bodies and permission checks.
+func Permission(resource string, action string) string {
+	return resource + ":" + action
+}
@@ -0,0 +1,21 @@
+package constants
+
+import "time"
+
+// RecurringExpenseJobInterval is how often due recurring expenses are turned into drafts.
+const RecurringExpenseJobInterval = time.Hour
+
+// Statuses of a recurring expense draft. A draft is confirmed into a
+// transaction, or dismissed when the expense did not happen that month.
+const (
+	RecurringExpenseDraftPending   = "draft"
+	RecurringExpenseDraftConfirmed = "confirmed"
+	RecurringExpenseDraftDismissed = "dismissed"
+)
+
+// RecurringExpenseDraftStatuses are the accepted draft statuses.
+var RecurringExpenseDraftStatuses = map[string]bool{
+	RecurringExpenseDraftPending:   true,
+	RecurringExpenseDraftConfirmed: true,
+	RecurringExpenseDraftDismissed: true,
+}
@@ -0,0 +1,21 @@
+package constants
+
+const (
+	RoomStatusAvailable    = "available"
+	RoomStatusReserved     = "reserved"
+	RoomStatusMaintenance  = "maintenance"
+	RoomStatusOutOfService = "out_of_service"
+)
+
+var RoomStatuses = map[string]bool{
+	RoomStatusAvailable:    true,
+	RoomStatusReserved:     true,
+	RoomStatusMaintenance:  true,
+	RoomStatusOutOfService: true,
+}
+
+// RoomUnassignableStatuses are the statuses under which no tenant may be placed in a room.
+var RoomUnassignableStatuses = map[string]bool{
+	RoomStatusMaintenance:  true,
+	RoomStatusOutOfService: true,
+}
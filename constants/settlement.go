@@ -0,0 +1,14 @@
+package constants
+
+// Management fee types of a rooming house. A percentage fee is taken from the
+// month's income; a fixed fee is the same amount every month.
+const (
+	ManagementFeePercentage = "percentage"
+	ManagementFeeFixed      = "fixed"
+)
+
+// ManagementFeeTypes are the accepted management fee types.
+var ManagementFeeTypes = map[string]bool{
+	ManagementFeePercentage: true,
+	ManagementFeeFixed:      true,
+}
@@ -0,0 +1,4 @@
+package constants
+
+// TenantUpcomingChargeCount is how many future billing cycles the tenant portal lists.
+const TenantUpcomingChargeCount = 3
@@ -0,0 +1,22 @@
+package constants
+
+// Codes of the system transaction categories that CreateTransaction handles
+// specially, or that other modules post transactions to.
+const (
+	TransactionCategoryCodeRent           = "rent"
+	TransactionCategoryCodeDeposit        = "deposit"
+	TransactionCategoryCodeDepositPayback = "deposit_payback"
+	TransactionCategoryCodeSalary         = "salary"
+)
+
+// DepositTransactionCategoryCodes are the categories of tenant deposits. The
+// money is held for the tenant, so it is neither income nor expense of the house.
+var DepositTransactionCategoryCodes = []string{TransactionCategoryCodeDeposit, TransactionCategoryCodeDepositPayback}
+
+// SystemTransactionCategoryNames maps each system category code to the name it was seeded with.
+var SystemTransactionCategoryNames = map[string]string{
+	TransactionCategoryCodeRent:           "Rent",
+	TransactionCategoryCodeDeposit:        "Deposit",
+	TransactionCategoryCodeDepositPayback: "Deposit Payback",
+	TransactionCategoryCodeSalary:         "Salary",
+}
@@ -0,0 +1,27 @@
+package constants
+
+import "time"
+
+// TOTPIssuer is the account issuer shown in authenticator apps.
+const TOTPIssuer = "Rooming House CMS"
+
+// TOTPSecretLength is the number of random bytes in a TOTP secret.
+const TOTPSecretLength = 20
+
+// TOTPDigits is the length of a TOTP code.
+const TOTPDigits = 6
+
+// TOTPPeriod is how long a TOTP code is valid.
+const TOTPPeriod = 30 * time.Second
+
+// TOTPSkew is how many periods of clock drift are accepted either way.
+const TOTPSkew = 1
+
+// RecoveryCodeCount is how many recovery codes are issued at a time.
+const RecoveryCodeCount = 10
+
+// LoginChallengeTTL is how long the second login step can be completed.
+const LoginChallengeTTL = 5 * time.Minute
+
+// LoginChallengeMaxAttempts is how many wrong codes end a login challenge.
+const LoginChallengeMaxAttempts = 5
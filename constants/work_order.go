@@ -0,0 +1,30 @@
+package constants
+
+const (
+	WorkOrderStatusOpen       = "open"
+	WorkOrderStatusAssigned   = "assigned"
+	WorkOrderStatusInProgress = "in_progress"
+	WorkOrderStatusDone       = "done"
+)
+
+const (
+	WorkOrderPriorityLow    = "low"
+	WorkOrderPriorityMedium = "medium"
+	WorkOrderPriorityHigh   = "high"
+	WorkOrderPriorityUrgent = "urgent"
+)
+
+var WorkOrderPriorities = map[string]bool{
+	WorkOrderPriorityLow:    true,
+	WorkOrderPriorityMedium: true,
+	WorkOrderPriorityHigh:   true,
+	WorkOrderPriorityUrgent: true,
+}
+
+// WorkOrderTransitions lists the statuses a work order may move to from each status.
+var WorkOrderTransitions = map[string][]string{
+	WorkOrderStatusOpen:       {WorkOrderStatusAssigned, WorkOrderStatusInProgress},
+	WorkOrderStatusAssigned:   {WorkOrderStatusOpen, WorkOrderStatusInProgress},
+	WorkOrderStatusInProgress: {WorkOrderStatusAssigned, WorkOrderStatusDone},
+	WorkOrderStatusDone:       {},
+}
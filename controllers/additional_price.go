@@ -34,20 +34,18 @@ func (apc *AdditionalPriceController) CreateAdditionalPrice(c echo.Context) erro
 		return utils.HandlerError(c, utils.NewBadRequestError("name is required"))
 	}
 
-	if additionalPriceBody.MonthlyPrice == 0 {
-		return utils.HandlerError(c, utils.NewBadRequestError("monthly price is required"))
+	roomingHouseID, apiErr := targetRoomingHouseID(userPayload, additionalPriceBody.RoomingHouseID)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
-	if additionalPriceBody.AnnualPrice == 0 {
-		return utils.HandlerError(c, utils.NewBadRequestError("annual price is required"))
+	ownerID, err := resolveOwnerID(apc.roomingHouseRepo, userPayload, roomingHouseID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get rooming house"))
 	}
 
-	var roomingHouseID uuid.UUID
-
-	if userPayload.Role == "owner" {
-		roomingHouseID = additionalPriceBody.RoomingHouseID
-	} else {
-		roomingHouseID = userPayload.RoomingHouseID
+	if apiErr := validatePeriodPrices(apc.periodRepo, additionalPriceBody.Prices, ownerID); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
 	newAdditionalPrice := models.AdditionalPrice{
@@ -55,54 +53,20 @@ func (apc *AdditionalPriceController) CreateAdditionalPrice(c echo.Context) erro
 		RoomingHouseID: roomingHouseID,
 	}
 
-	if err := apc.additionalPriceRepo.CreateAdditionalPrice(&newAdditionalPrice); err != nil {
+	if err := apc.additionalPriceRepo.CreateAdditionalPrice(c.Request().Context(), &newAdditionalPrice); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("failed to create additional price"))
 	}
 
-	daily, err := apc.periodRepo.FindPeriodByName("Daily")
-	if err != nil {
-		return utils.HandlerError(c, utils.NewNotFoundError("failed to find daily period"))
-	}
-
-	weekly, err := apc.periodRepo.FindPeriodByName("Weekly")
-	if err != nil {
-		return utils.HandlerError(c, utils.NewNotFoundError("failed to find weekly period"))
-	}
-
-	monthly, err := apc.periodRepo.FindPeriodByName("Monthly")
-	if err != nil {
-		return utils.HandlerError(c, utils.NewNotFoundError("failed to find monthly period"))
-	}
-
-	annual, err := apc.periodRepo.FindPeriodByName("Annually")
-	if err != nil {
-		return utils.HandlerError(c, utils.NewNotFoundError("failed to find annual period"))
-	}
-
-	additionalPeriods := []models.AdditionalPeriod{
-		{
-			PeriodID:          daily.ID,
-			AdditionalPriceID: newAdditionalPrice.ID,
-			Price:             additionalPriceBody.DailyPrice,
-		},
-		{
-			PeriodID:          weekly.ID,
+	var additionalPeriods []models.AdditionalPeriod
+	for periodID, price := range additionalPriceBody.Prices {
+		additionalPeriods = append(additionalPeriods, models.AdditionalPeriod{
+			PeriodID:          periodID,
 			AdditionalPriceID: newAdditionalPrice.ID,
-			Price:             additionalPriceBody.WeeklyPrice,
-		},
-		{
-			PeriodID:          monthly.ID,
-			AdditionalPriceID: newAdditionalPrice.ID,
-			Price:             additionalPriceBody.MonthlyPrice,
-		},
-		{
-			PeriodID:          annual.ID,
-			AdditionalPriceID: newAdditionalPrice.ID,
-			Price:             additionalPriceBody.AnnualPrice,
-		},
+			Price:             price,
+		})
 	}
 
-	if err := apc.additionalPeriodRepo.CreateAdditionalPeriod(&additionalPeriods); err != nil {
+	if err := apc.additionalPeriodRepo.CreateAdditionalPeriod(c.Request().Context(), &additionalPeriods); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("failed to create additional period"))
 	}
 
@@ -126,20 +90,9 @@ func (apc *AdditionalPriceController) FindAdditionalPriceByID(c echo.Context) er
 func (apc *AdditionalPriceController) FindAllAdditionalPrices(c echo.Context) error {
 	userPayload := c.Get("userPayload").(*models.JWTPayload)
 
-	var roomingHouseIDs []uuid.UUID
-
-	if userPayload.Role == "admin" {
-		roomingHouseIDs = append(roomingHouseIDs, userPayload.RoomingHouseID)
-	} else {
-		IDs, err := apc.roomingHouseRepo.FindAllRoomingHouse(userPayload.RoomingHouseID, userPayload.UserID, userPayload.Role)
-
-		if err != nil {
-			return utils.HandlerError(c, utils.NewNotFoundError("rooming houses not found"))
-		}
-
-		for _, roomingHouseID := range IDs {
-			roomingHouseIDs = append(roomingHouseIDs, roomingHouseID.ID)
-		}
+	roomingHouseIDs, apiErr := scopedRoomingHouseIDs(apc.roomingHouseRepo, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
 	additionalPrices, err := apc.additionalPriceRepo.FindAllAdditionalPrices(roomingHouseIDs)
@@ -151,6 +104,8 @@ func (apc *AdditionalPriceController) FindAllAdditionalPrices(c echo.Context) er
 }
 
 func (apc *AdditionalPriceController) UpdateAdditionalPriceByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("invalid id"))
@@ -166,66 +121,38 @@ func (apc *AdditionalPriceController) UpdateAdditionalPriceByID(c echo.Context)
 		return utils.HandlerError(c, utils.NewBadRequestError("name is required"))
 	}
 
-	if additionalPriceBody.MonthlyPrice == 0 {
-		return utils.HandlerError(c, utils.NewBadRequestError("monthly price is required"))
-	}
-
-	if additionalPriceBody.AnnualPrice == 0 {
-		return utils.HandlerError(c, utils.NewBadRequestError("annual price is required"))
+	additionalPrice, apiErr := apc.findWritableAdditionalPrice(userPayload, id)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
-	updatedAdditionalPrice := models.AdditionalPrice{
-		Name: additionalPriceBody.Name,
-	}
-
-	if err := apc.additionalPriceRepo.UpdateAdditionalPriceByID(&updatedAdditionalPrice, id); err != nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("failed to update additional price"))
-	}
-
-	daily, err := apc.periodRepo.FindPeriodByName("Daily")
+	ownerID, err := resolveOwnerID(apc.roomingHouseRepo, userPayload, additionalPrice.RoomingHouse.ID)
 	if err != nil {
-		return utils.HandlerError(c, utils.NewNotFoundError("failed to find daily period"))
+		return utils.HandlerError(c, utils.NewInternalError("failed to get rooming house"))
 	}
 
-	weekly, err := apc.periodRepo.FindPeriodByName("Weekly")
-	if err != nil {
-		return utils.HandlerError(c, utils.NewNotFoundError("failed to find weekly period"))
+	if apiErr := validatePeriodPrices(apc.periodRepo, additionalPriceBody.Prices, ownerID); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
-	monthly, err := apc.periodRepo.FindPeriodByName("Monthly")
-	if err != nil {
-		return utils.HandlerError(c, utils.NewNotFoundError("failed to find monthly period"))
+	updatedAdditionalPrice := models.AdditionalPrice{
+		Name: additionalPriceBody.Name,
 	}
 
-	annually, err := apc.periodRepo.FindPeriodByName("Annually")
-	if err != nil {
-		return utils.HandlerError(c, utils.NewNotFoundError("failed to find annual period"))
+	if err := apc.additionalPriceRepo.UpdateAdditionalPriceByID(c.Request().Context(), &updatedAdditionalPrice, id); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("failed to update additional price"))
 	}
 
-	additionalPeriods := []models.AdditionalPeriod{
-		{
-			PeriodID:          daily.ID,
-			AdditionalPriceID: id,
-			Price:             additionalPriceBody.DailyPrice,
-		},
-		{
-			PeriodID:          weekly.ID,
-			AdditionalPriceID: id,
-			Price:             additionalPriceBody.WeeklyPrice,
-		},
-		{
-			PeriodID:          monthly.ID,
+	var additionalPeriods []models.AdditionalPeriod
+	for periodID, price := range additionalPriceBody.Prices {
+		additionalPeriods = append(additionalPeriods, models.AdditionalPeriod{
+			PeriodID:          periodID,
 			AdditionalPriceID: id,
-			Price:             additionalPriceBody.MonthlyPrice,
-		},
-		{
-			PeriodID:          annually.ID,
-			AdditionalPriceID: id,
-			Price:             additionalPriceBody.AnnualPrice,
-		},
+			Price:             price,
+		})
 	}
 
-	if err := apc.additionalPeriodRepo.UpdateAdditionalPeriod(&additionalPeriods, id); err != nil {
+	if err := apc.additionalPeriodRepo.UpdateAdditionalPeriod(c.Request().Context(), &additionalPeriods, id); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("failed to update additional period"))
 	}
 
@@ -233,15 +160,31 @@ func (apc *AdditionalPriceController) UpdateAdditionalPriceByID(c echo.Context)
 }
 
 func (apc *AdditionalPriceController) DeleteAdditionalPriceByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("invalid id"))
 	}
 
-	if err := apc.additionalPriceRepo.DeleteAdditionalPriceByID(id); err != nil {
+	if _, apiErr := apc.findWritableAdditionalPrice(userPayload, id); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if err := apc.additionalPriceRepo.DeleteAdditionalPriceByID(c.Request().Context(), id); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("failed to delete additional price"))
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "additional price deleted"})
 
 }
+
+// findWritableAdditionalPrice loads the additional price if it belongs to a
+// rooming house the request may change.
+func (apc *AdditionalPriceController) findWritableAdditionalPrice(userPayload *models.JWTPayload, id uuid.UUID) (*models.AdditionalPriceResponse, *utils.APIError) {
+	additionalPrice, err := apc.additionalPriceRepo.FindAdditionalPriceByID(id)
+	if err != nil || !canAccessRoomingHouse(apc.roomingHouseRepo, userPayload, additionalPrice.RoomingHouse.ID) {
+		return nil, utils.NewNotFoundError("additional price not found")
+	}
+	return additionalPrice, nil
+}
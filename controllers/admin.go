@@ -2,21 +2,29 @@ package controllers
 
 import (
 	"net/http"
+	"net/mail"
+	"rooming-house-cms-be/mailer"
 	"rooming-house-cms-be/models"
 	"rooming-house-cms-be/repositories"
 	"rooming-house-cms-be/utils"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
 type AdminController struct {
-	adminRepo        repositories.AdminRepository
-	roomingHouseRepo repositories.RoomingHouseRepository
+	adminRepo         repositories.AdminRepository
+	roomingHouseRepo  repositories.RoomingHouseRepository
+	sessionRepo       repositories.SessionRepository
+	roleRepo          repositories.RoleRepository
+	passwordResetRepo repositories.PasswordResetRepository
+	mailer            mailer.Mailer
 }
 
-func NewAdminController(adminRepo repositories.AdminRepository, roomingHouseRepo repositories.RoomingHouseRepository) *AdminController {
-	return &AdminController{adminRepo: adminRepo, roomingHouseRepo: roomingHouseRepo}
+func NewAdminController(adminRepo repositories.AdminRepository, roomingHouseRepo repositories.RoomingHouseRepository, sessionRepo repositories.SessionRepository, roleRepo repositories.RoleRepository, passwordResetRepo repositories.PasswordResetRepository, mailer mailer.Mailer) *AdminController {
+	return &AdminController{adminRepo: adminRepo, roomingHouseRepo: roomingHouseRepo, sessionRepo: sessionRepo, roleRepo: roleRepo, passwordResetRepo: passwordResetRepo, mailer: mailer}
 }
 
 func (ac *AdminController) GetAllAdmin(c echo.Context) error {
@@ -25,7 +33,7 @@ func (ac *AdminController) GetAllAdmin(c echo.Context) error {
 	var roomingHouseIDs []uuid.UUID
 
 	if userPayload.RoomingHouseID == uuid.Nil {
-		roomingHouses, err := ac.roomingHouseRepo.FindAllRoomingHouse(userPayload.RoomingHouseID, userPayload.UserID, userPayload.Role)
+		roomingHouses, err := ac.roomingHouseRepo.FindAllRoomingHouse(userPayload.UserID, userPayload.Role)
 		if err != nil {
 			return utils.HandlerError(c, utils.NewInternalError(err.Error()))
 		}
@@ -45,20 +53,362 @@ func (ac *AdminController) GetAllAdmin(c echo.Context) error {
 
 func (ac *AdminController) DeleteAdminByID(c echo.Context) error {
 	userPayload := c.Get("userPayload").(*models.JWTPayload)
-	adminID := c.Param("id")
 
-	if userPayload.Role != "owner" {
-		return utils.HandlerError(c, utils.NewForbiddenError("you are not allowed to access this resource"))
+	admin, _, apiErr := ac.findOwnedAdmin(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
-	adminUUID, err := uuid.Parse(adminID)
-	if err != nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("invalid admin id"))
+	if err := ac.adminRepo.DeleteAdminByID(c.Request().Context(), admin.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError(err.Error()))
 	}
 
-	if err := ac.adminRepo.DeleteAdminByID(adminUUID); err != nil {
-		return utils.HandlerError(c, utils.NewInternalError(err.Error()))
+	if err := ac.sessionRepo.RevokeSessionsByUserID(admin.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to revoke admin sessions"))
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "admin deleted successfully"})
 }
+
+func (ac *AdminController) UpdateAdminByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var adminBody models.UpdateAdminBody
+
+	if err := c.Bind(&adminBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	admin, _, apiErr := ac.findOwnedAdmin(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if adminBody.FullName != "" {
+		admin.FullName = adminBody.FullName
+	}
+
+	if adminBody.Username != "" {
+		admin.Username = adminBody.Username
+	}
+
+	if adminBody.Email != "" {
+		email := strings.ToLower(strings.TrimSpace(adminBody.Email))
+		if _, err := mail.ParseAddress(email); err != nil {
+			return utils.HandlerError(c, utils.NewBadRequestError("invalid email"))
+		}
+
+		if existing, err := ac.adminRepo.FindAdminByEmail(email); err == nil && existing.ID != admin.ID {
+			return utils.HandlerError(c, utils.NewConflictError("email is already used by another admin"))
+		}
+
+		admin.Email = email
+	}
+
+	if err := ac.adminRepo.UpdateAdmin(c.Request().Context(), admin); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to update admin"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "admin updated successfully"})
+}
+
+// DisableAdmin locks the admin out without deleting it. Its sessions end
+// immediately and it cannot login until enabled again.
+func (ac *AdminController) DisableAdmin(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	admin, _, apiErr := ac.findOwnedAdmin(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if admin.DisabledAt != nil {
+		return utils.HandlerError(c, utils.NewConflictError("admin is already disabled"))
+	}
+
+	now := time.Now()
+	if err := ac.adminRepo.SetAdminDisabled(c.Request().Context(), admin.ID, &now); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to disable admin"))
+	}
+
+	if err := ac.sessionRepo.RevokeSessionsByUserID(admin.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to revoke admin sessions"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "admin disabled"})
+}
+
+func (ac *AdminController) EnableAdmin(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	admin, _, apiErr := ac.findOwnedAdmin(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if admin.DisabledAt == nil {
+		return utils.HandlerError(c, utils.NewConflictError("admin is not disabled"))
+	}
+
+	if err := ac.adminRepo.SetAdminDisabled(c.Request().Context(), admin.ID, nil); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to enable admin"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "admin enabled"})
+}
+
+// ResetAdminPassword emails the admin a password reset token and ends its
+// sessions, for an admin who lost their password or may be compromised.
+func (ac *AdminController) ResetAdminPassword(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	admin, _, apiErr := ac.findOwnedAdmin(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	adminCredential := &credential{UserID: admin.ID, Email: admin.Email, PasswordHash: admin.Password}
+	if err := sendPasswordReset(ac.passwordResetRepo, ac.mailer, adminCredential, "admin", "The owner of your rooming house has requested a password reset for your account."); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to send reset email"))
+	}
+
+	if err := ac.sessionRepo.RevokeSessionsByUserID(admin.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to revoke admin sessions"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "password reset email sent to admin"})
+}
+
+func (ac *AdminController) AssignAdminRoomingHouse(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var assignBody models.AssignAdminRoomingHouseBody
+
+	if err := c.Bind(&assignBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if assignBody.RoomingHouseID == uuid.Nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house id is required"))
+	}
+
+	admin, roomingHouseIDs, apiErr := ac.findOwnedAdmin(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if apiErr := ac.checkPrimaryOwner(userPayload, assignBody.RoomingHouseID); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	for _, roomingHouseID := range roomingHouseIDs {
+		if roomingHouseID == assignBody.RoomingHouseID {
+			return utils.HandlerError(c, utils.NewConflictError("admin is already assigned to this rooming house"))
+		}
+	}
+
+	role, apiErr := resolveAssignableRole(ac.roleRepo, assignBody.RoleID, userPayload.UserID)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if err := ac.adminRepo.AssignAdminRoomingHouse(c.Request().Context(), &models.AdminRoomingHouse{AdminID: admin.ID, RoomingHouseID: assignBody.RoomingHouseID, RoleID: &role.ID}); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to assign admin"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "admin assigned to rooming house"})
+}
+
+func (ac *AdminController) UpdateAdminRoomingHouseRole(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var roleBody models.UpdateAdminRoleBody
+
+	if err := c.Bind(&roleBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if roleBody.RoleID == uuid.Nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("role id is required"))
+	}
+
+	roomingHouseID, err := uuid.Parse(c.Param("roomingHouseID"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid rooming house id"))
+	}
+
+	admin, roomingHouseIDs, apiErr := ac.findOwnedAdmin(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	assigned := false
+	for _, id := range roomingHouseIDs {
+		if id == roomingHouseID {
+			assigned = true
+			break
+		}
+	}
+
+	if !assigned {
+		return utils.HandlerError(c, utils.NewNotFoundError("admin is not assigned to this rooming house"))
+	}
+
+	role, apiErr := resolveAssignableRole(ac.roleRepo, &roleBody.RoleID, userPayload.UserID)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if err := ac.adminRepo.UpdateAdminRoomingHouseRole(c.Request().Context(), admin.ID, roomingHouseID, role.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to update admin role"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "admin role updated"})
+}
+
+func (ac *AdminController) UnassignAdminRoomingHouse(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	roomingHouseID, err := uuid.Parse(c.Param("roomingHouseID"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid rooming house id"))
+	}
+
+	admin, roomingHouseIDs, apiErr := ac.findOwnedAdmin(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	assigned := false
+	for _, id := range roomingHouseIDs {
+		if id == roomingHouseID {
+			assigned = true
+			break
+		}
+	}
+
+	if !assigned {
+		return utils.HandlerError(c, utils.NewNotFoundError("admin is not assigned to this rooming house"))
+	}
+
+	if len(roomingHouseIDs) == 1 {
+		return utils.HandlerError(c, utils.NewConflictError("admin must keep at least one rooming house, delete the admin instead"))
+	}
+
+	if err := ac.adminRepo.UnassignAdminRoomingHouse(c.Request().Context(), admin.ID, roomingHouseID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to unassign admin"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "admin unassigned from rooming house"})
+}
+
+// MoveAdminRoomingHouse moves the admin from one of its rooming houses to
+// another house of the owner. The admin keeps its role there unless a new one
+// is given. With a single assignment the from house can be left out.
+func (ac *AdminController) MoveAdminRoomingHouse(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var moveBody models.MoveAdminBody
+
+	if err := c.Bind(&moveBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if moveBody.ToRoomingHouseID == uuid.Nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("to rooming house id is required"))
+	}
+
+	admin, roomingHouseIDs, apiErr := ac.findOwnedAdmin(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if moveBody.FromRoomingHouseID == uuid.Nil {
+		if len(roomingHouseIDs) > 1 {
+			return utils.HandlerError(c, utils.NewBadRequestError("from rooming house id is required for an admin of several rooming houses"))
+		}
+		moveBody.FromRoomingHouseID = roomingHouseIDs[0]
+	}
+
+	assigned := false
+	for _, id := range roomingHouseIDs {
+		if id == moveBody.ToRoomingHouseID {
+			return utils.HandlerError(c, utils.NewConflictError("admin is already assigned to this rooming house"))
+		}
+		if id == moveBody.FromRoomingHouseID {
+			assigned = true
+		}
+	}
+
+	if !assigned {
+		return utils.HandlerError(c, utils.NewNotFoundError("admin is not assigned to the from rooming house"))
+	}
+
+	if apiErr := ac.checkPrimaryOwner(userPayload, moveBody.ToRoomingHouseID); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	roleID := moveBody.RoleID
+	if roleID == nil || *roleID == uuid.Nil {
+		assignment, err := ac.adminRepo.FindAdminRoomingHouse(admin.ID, moveBody.FromRoomingHouseID)
+		if err != nil {
+			return utils.HandlerError(c, utils.NewInternalError("failed to get admin role"))
+		}
+		roleID = assignment.RoleID
+	}
+
+	role, apiErr := resolveAssignableRole(ac.roleRepo, roleID, userPayload.UserID)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if err := ac.adminRepo.MoveAdminRoomingHouse(c.Request().Context(), admin.ID, moveBody.FromRoomingHouseID, moveBody.ToRoomingHouseID, role.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to move admin"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "admin moved to rooming house"})
+}
+
+// findOwnedAdmin loads the admin from the id param together with its assigned
+// rooming houses. The admin belongs to the owner of those houses, so the user
+// must be the primary owner of every one of them.
+func (ac *AdminController) findOwnedAdmin(c echo.Context, userPayload *models.JWTPayload) (*models.Admin, []uuid.UUID, *utils.APIError) {
+	adminID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, nil, utils.NewBadRequestError("invalid admin id")
+	}
+
+	admin, err := ac.adminRepo.FindAdminByID(adminID)
+	if err != nil {
+		return nil, nil, utils.NewNotFoundError("admin not found")
+	}
+
+	roomingHouseIDs, err := ac.adminRepo.FindAdminRoomingHouseIDs(admin.ID)
+	if err != nil {
+		return nil, nil, utils.NewInternalError("failed to get admin rooming houses")
+	}
+
+	if len(roomingHouseIDs) == 0 {
+		return nil, nil, utils.NewNotFoundError("admin not found")
+	}
+
+	for _, roomingHouseID := range roomingHouseIDs {
+		if ac.checkPrimaryOwner(userPayload, roomingHouseID) != nil {
+			return nil, nil, utils.NewNotFoundError("admin not found")
+		}
+	}
+
+	return admin, roomingHouseIDs, nil
+}
+
+// checkPrimaryOwner makes sure the user is the primary owner of the rooming
+// house. Like invitations, admins are managed by primary owners only, so a
+// co-owner cannot change the admins or roles of the primary owner.
+func (ac *AdminController) checkPrimaryOwner(userPayload *models.JWTPayload, roomingHouseID uuid.UUID) *utils.APIError {
+	roomingHouse, err := ac.roomingHouseRepo.FindRoomingHouseByID(roomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
+	if err != nil {
+		return utils.NewBadRequestError("rooming house not found")
+	}
+
+	if roomingHouse.OwnerID != userPayload.UserID {
+		return utils.NewForbiddenError("only the primary owner can manage the admins of this rooming house")
+	}
+
+	return nil
+}
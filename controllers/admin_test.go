@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// fakeAdminRepository keeps admins and their rooming houses in memory.
+type fakeAdminRepository struct {
+	repositories.AdminRepository
+	roomingHouseIDs map[uuid.UUID][]uuid.UUID
+	deleted         []uuid.UUID
+	assigned        []models.AdminRoomingHouse
+}
+
+func (r *fakeAdminRepository) FindAdminByID(id uuid.UUID) (*models.Admin, error) {
+	if _, ok := r.roomingHouseIDs[id]; !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	admin := &models.Admin{}
+	admin.ID = id
+	return admin, nil
+}
+
+func (r *fakeAdminRepository) FindAdminRoomingHouseIDs(adminID uuid.UUID) ([]uuid.UUID, error) {
+	return r.roomingHouseIDs[adminID], nil
+}
+
+func (r *fakeAdminRepository) DeleteAdminByID(_ context.Context, id uuid.UUID) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+func (r *fakeAdminRepository) AssignAdminRoomingHouse(_ context.Context, adminRoomingHouse *models.AdminRoomingHouse) error {
+	r.assigned = append(r.assigned, *adminRoomingHouse)
+	return nil
+}
+
+func TestAdminsAreManagedByThePrimaryOwner(t *testing.T) {
+	co := newCoOwnership()
+	primaryOwner := &models.JWTPayload{UserID: co.primaryOwnerID, Role: "owner"}
+	coOwner := &models.JWTPayload{UserID: co.coOwnerID, Role: "owner"}
+	otherOwner := &models.JWTPayload{UserID: co.otherOwnerID, Role: "owner"}
+
+	sharedAdminID := uuid.New()
+	otherAdminID := uuid.New()
+	bothAdminID := uuid.New()
+
+	tests := []struct {
+		name        string
+		userPayload *models.JWTPayload
+		adminID     uuid.UUID
+		wantStatus  int
+	}{
+		{name: "primary owner deletes the admin of the shared house", userPayload: primaryOwner, adminID: sharedAdminID, wantStatus: http.StatusOK},
+		{name: "co-owner cannot delete the primary owner's admin", userPayload: coOwner, adminID: sharedAdminID, wantStatus: http.StatusNotFound},
+		{name: "co-owner with houses of their own cannot either", userPayload: otherOwner, adminID: sharedAdminID, wantStatus: http.StatusNotFound},
+		{name: "owner deletes the admin of their own house", userPayload: otherOwner, adminID: otherAdminID, wantStatus: http.StatusOK},
+		{name: "admin of several owners' houses needs every one", userPayload: otherOwner, adminID: bothAdminID, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adminRepo := &fakeAdminRepository{roomingHouseIDs: map[uuid.UUID][]uuid.UUID{
+				sharedAdminID: {co.sharedHouseID},
+				otherAdminID:  {co.otherHouseID},
+				bothAdminID:   {co.otherHouseID, co.sharedHouseID},
+			}}
+			ac := NewAdminController(adminRepo, co.roomingHouses, &fakeSessionRepository{}, nil, nil, nil)
+
+			rec := serveAs(tt.userPayload, ac.DeleteAdminByID, http.MethodDelete, "/admins/"+tt.adminID.String(), "", tt.adminID.String())
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			wantDeleted := tt.wantStatus == http.StatusOK
+			if deleted := len(adminRepo.deleted) == 1; deleted != wantDeleted {
+				t.Errorf("admin deleted = %v, want %v", deleted, wantDeleted)
+			}
+		})
+	}
+
+	t.Run("owner cannot assign their admin to a co-owned house", func(t *testing.T) {
+		adminRepo := &fakeAdminRepository{roomingHouseIDs: map[uuid.UUID][]uuid.UUID{otherAdminID: {co.otherHouseID}}}
+		ac := NewAdminController(adminRepo, co.roomingHouses, &fakeSessionRepository{}, nil, nil, nil)
+
+		rec := serveAs(otherOwner, ac.AssignAdminRoomingHouse, http.MethodPost, "/admins/"+otherAdminID.String()+"/rooming-houses", `{"rooming_house_id":"`+co.sharedHouseID.String()+`"}`, otherAdminID.String())
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("status %d, want 403: %s", rec.Code, rec.Body.String())
+		}
+		if len(adminRepo.assigned) != 0 {
+			t.Errorf("admin was assigned to the co-owned house")
+		}
+	})
+}
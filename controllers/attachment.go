@@ -0,0 +1,222 @@
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/storage"
+	"rooming-house-cms-be/utils"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type AttachmentController struct {
+	attachmentRepo   repositories.AttachmentRepository
+	roomingHouseRepo repositories.RoomingHouseRepository
+	storage          storage.Storage
+}
+
+func NewAttachmentController(attachmentRepo repositories.AttachmentRepository, roomingHouseRepo repositories.RoomingHouseRepository, storage storage.Storage) *AttachmentController {
+	return &AttachmentController{attachmentRepo: attachmentRepo, roomingHouseRepo: roomingHouseRepo, storage: storage}
+}
+
+func (ac *AttachmentController) UploadAttachment(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	entityType := c.FormValue("entity_type")
+	if _, ok := constants.AttachmentEntityTables[entityType]; !ok {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid entity type"))
+	}
+
+	entityID, err := uuid.Parse(c.FormValue("entity_id"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid entity id"))
+	}
+
+	roomingHouseID, apiErr := ac.authorizeEntity(userPayload, entityType, entityID)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("file is required"))
+	}
+
+	if fileHeader.Size == 0 {
+		return utils.HandlerError(c, utils.NewBadRequestError("file is empty"))
+	}
+
+	if fileHeader.Size > constants.AttachmentMaxSize {
+		return utils.HandlerError(c, utils.NewBadRequestError(fmt.Sprintf("file exceeds maximum size of %d MB", constants.AttachmentMaxSize>>20)))
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("failed to read file"))
+	}
+	defer file.Close()
+
+	// Detect the type from the content itself rather than trusting the client header
+	sniff := make([]byte, 512)
+	n, err := file.Read(sniff)
+	if err != nil && err != io.EOF {
+		return utils.HandlerError(c, utils.NewBadRequestError("failed to read file"))
+	}
+
+	mimeType := http.DetectContentType(sniff[:n])
+	extension, ok := constants.AttachmentAllowedMimeTypes[mimeType]
+	if !ok {
+		return utils.HandlerError(c, utils.NewBadRequestError("file type is not allowed"))
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to read file"))
+	}
+
+	storageKey := fmt.Sprintf("%s/%s/%s/%s%s", roomingHouseID, entityType, entityID, uuid.New(), extension)
+
+	if err := ac.storage.Save(storageKey, io.LimitReader(file, constants.AttachmentMaxSize)); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to store file"))
+	}
+
+	newAttachment := models.Attachment{
+		EntityType:     entityType,
+		EntityID:       entityID,
+		RoomingHouseID: roomingHouseID,
+		FileName:       fileHeader.Filename,
+		MimeType:       mimeType,
+		Size:           fileHeader.Size,
+		Description:    c.FormValue("description"),
+		StorageKey:     storageKey,
+		UploadedBy:     userPayload.UserID,
+	}
+
+	if err := ac.attachmentRepo.CreateAttachment(c.Request().Context(), &newAttachment); err != nil {
+		ac.storage.Delete(storageKey)
+		return utils.HandlerError(c, utils.NewInternalError("failed to create attachment"))
+	}
+
+	return c.JSON(http.StatusCreated, models.AttachmentResponse{
+		ID:             newAttachment.ID,
+		EntityType:     newAttachment.EntityType,
+		EntityID:       newAttachment.EntityID,
+		RoomingHouseID: newAttachment.RoomingHouseID,
+		FileName:       newAttachment.FileName,
+		MimeType:       newAttachment.MimeType,
+		Size:           newAttachment.Size,
+		Description:    newAttachment.Description,
+		UploadedBy:     newAttachment.UploadedBy,
+		CreatedAt:      newAttachment.CreatedAt,
+	})
+}
+
+func (ac *AttachmentController) FindAllAttachments(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	entityType := c.QueryParam("entity_type")
+	if _, ok := constants.AttachmentEntityTables[entityType]; !ok {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid entity type"))
+	}
+
+	entityID, err := uuid.Parse(c.QueryParam("entity_id"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid entity id"))
+	}
+
+	if _, apiErr := ac.authorizeEntity(userPayload, entityType, entityID); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	attachments, err := ac.attachmentRepo.FindAllAttachments(entityType, entityID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get attachments"))
+	}
+
+	return c.JSON(http.StatusOK, attachments)
+}
+
+func (ac *AttachmentController) DownloadAttachment(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	attachment, apiErr := ac.findAuthorizedAttachment(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	file, err := ac.storage.Open(attachment.StorageKey)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewNotFoundError("file not found"))
+	}
+	defer file.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", attachment.FileName))
+
+	return c.Stream(http.StatusOK, attachment.MimeType, file)
+}
+
+func (ac *AttachmentController) DeleteAttachmentByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	attachment, apiErr := ac.findAuthorizedAttachment(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if err := ac.attachmentRepo.DeleteAttachmentByID(c.Request().Context(), attachment.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to delete attachment"))
+	}
+
+	if err := ac.storage.Delete(attachment.StorageKey); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to delete file"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "attachment deleted"})
+}
+
+func (ac *AttachmentController) findAuthorizedAttachment(c echo.Context, userPayload *models.JWTPayload) (*models.Attachment, *utils.APIError) {
+	attachmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, utils.NewBadRequestError("invalid attachment id")
+	}
+
+	attachment, err := ac.attachmentRepo.FindAttachmentByID(attachmentID)
+	if err != nil {
+		return nil, utils.NewNotFoundError("attachment not found")
+	}
+
+	if apiErr := ac.authorizeRoomingHouse(userPayload, attachment.RoomingHouseID); apiErr != nil {
+		return nil, utils.NewNotFoundError("attachment not found")
+	}
+
+	return attachment, nil
+}
+
+func (ac *AttachmentController) authorizeEntity(userPayload *models.JWTPayload, entityType string, entityID uuid.UUID) (uuid.UUID, *utils.APIError) {
+	roomingHouseID, err := ac.attachmentRepo.FindEntityRoomingHouseID(entityType, entityID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return uuid.Nil, utils.NewNotFoundError(entityType + " not found")
+		}
+		return uuid.Nil, utils.NewInternalError("failed to get " + entityType)
+	}
+
+	if apiErr := ac.authorizeRoomingHouse(userPayload, roomingHouseID); apiErr != nil {
+		return uuid.Nil, utils.NewNotFoundError(entityType + " not found")
+	}
+
+	return roomingHouseID, nil
+}
+
+func (ac *AttachmentController) authorizeRoomingHouse(userPayload *models.JWTPayload, roomingHouseID uuid.UUID) *utils.APIError {
+	if !canAccessRoomingHouse(ac.roomingHouseRepo, userPayload, roomingHouseID) {
+		return utils.NewForbiddenError("you are not allowed to access this rooming house")
+	}
+
+	return nil
+}
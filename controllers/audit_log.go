@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"net/http"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/utils"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AuditLogController struct {
+	auditLogRepo     repositories.AuditLogRepository
+	loginAttemptRepo repositories.LoginAttemptRepository
+}
+
+func NewAuditLogController(auditLogRepo repositories.AuditLogRepository, loginAttemptRepo repositories.LoginAttemptRepository) *AuditLogController {
+	return &AuditLogController{auditLogRepo: auditLogRepo, loginAttemptRepo: loginAttemptRepo}
+}
+
+// FindAllAuditLogs lists the owner's audit trail, newest first. It can be
+// narrowed by entity_type, entity_id, user_id, rooming_house_id, action and a
+// from/to date range (both inclusive).
+func (alc *AuditLogController) FindAllAuditLogs(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	filter := models.AuditLogFilter{
+		EntityType: c.QueryParam("entity_type"),
+		EntityID:   c.QueryParam("entity_id"),
+		Action:     c.QueryParam("action"),
+	}
+
+	var apiErr *utils.APIError
+	if filter.ActorID, apiErr = parseOptionalUUIDParam(c, "user_id"); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if filter.RoomingHouseID, apiErr = parseOptionalUUIDParam(c, "rooming_house_id"); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if filter.Action != "" && filter.Action != constants.AuditActionCreate && filter.Action != constants.AuditActionUpdate && filter.Action != constants.AuditActionDelete {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid action"))
+	}
+
+	if filter.From, filter.To, filter.Limit, apiErr = parseAuditWindow(c); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	auditLogs, err := alc.auditLogRepo.FindAllAuditLogs(userPayload.UserID, filter)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get audit logs"))
+	}
+
+	return c.JSON(http.StatusOK, auditLogs)
+}
+
+// FindAllLoginAttempts lists the logins of the owner and their admins, newest
+// first. It can be narrowed by user_id, success and a from/to date range.
+func (alc *AuditLogController) FindAllLoginAttempts(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	filter := models.LoginAttemptFilter{}
+
+	var apiErr *utils.APIError
+	if filter.UserID, apiErr = parseOptionalUUIDParam(c, "user_id"); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if successParam := c.QueryParam("success"); successParam != "" {
+		success, err := strconv.ParseBool(successParam)
+		if err != nil {
+			return utils.HandlerError(c, utils.NewBadRequestError("invalid success"))
+		}
+		filter.Success = &success
+	}
+
+	if filter.From, filter.To, filter.Limit, apiErr = parseAuditWindow(c); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	loginAttempts, err := alc.loginAttemptRepo.FindAllLoginAttempts(userPayload.UserID, filter)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get login history"))
+	}
+
+	return c.JSON(http.StatusOK, loginAttempts)
+}
+
+// parseAuditWindow reads the from/to date range and the limit shared by the
+// audit listings. The returned to is exclusive, the day after the given date.
+func parseAuditWindow(c echo.Context) (*time.Time, *time.Time, int, *utils.APIError) {
+	var from, to *time.Time
+	limit := constants.AuditDefaultLimit
+
+	if fromParam := c.QueryParam("from"); fromParam != "" {
+		parsedFrom, err := time.ParseInLocation(constants.DateLayout, fromParam, time.Local)
+		if err != nil {
+			return nil, nil, 0, utils.NewBadRequestError("invalid from date")
+		}
+		from = &parsedFrom
+	}
+
+	if toParam := c.QueryParam("to"); toParam != "" {
+		parsedTo, err := time.ParseInLocation(constants.DateLayout, toParam, time.Local)
+		if err != nil {
+			return nil, nil, 0, utils.NewBadRequestError("invalid to date")
+		}
+		parsedTo = parsedTo.AddDate(0, 0, 1)
+		to = &parsedTo
+	}
+
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, 0, utils.NewBadRequestError("from must not be after to")
+	}
+
+	if limitParam := c.QueryParam("limit"); limitParam != "" {
+		parsedLimit, err := strconv.Atoi(limitParam)
+		if err != nil || parsedLimit <= 0 || parsedLimit > constants.AuditMaxLimit {
+			return nil, nil, 0, utils.NewBadRequestError("limit must be between 1 and " + strconv.Itoa(constants.AuditMaxLimit))
+		}
+		limit = parsedLimit
+	}
+
+	return from, to, limit, nil
+}
@@ -1,25 +1,40 @@
 package controllers
 
 import (
+	"math"
 	"net/http"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/mailer"
 	"rooming-house-cms-be/middlewares"
 	"rooming-house-cms-be/models"
 	"rooming-house-cms-be/repositories"
 	"rooming-house-cms-be/utils"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 type UserController struct {
-	ownerRepo        repositories.OwnerRepository
-	adminRepo        repositories.AdminRepository
-	roomingHouseRepo repositories.RoomingHouseRepository
+	ownerRepo         repositories.OwnerRepository
+	adminRepo         repositories.AdminRepository
+	roomingHouseRepo  repositories.RoomingHouseRepository
+	tenantAccountRepo repositories.TenantAccountRepository
+	sessionRepo       repositories.SessionRepository
+	passwordResetRepo repositories.PasswordResetRepository
+	mailer            mailer.Mailer
+	roleRepo          repositories.RoleRepository
+	twoFactorRepo     repositories.TwoFactorRepository
+	challengeRepo     repositories.LoginChallengeRepository
+	loginAttemptRepo  repositories.LoginAttemptRepository
 }
 
-func NewUserController(ownerRepo repositories.OwnerRepository, adminRepo repositories.AdminRepository, roomingHouseRepo repositories.RoomingHouseRepository) *UserController {
-	return &UserController{ownerRepo: ownerRepo, adminRepo: adminRepo, roomingHouseRepo: roomingHouseRepo}
+func NewUserController(ownerRepo repositories.OwnerRepository, adminRepo repositories.AdminRepository, roomingHouseRepo repositories.RoomingHouseRepository, tenantAccountRepo repositories.TenantAccountRepository, sessionRepo repositories.SessionRepository, passwordResetRepo repositories.PasswordResetRepository, mailer mailer.Mailer, roleRepo repositories.RoleRepository, twoFactorRepo repositories.TwoFactorRepository, challengeRepo repositories.LoginChallengeRepository, loginAttemptRepo repositories.LoginAttemptRepository) *UserController {
+	return &UserController{ownerRepo: ownerRepo, adminRepo: adminRepo, roomingHouseRepo: roomingHouseRepo, tenantAccountRepo: tenantAccountRepo, sessionRepo: sessionRepo, passwordResetRepo: passwordResetRepo, mailer: mailer, roleRepo: roleRepo, twoFactorRepo: twoFactorRepo, challengeRepo: challengeRepo, loginAttemptRepo: loginAttemptRepo}
 }
 
 func (uc *UserController) RegisterOwner(c echo.Context) error {
@@ -53,122 +68,243 @@ func (uc *UserController) RegisterOwner(c echo.Context) error {
 	}
 
 	// Create new user
-	if err := uc.ownerRepo.CreateOwner(&newOwner); err != nil {
+	if err := uc.ownerRepo.CreateOwner(c.Request().Context(), &newOwner); err != nil {
 		return utils.HandlerError(c, utils.NewInternalError("failed to create owner"))
 	}
 
 	return c.JSON(http.StatusCreated, map[string]string{"message": "success to create owner"})
 }
 
-func (uc *UserController) RegisterAdmin(c echo.Context) error {
-	userPayload := c.Get("userPayload").(*models.JWTPayload)
-	var admin models.AdminRegisterBody
-	if err := c.Bind(&admin); err != nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+func (uc *UserController) Login(c echo.Context) error {
+	var input struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+		Role     string `json:"role"`
+	}
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid input"})
 	}
 
-	if admin.FullName == "" {
-		return utils.HandlerError(c, utils.NewBadRequestError("full name is required"))
+	if input.Role != "owner" && input.Role != "admin" && input.Role != "tenant" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid role"})
 	}
 
-	if admin.Email == "" {
-		return utils.HandlerError(c, utils.NewBadRequestError("email is required"))
+	attempt := models.LoginAttempt{
+		Email:     strings.ToLower(strings.TrimSpace(input.Email)),
+		Role:      input.Role,
+		IPAddress: c.RealIP(),
+		UserAgent: c.Request().UserAgent(),
 	}
 
-	if admin.Username == "" {
-		return utils.HandlerError(c, utils.NewBadRequestError("username is required"))
+	retryAfter, err := uc.loginRetryAfter(attempt.Email, attempt.Role, attempt.IPAddress)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "could not check login attempts"})
 	}
 
-	if admin.Password == "" {
-		return utils.HandlerError(c, utils.NewBadRequestError("password is required"))
+	if retryAfter > 0 {
+		attempt.FailureReason = constants.LoginFailureThrottled
+		uc.loginAttemptRepo.CreateLoginAttempt(&attempt)
+
+		c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		return c.JSON(http.StatusTooManyRequests, map[string]string{"error": "too many failed login attempts, please try again later"})
+	}
+
+	// Unknown emails are still checked against a hash so the response does not
+	// reveal, by its message or its timing, which accounts exist
+	passwordHash := constants.DummyPasswordHash
+	userCredential, err := uc.findCredentialByEmail(input.Role, attempt.Email)
+	accountFound := err == nil
+	if accountFound {
+		passwordHash = userCredential.PasswordHash
+		attempt.UserID = &userCredential.UserID
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(input.Password)); err != nil || !accountFound {
+		attempt.FailureReason = constants.LoginFailureInvalidCredentials
+		uc.loginAttemptRepo.CreateLoginAttempt(&attempt)
+
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid email or password"})
+	}
+
+	if userCredential.Disabled {
+		attempt.FailureReason = constants.LoginFailureDisabled
+		uc.loginAttemptRepo.CreateLoginAttempt(&attempt)
+
+		return c.JSON(http.StatusForbidden, map[string]string{"error": "your account has been disabled"})
+	}
+
+	// Admin houses come from their assignments on every request and owners
+	// work on all of theirs, so only tenant tokens carry a house
+	roomingHouseID := uuid.Nil
+	if input.Role == "tenant" {
+		roomingHouseID = userCredential.RoomingHouseID
+	}
+
+	// Accounts with two-factor enabled only get a JWT from VerifyLogin
+	if input.Role == "owner" || input.Role == "admin" {
+		twoFactor, err := uc.twoFactorRepo.FindTwoFactorByUser(userCredential.UserID, input.Role)
+		if err == nil && twoFactor.EnabledAt != nil {
+			challenge, err := uc.createLoginChallenge(attempt.Email, userCredential.UserID, input.Role, roomingHouseID)
+			if err != nil {
+				return c.JSON(http.StatusInternalServerError, map[string]string{"error": "could not create login challenge"})
+			}
+			return c.JSON(http.StatusOK, challenge)
+		}
 	}
 
-	if admin.RoomingHouseID == uuid.Nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("rooming house ID is required"))
+	tokens, err := uc.createSession(userCredential.UserID, input.Role, roomingHouseID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "could not create session"})
 	}
 
-	roomingHouse, err := uc.roomingHouseRepo.FindRoomingHouseByID(admin.RoomingHouseID, userPayload.UserID, userPayload.Role)
+	attempt.Success = true
+	uc.loginAttemptRepo.CreateLoginAttempt(&attempt)
+
+	return c.JSON(http.StatusOK, tokens)
+}
+
+// loginRetryAfter returns how long the account or the IP address has to wait
+// before it may try to login again, or 0 if it may try now. Every failure past
+// the free attempts doubles the wait, and too many failures lock the account
+// or address out for a while.
+func (uc *UserController) loginRetryAfter(email string, role string, ipAddress string) (time.Duration, error) {
+	now := time.Now()
+	since := now.Add(-constants.LoginAttemptWindow)
+
+	account, err := uc.loginAttemptRepo.SummarizeAccountFailures(email, role, since)
 	if err != nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+		return 0, err
 	}
 
-	if roomingHouse.OwnerID != userPayload.UserID {
-		return utils.HandlerError(c, utils.NewUnauthorizedError("you are not the owner of this rooming house"))
+	ip, err := uc.loginAttemptRepo.SummarizeIPFailures(ipAddress, since)
+	if err != nil {
+		return 0, err
 	}
 
-	if roomingHouse.Admin.ID != uuid.Nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("rooming house already has an admin"))
+	var retryAfter time.Duration
+	if account.LastFailedAt != nil {
+		retryAfter = account.LastFailedAt.Add(loginFailureDelay(account.Failures)).Sub(now)
 	}
 
-	newAdmin := models.Admin{
-		FullName:       admin.FullName,
-		Email:          admin.Email,
-		Username:       admin.Username,
-		Password:       admin.Password,
-		Role:           "admin",
-		RoomingHouseID: admin.RoomingHouseID,
+	if ip.LastFailedAt != nil && ip.Failures >= constants.LoginIPLockoutThreshold {
+		if ipRetryAfter := ip.LastFailedAt.Add(constants.LoginLockoutDuration).Sub(now); ipRetryAfter > retryAfter {
+			retryAfter = ipRetryAfter
+		}
 	}
 
-	// Create new user
+	if retryAfter < 0 {
+		return 0, nil
+	}
+	return retryAfter, nil
+}
 
-	if err := uc.adminRepo.CreateAdmin(&newAdmin); err != nil {
-		return utils.HandlerError(c, utils.NewInternalError("failed to create admin"))
+func loginFailureDelay(failures int64) time.Duration {
+	if failures >= constants.LoginLockoutThreshold {
+		return constants.LoginLockoutDuration
 	}
 
-	return c.JSON(http.StatusCreated, map[string]string{"message": "success to create admin"})
+	if failures <= constants.LoginFreeAttempts {
+		return 0
+	}
+
+	delay := constants.LoginDelayBase << uint(failures-constants.LoginFreeAttempts-1)
+	if delay > constants.LoginDelayMax {
+		return constants.LoginDelayMax
+	}
+	return delay
 }
 
-func (uc *UserController) Login(c echo.Context) error {
-	var input struct {
-		Email    string `json:"email"`
-		Password string `json:"password"`
-		Role     string `json:"role"`
+// RefreshToken exchanges a refresh token for a new access token and a new
+// refresh token. Presenting a refresh token that was already rotated means it
+// leaked, so the whole session is revoked.
+func (uc *UserController) RefreshToken(c echo.Context) error {
+	var refreshBody models.RefreshTokenBody
+	if err := c.Bind(&refreshBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
 	}
-	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid input"})
+
+	sessionIDStr, refreshSecret, found := strings.Cut(refreshBody.RefreshToken, ".")
+	if !found || refreshSecret == "" {
+		return utils.HandlerError(c, utils.NewUnauthorizedError("invalid refresh token"))
 	}
 
-	var passwordHash string
-	var userID uuid.UUID
-	var roomingHouseID uuid.UUID
-	var err error
+	sessionID, err := uuid.Parse(sessionIDStr)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewUnauthorizedError("invalid refresh token"))
+	}
 
-	switch input.Role {
-	case "admin":
-		admin, err := uc.adminRepo.FindAdminByEmail(input.Email)
-		if err != nil {
-			return c.JSON(http.StatusUnauthorized, map[string]string{"error": "admin not found"})
-		}
-		passwordHash = admin.Password
-		userID = admin.ID
-		roomingHouseID = admin.RoomingHouseID
-	case "owner":
-		owner, err := uc.ownerRepo.FindOwnerByEmail(input.Email)
-		if err != nil {
-			return c.JSON(http.StatusUnauthorized, map[string]string{"error": "owner not found"})
-		}
-		passwordHash = owner.Password
-		userID = owner.ID
-		if len(owner.RoomingHouses) > 0 {
-			roomingHouseID = owner.RoomingHouses[0].ID
-		} else {
-			roomingHouseID = uuid.Nil
+	session, err := uc.sessionRepo.FindSessionByID(sessionID)
+	if err != nil || session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
+		return utils.HandlerError(c, utils.NewUnauthorizedError("session has expired, please login again"))
+	}
+
+	newRefreshSecret, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("could not generate token"))
+	}
+
+	expiresAt := time.Now().Add(constants.RefreshTokenTTL)
+	if err := uc.sessionRepo.RotateSession(session.ID, utils.HashToken(refreshSecret), utils.HashToken(newRefreshSecret), expiresAt); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			uc.sessionRepo.RevokeSessionByID(session.ID)
+			return utils.HandlerError(c, utils.NewUnauthorizedError("refresh token has already been used, please login again"))
 		}
-	default:
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid role"})
+		return utils.HandlerError(c, utils.NewInternalError("failed to refresh session"))
+	}
+
+	session.ExpiresAt = expiresAt
+
+	tokens, err := issueTokens(session, newRefreshSecret)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("could not generate token"))
+	}
+
+	return c.JSON(http.StatusOK, tokens)
+}
+
+func (uc *UserController) Logout(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	if err := uc.sessionRepo.RevokeSessionByID(userPayload.SessionID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to logout"))
 	}
 
-	// Verifikasi password
-	err = bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(input.Password))
+	return c.JSON(http.StatusOK, map[string]string{"message": "logged out"})
+}
+
+// createSession starts a session for a fully authenticated user and returns its tokens.
+func (uc *UserController) createSession(userID uuid.UUID, role string, roomingHouseID uuid.UUID) (*models.TokenResponse, error) {
+	refreshSecret, err := utils.GenerateRandomToken(32)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid password"})
+		return nil, err
+	}
+
+	session := models.Session{
+		UserID:           userID,
+		Role:             role,
+		RoomingHouseID:   roomingHouseID,
+		RefreshTokenHash: utils.HashToken(refreshSecret),
+		ExpiresAt:        time.Now().Add(constants.RefreshTokenTTL),
+	}
+
+	if err := uc.sessionRepo.CreateSession(&session); err != nil {
+		return nil, err
 	}
 
-	// Generate JWT
-	token, err := middlewares.GenerateJWT(userID, input.Role, roomingHouseID)
+	return issueTokens(&session, refreshSecret)
+}
+
+// issueTokens builds the login response of a session. The refresh token is
+// prefixed with the session ID so a refresh can find its session.
+func issueTokens(session *models.Session, refreshSecret string) (*models.TokenResponse, error) {
+	token, err := middlewares.GenerateJWT(session.UserID, session.Role, session.RoomingHouseID, session.ID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "could not generate token"})
+		return nil, err
 	}
 
-	return c.JSON(http.StatusOK, map[string]string{"token": token})
+	return &models.TokenResponse{
+		Token:        token,
+		RefreshToken: session.ID.String() + "." + refreshSecret,
+		ExpiresIn:    int64(constants.AccessTokenTTL.Seconds()),
+	}, nil
 }
@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/utils"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// fakeSessionRepository keeps sessions in memory, with the same conditions as
+// the database queries.
+type fakeSessionRepository struct {
+	repositories.SessionRepository
+	sessions map[uuid.UUID]*models.Session
+}
+
+func (r *fakeSessionRepository) FindSessionByID(id uuid.UUID) (*models.Session, error) {
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (r *fakeSessionRepository) RotateSession(id uuid.UUID, currentHash string, newHash string, expiresAt time.Time) error {
+	session, ok := r.sessions[id]
+	if !ok || session.RefreshTokenHash != currentHash || session.RevokedAt != nil {
+		return gorm.ErrRecordNotFound
+	}
+	session.RefreshTokenHash = newHash
+	session.ExpiresAt = expiresAt
+	return nil
+}
+
+func (r *fakeSessionRepository) RevokeSessionByID(id uuid.UUID) error {
+	if session, ok := r.sessions[id]; ok && session.RevokedAt == nil {
+		now := time.Now()
+		session.RevokedAt = &now
+	}
+	return nil
+}
+
+func (r *fakeSessionRepository) RevokeSessionsByUserID(userID uuid.UUID) error {
+	for id, session := range r.sessions {
+		if session.UserID == userID {
+			r.RevokeSessionByID(id)
+		}
+	}
+	return nil
+}
+
+func TestRefreshToken(t *testing.T) {
+	const initialSecret = "initial-secret"
+
+	newSession := func(expiresAt time.Time, revoked bool) *models.Session {
+		session := &models.Session{
+			UserID:           uuid.New(),
+			Role:             "owner",
+			RefreshTokenHash: utils.HashToken(initialSecret),
+			ExpiresAt:        expiresAt,
+		}
+		session.ID = uuid.New()
+		if revoked {
+			revokedAt := time.Now().Add(-time.Minute)
+			session.RevokedAt = &revokedAt
+		}
+		return session
+	}
+
+	// Each attempt presents the initial token, the latest issued token or a
+	// literal one.
+	type attempt struct {
+		token      string
+		wantStatus int
+	}
+
+	tests := []struct {
+		name        string
+		session     *models.Session
+		attempts    []attempt
+		wantRevoked bool
+	}{
+		{
+			name:     "each rotated token refreshes once",
+			session:  newSession(time.Now().Add(time.Hour), false),
+			attempts: []attempt{{token: "initial", wantStatus: http.StatusOK}, {token: "latest", wantStatus: http.StatusOK}, {token: "latest", wantStatus: http.StatusOK}},
+		},
+		{
+			name:        "reusing a rotated token revokes the session",
+			session:     newSession(time.Now().Add(time.Hour), false),
+			attempts:    []attempt{{token: "initial", wantStatus: http.StatusOK}, {token: "initial", wantStatus: http.StatusUnauthorized}, {token: "latest", wantStatus: http.StatusUnauthorized}},
+			wantRevoked: true,
+		},
+		{
+			name:        "wrong secret for a live session revokes it",
+			session:     newSession(time.Now().Add(time.Hour), false),
+			attempts:    []attempt{{token: "wrong", wantStatus: http.StatusUnauthorized}, {token: "initial", wantStatus: http.StatusUnauthorized}},
+			wantRevoked: true,
+		},
+		{
+			name:        "revoked session",
+			session:     newSession(time.Now().Add(time.Hour), true),
+			attempts:    []attempt{{token: "initial", wantStatus: http.StatusUnauthorized}},
+			wantRevoked: true,
+		},
+		{
+			name:     "expired session",
+			session:  newSession(time.Now().Add(-time.Second), false),
+			attempts: []attempt{{token: "initial", wantStatus: http.StatusUnauthorized}},
+		},
+		{
+			name:     "malformed tokens",
+			session:  newSession(time.Now().Add(time.Hour), false),
+			attempts: []attempt{{token: "", wantStatus: http.StatusUnauthorized}, {token: "no-separator", wantStatus: http.StatusUnauthorized}, {token: "not-a-uuid.secret", wantStatus: http.StatusUnauthorized}, {token: uuid.NewString() + ".secret", wantStatus: http.StatusUnauthorized}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeSessionRepository{sessions: map[uuid.UUID]*models.Session{tt.session.ID: tt.session}}
+			uc := &UserController{sessionRepo: repo}
+			e := echo.New()
+
+			latest := tt.session.ID.String() + "." + initialSecret
+			for i, a := range tt.attempts {
+				token := a.token
+				switch token {
+				case "initial":
+					token = tt.session.ID.String() + "." + initialSecret
+				case "latest":
+					token = latest
+				case "wrong":
+					token = tt.session.ID.String() + ".wrong-secret"
+				}
+
+				body, _ := json.Marshal(models.RefreshTokenBody{RefreshToken: token})
+				req := httptest.NewRequest(http.MethodPost, "/refresh", strings.NewReader(string(body)))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				rec := httptest.NewRecorder()
+
+				if err := uc.RefreshToken(e.NewContext(req, rec)); err != nil {
+					t.Fatalf("attempt %d returned error: %v", i, err)
+				}
+
+				if rec.Code != a.wantStatus {
+					t.Fatalf("attempt %d status = %d, want %d: %s", i, rec.Code, a.wantStatus, rec.Body.String())
+				}
+
+				if rec.Code == http.StatusOK {
+					var tokens models.TokenResponse
+					if err := json.Unmarshal(rec.Body.Bytes(), &tokens); err != nil {
+						t.Fatalf("attempt %d: decode response: %v", i, err)
+					}
+					if tokens.RefreshToken == latest {
+						t.Fatalf("attempt %d did not rotate the refresh token", i)
+					}
+					latest = tokens.RefreshToken
+				}
+			}
+
+			if revoked := tt.session.RevokedAt != nil; revoked != tt.wantRevoked {
+				t.Errorf("session revoked = %v, want %v", revoked, tt.wantRevoked)
+			}
+		})
+	}
+}
@@ -0,0 +1,386 @@
+package controllers
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/utils"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type BudgetController struct {
+	budgetRepo              repositories.BudgetRepository
+	transactionRepo         repositories.TransactionRepository
+	transactionCategoryRepo repositories.TransactionCategoryRepository
+	roomingHouseRepo        repositories.RoomingHouseRepository
+}
+
+func NewBudgetController(budgetRepo repositories.BudgetRepository, transactionRepo repositories.TransactionRepository, transactionCategoryRepo repositories.TransactionCategoryRepository, roomingHouseRepo repositories.RoomingHouseRepository) *BudgetController {
+	return &BudgetController{budgetRepo: budgetRepo, transactionRepo: transactionRepo, transactionCategoryRepo: transactionCategoryRepo, roomingHouseRepo: roomingHouseRepo}
+}
+
+// SetBudget replaces the budget of a category of a rooming house for a year.
+func (bc *BudgetController) SetBudget(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var budgetBody models.BudgetBody
+
+	if err := c.Bind(&budgetBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if budgetBody.RoomingHouseID == uuid.Nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house id is required"))
+	}
+
+	if budgetBody.TransactionCategoryID == uuid.Nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("transaction category id is required"))
+	}
+
+	if budgetBody.Year == 0 {
+		return utils.HandlerError(c, utils.NewBadRequestError("year is required"))
+	}
+
+	if budgetBody.AlertThreshold < 0 {
+		return utils.HandlerError(c, utils.NewBadRequestError("alert threshold cannot be negative"))
+	}
+
+	if budgetBody.AlertThreshold == 0 {
+		budgetBody.AlertThreshold = constants.BudgetDefaultAlertThreshold
+	}
+
+	if budgetBody.AnnualAmount < 0 {
+		return utils.HandlerError(c, utils.NewBadRequestError("annual amount cannot be negative"))
+	}
+
+	if budgetBody.AnnualAmount > 0 && len(budgetBody.Months) > 0 {
+		return utils.HandlerError(c, utils.NewBadRequestError("set either the annual amount or the months"))
+	}
+
+	if !canAccessRoomingHouse(bc.roomingHouseRepo, userPayload, budgetBody.RoomingHouseID) {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	roomingHouse, err := bc.roomingHouseRepo.FindRoomingHouseByID(budgetBody.RoomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	transactionCategory, err := bc.transactionCategoryRepo.FindTransactionCategoryByID(budgetBody.TransactionCategoryID)
+	if err != nil || !transactionCategoryAvailableToRoomingHouse(transactionCategory, roomingHouse) {
+		return utils.HandlerError(c, utils.NewBadRequestError("transaction category not found"))
+	}
+
+	budgets := []models.Budget{}
+	newBudget := func(month int, amount float64) models.Budget {
+		return models.Budget{
+			RoomingHouseID:        budgetBody.RoomingHouseID,
+			TransactionCategoryID: budgetBody.TransactionCategoryID,
+			Year:                  budgetBody.Year,
+			Month:                 month,
+			Amount:                amount,
+			AlertThreshold:        budgetBody.AlertThreshold,
+		}
+	}
+
+	if budgetBody.AnnualAmount > 0 {
+		// Spread evenly and give the remaining cents to December so the
+		// months add up to the annual amount
+		monthlyAmount := roundAmount(budgetBody.AnnualAmount / 12)
+		for month := 1; month <= 12; month++ {
+			amount := monthlyAmount
+			if month == 12 {
+				amount = roundAmount(budgetBody.AnnualAmount - monthlyAmount*11)
+			}
+			budgets = append(budgets, newBudget(month, amount))
+		}
+	} else {
+		seenMonths := map[int]bool{}
+		for _, monthBody := range budgetBody.Months {
+			if monthBody.Month < 1 || monthBody.Month > 12 {
+				return utils.HandlerError(c, utils.NewBadRequestError("month must be between 1 and 12"))
+			}
+
+			if seenMonths[monthBody.Month] {
+				return utils.HandlerError(c, utils.NewBadRequestError("duplicate month "+strconv.Itoa(monthBody.Month)))
+			}
+			seenMonths[monthBody.Month] = true
+
+			if monthBody.Amount < 0 {
+				return utils.HandlerError(c, utils.NewBadRequestError("amount cannot be negative"))
+			}
+
+			budgets = append(budgets, newBudget(monthBody.Month, monthBody.Amount))
+		}
+	}
+
+	if err := bc.budgetRepo.ReplaceBudgets(c.Request().Context(), budgetBody.RoomingHouseID, budgetBody.TransactionCategoryID, budgetBody.Year, budgets); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to set budget"))
+	}
+
+	return c.JSON(http.StatusOK, budgets)
+}
+
+func (bc *BudgetController) FindAllBudgets(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	year, apiErr := parseBudgetYear(c)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(bc.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if len(roomingHouseIDs) == 0 {
+		return c.JSON(http.StatusOK, []models.BudgetResponse{})
+	}
+
+	budgets, err := bc.budgetRepo.FindAllBudgets(roomingHouseIDs, year)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get budgets"))
+	}
+
+	return c.JSON(http.StatusOK, budgets)
+}
+
+func (bc *BudgetController) DeleteBudgetByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	budgetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid budget id"))
+	}
+
+	budget, err := bc.budgetRepo.FindBudgetByID(budgetID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.HandlerError(c, utils.NewNotFoundError("budget not found"))
+		}
+		return utils.HandlerError(c, utils.NewInternalError("failed to get budget"))
+	}
+
+	if !canAccessRoomingHouse(bc.roomingHouseRepo, userPayload, budget.RoomingHouseID) {
+		return utils.HandlerError(c, utils.NewNotFoundError("budget not found"))
+	}
+
+	if err := bc.budgetRepo.DeleteBudgetByID(c.Request().Context(), budget.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to delete budget"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "budget deleted"})
+}
+
+// Variance reports actual against budget for every budgeted category over a
+// year, month by month, or a single month with the month query param.
+func (bc *BudgetController) Variance(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	year, apiErr := parseBudgetYear(c)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	month := 0
+	if monthParam := c.QueryParam("month"); monthParam != "" {
+		parsedMonth, err := strconv.Atoi(monthParam)
+		if err != nil || parsedMonth < 1 || parsedMonth > 12 {
+			return utils.HandlerError(c, utils.NewBadRequestError("month must be between 1 and 12"))
+		}
+		month = parsedMonth
+	}
+
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(bc.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	variances, apiErr := bc.budgetVariances(roomingHouseIDs, year, month)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	return c.JSON(http.StatusOK, variances)
+}
+
+// Alerts returns the budgeted expense categories whose spending this month
+// has crossed their alert threshold.
+func (bc *BudgetController) Alerts(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(bc.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	now := time.Now()
+	variances, apiErr := bc.budgetVariances(roomingHouseIDs, now.Year(), int(now.Month()))
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	alerts := []models.BudgetVarianceResponse{}
+	for _, variance := range variances {
+		if variance.Alert {
+			alerts = append(alerts, variance)
+		}
+	}
+
+	return c.JSON(http.StatusOK, alerts)
+}
+
+// budgetVariances compares the budgets of the rooming houses with the same
+// monthly transaction totals as the dashboard, for the whole year when month
+// is 0.
+func (bc *BudgetController) budgetVariances(roomingHouseIDs []uuid.UUID, year int, month int) ([]models.BudgetVarianceResponse, *utils.APIError) {
+	variances := []models.BudgetVarianceResponse{}
+	if len(roomingHouseIDs) == 0 {
+		return variances, nil
+	}
+
+	budgets, err := bc.budgetRepo.FindAllBudgets(roomingHouseIDs, year)
+	if err != nil {
+		return nil, utils.NewInternalError("failed to get budgets")
+	}
+
+	if len(*budgets) == 0 {
+		return variances, nil
+	}
+
+	transactions, err := bc.transactionRepo.FindAllTransactions(roomingHouseIDs, year)
+	if err != nil {
+		return nil, utils.NewInternalError("failed to find transactions")
+	}
+
+	budgetKey := func(roomingHouseID uuid.UUID, transactionCategoryID uuid.UUID) string {
+		return roomingHouseID.String() + "/" + transactionCategoryID.String()
+	}
+
+	totals := monthlyTransactionTotals(*transactions, func(txn models.TransactionResponse) string {
+		return budgetKey(txn.RoomingHouse.ID, txn.TransactionCategoryID)
+	})
+
+	varianceIndex := map[string]int{}
+	for _, budget := range *budgets {
+		key := budgetKey(budget.RoomingHouse.ID, budget.TransactionCategoryID)
+
+		index, ok := varianceIndex[key]
+		if !ok {
+			index = len(variances)
+			varianceIndex[key] = index
+
+			months := make([]models.BudgetVarianceMonth, 12)
+			for i := range months {
+				months[i] = models.BudgetVarianceMonth{Month: constants.Months[i], Index: i + 1, AlertThreshold: constants.BudgetDefaultAlertThreshold}
+				if monthTotals, ok := totals[key]; ok {
+					if budget.IsExpense {
+						months[i].Actual = monthTotals[i].Expense
+					} else {
+						months[i].Actual = monthTotals[i].Income
+					}
+				}
+			}
+
+			variances = append(variances, models.BudgetVarianceResponse{
+				RoomingHouse:          budget.RoomingHouse,
+				TransactionCategoryID: budget.TransactionCategoryID,
+				CategoryName:          budget.CategoryName,
+				IsExpense:             budget.IsExpense,
+				Year:                  year,
+				Month:                 month,
+				Months:                months,
+			})
+		}
+
+		if budget.Month >= 1 && budget.Month <= 12 {
+			variances[index].Months[budget.Month-1].Budget = budget.Amount
+			variances[index].Months[budget.Month-1].AlertThreshold = budget.AlertThreshold
+		}
+	}
+
+	for i := range variances {
+		summarizeBudgetVariance(&variances[i], month)
+	}
+
+	return variances, nil
+}
+
+// summarizeBudgetVariance evaluates every month against its own budget and
+// alert threshold and totals the months of the period, the whole year when
+// month is 0. The period alerts when any of its months does.
+func summarizeBudgetVariance(variance *models.BudgetVarianceResponse, month int) {
+	for j := range variance.Months {
+		monthVariance := &variance.Months[j]
+		monthVariance.Variance = budgetVariance(variance.IsExpense, monthVariance.Budget, monthVariance.Actual)
+		monthVariance.UsedPercentage = usedPercentage(monthVariance.Budget, monthVariance.Actual)
+		monthVariance.Alert = budgetAlert(variance.IsExpense, monthVariance.Budget, monthVariance.Actual, monthVariance.AlertThreshold)
+
+		if month == 0 || monthVariance.Index == month {
+			variance.Budget += monthVariance.Budget
+			variance.Actual += monthVariance.Actual
+			variance.Alert = variance.Alert || monthVariance.Alert
+		}
+	}
+
+	variance.Budget = roundAmount(variance.Budget)
+	variance.Actual = roundAmount(variance.Actual)
+	variance.Variance = budgetVariance(variance.IsExpense, variance.Budget, variance.Actual)
+	variance.UsedPercentage = usedPercentage(variance.Budget, variance.Actual)
+
+	if month != 0 {
+		variance.AlertThreshold = variance.Months[month-1].AlertThreshold
+		variance.Months = nil
+	}
+}
+
+// budgetAlert reports whether spending reached the alert threshold of its
+// budget. Spending without any budget crosses every threshold.
+func budgetAlert(isExpense bool, budget float64, actual float64, alertThreshold float64) bool {
+	if !isExpense {
+		return false
+	}
+
+	if budget == 0 {
+		return actual > 0
+	}
+
+	return usedPercentage(budget, actual) >= alertThreshold
+}
+
+func usedPercentage(budget float64, actual float64) float64 {
+	if budget <= 0 {
+		return 0
+	}
+	return math.Round(actual/budget*10000) / 100
+}
+
+// budgetVariance is positive when the actual is better than the budget.
+func budgetVariance(isExpense bool, budget float64, actual float64) float64 {
+	if isExpense {
+		return roundAmount(budget - actual)
+	}
+	return roundAmount(actual - budget)
+}
+
+func parseBudgetYear(c echo.Context) (int, *utils.APIError) {
+	yearParam := c.QueryParam("year")
+	if yearParam == "" {
+		return time.Now().Year(), nil
+	}
+
+	year, err := strconv.Atoi(yearParam)
+	if err != nil {
+		return 0, utils.NewBadRequestError("invalid year")
+	}
+
+	return year, nil
+}
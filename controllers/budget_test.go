@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"testing"
+)
+
+func TestBudgetVariance(t *testing.T) {
+	tests := []struct {
+		name      string
+		isExpense bool
+		budget    float64
+		actual    float64
+		want      float64
+	}{
+		{name: "expense under budget is positive", isExpense: true, budget: 1000, actual: 800, want: 200},
+		{name: "expense over budget is negative", isExpense: true, budget: 1000, actual: 1250.5, want: -250.5},
+		{name: "expense without budget is negative", isExpense: true, budget: 0, actual: 300, want: -300},
+		{name: "income over budget is positive", isExpense: false, budget: 1000, actual: 1200, want: 200},
+		{name: "income under budget is negative", isExpense: false, budget: 1000, actual: 999.99, want: -0.01},
+		{name: "on budget is zero", isExpense: true, budget: 500, actual: 500, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := budgetVariance(tt.isExpense, tt.budget, tt.actual); got != tt.want {
+				t.Errorf("budgetVariance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBudgetAlert(t *testing.T) {
+	tests := []struct {
+		name           string
+		isExpense      bool
+		budget         float64
+		actual         float64
+		alertThreshold float64
+		want           bool
+	}{
+		{name: "below threshold", isExpense: true, budget: 1000, actual: 799, alertThreshold: 80},
+		{name: "exactly at threshold", isExpense: true, budget: 1000, actual: 800, alertThreshold: 80, want: true},
+		{name: "over budget", isExpense: true, budget: 1000, actual: 1001, alertThreshold: 100, want: true},
+		{name: "spending without budget", isExpense: true, budget: 0, actual: 1, alertThreshold: 100, want: true},
+		{name: "no budget and no spending", isExpense: true, budget: 0, actual: 0, alertThreshold: 100},
+		{name: "income never alerts", isExpense: false, budget: 1000, actual: 5000, alertThreshold: 80},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := budgetAlert(tt.isExpense, tt.budget, tt.actual, tt.alertThreshold); got != tt.want {
+				t.Errorf("budgetAlert() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeBudgetVariance(t *testing.T) {
+	newVariance := func() *models.BudgetVarianceResponse {
+		months := make([]models.BudgetVarianceMonth, 12)
+		for i := range months {
+			months[i] = models.BudgetVarianceMonth{Month: constants.Months[i], Index: i + 1, AlertThreshold: constants.BudgetDefaultAlertThreshold}
+		}
+
+		// January is well under its default threshold, February crosses its
+		// own lowered threshold and March has no budget at all.
+		months[0].Budget, months[0].Actual = 1000, 500
+		months[1].Budget, months[1].Actual, months[1].AlertThreshold = 1000, 600, 50
+		months[2].Actual = 100
+
+		return &models.BudgetVarianceResponse{IsExpense: true, Months: months}
+	}
+
+	tests := []struct {
+		name          string
+		month         int
+		wantBudget    float64
+		wantActual    float64
+		wantVariance  float64
+		wantUsed      float64
+		wantThreshold float64
+		wantAlert     bool
+	}{
+		{name: "month under its threshold", month: 1, wantBudget: 1000, wantActual: 500, wantVariance: 500, wantUsed: 50, wantThreshold: 100},
+		{name: "month over its own threshold", month: 2, wantBudget: 1000, wantActual: 600, wantVariance: 400, wantUsed: 60, wantThreshold: 50, wantAlert: true},
+		{name: "month without budget", month: 3, wantActual: 100, wantVariance: -100, wantThreshold: 100, wantAlert: true},
+		{name: "month without activity", month: 4, wantThreshold: 100},
+		{name: "whole year alerts when any month does", month: 0, wantBudget: 2000, wantActual: 1200, wantVariance: 800, wantUsed: 60, wantAlert: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variance := newVariance()
+			summarizeBudgetVariance(variance, tt.month)
+
+			if variance.Budget != tt.wantBudget || variance.Actual != tt.wantActual {
+				t.Errorf("budget, actual = %v, %v; want %v, %v", variance.Budget, variance.Actual, tt.wantBudget, tt.wantActual)
+			}
+			if variance.Variance != tt.wantVariance {
+				t.Errorf("variance = %v, want %v", variance.Variance, tt.wantVariance)
+			}
+			if variance.UsedPercentage != tt.wantUsed {
+				t.Errorf("used percentage = %v, want %v", variance.UsedPercentage, tt.wantUsed)
+			}
+			if variance.AlertThreshold != tt.wantThreshold {
+				t.Errorf("alert threshold = %v, want %v", variance.AlertThreshold, tt.wantThreshold)
+			}
+			if variance.Alert != tt.wantAlert {
+				t.Errorf("alert = %v, want %v", variance.Alert, tt.wantAlert)
+			}
+			if tt.month != 0 && variance.Months != nil {
+				t.Errorf("months are kept for a single month")
+			}
+			if tt.month == 0 && len(variance.Months) != 12 {
+				t.Errorf("got %d months, want 12", len(variance.Months))
+			}
+		})
+	}
+}
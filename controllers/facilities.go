@@ -2,25 +2,199 @@ package controllers
 
 import (
 	"net/http"
+	"rooming-house-cms-be/models"
 	"rooming-house-cms-be/repositories"
 	"rooming-house-cms-be/utils"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 type FacilityController struct {
-	facilityRepo repositories.FacilityRepository
+	facilityRepo     repositories.FacilityRepository
+	roomingHouseRepo repositories.RoomingHouseRepository
 }
 
-func NewFacilityController(facilityRepo repositories.FacilityRepository) *FacilityController {
-	return &FacilityController{facilityRepo: facilityRepo}
+func NewFacilityController(facilityRepo repositories.FacilityRepository, roomingHouseRepo repositories.RoomingHouseRepository) *FacilityController {
+	return &FacilityController{facilityRepo: facilityRepo, roomingHouseRepo: roomingHouseRepo}
 }
 
 func (fc *FacilityController) GetAllFacilities(c echo.Context) error {
-	facilities, err := fc.facilityRepo.GetAllFacilities()
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	ownerID, err := requestOwnerID(fc.roomingHouseRepo, c, userPayload)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	facilities, err := fc.facilityRepo.GetAllFacilities(ownerID)
 	if err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError(err.Error()))
 	}
 
 	return c.JSON(http.StatusOK, facilities)
 }
+
+func (fc *FacilityController) CreateFacility(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var facilityBody models.FacilityBody
+
+	if err := c.Bind(&facilityBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if apiErr := validateFacilityBody(facilityBody); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	ownerID, err := requestOwnerID(fc.roomingHouseRepo, c, userPayload)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	if apiErr := fc.checkDuplicateName(facilityBody.Name, ownerID, uuid.Nil); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	newFacility := models.Facility{
+		Name:        facilityBody.Name,
+		Description: facilityBody.Description,
+		IsPublic:    facilityBody.IsPublic,
+		IsRoom:      facilityBody.IsRoom,
+		OwnerID:     &ownerID,
+	}
+
+	if err := fc.facilityRepo.CreateFacility(c.Request().Context(), &newFacility); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to create facility"))
+	}
+
+	return c.JSON(http.StatusCreated, newFacility)
+}
+
+func (fc *FacilityController) UpdateFacilityByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var facilityBody models.FacilityBody
+
+	if err := c.Bind(&facilityBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if apiErr := validateFacilityBody(facilityBody); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	facility, apiErr := fc.findOwnedFacility(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if apiErr := fc.checkDuplicateName(facilityBody.Name, *facility.OwnerID, facility.ID); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	// Switching between room and public would invalidate the rooms or rooming houses using it
+	if facility.IsPublic != facilityBody.IsPublic || facility.IsRoom != facilityBody.IsRoom {
+		references, err := fc.facilityRepo.CountFacilityReferences(facility.ID)
+		if err != nil {
+			return utils.HandlerError(c, utils.NewInternalError("failed to check facility usage"))
+		}
+
+		if references > 0 {
+			return utils.HandlerError(c, utils.NewConflictError("facility type cannot be changed while it is in use"))
+		}
+	}
+
+	updatedFacility := models.Facility{
+		Name:        facilityBody.Name,
+		Description: facilityBody.Description,
+		IsPublic:    facilityBody.IsPublic,
+		IsRoom:      facilityBody.IsRoom,
+	}
+
+	if err := fc.facilityRepo.UpdateFacilityByID(c.Request().Context(), &updatedFacility, facility.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to update facility"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "facility updated"})
+}
+
+func (fc *FacilityController) DeleteFacilityByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	facility, apiErr := fc.findOwnedFacility(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	references, err := fc.facilityRepo.CountFacilityReferences(facility.ID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to check facility usage"))
+	}
+
+	if references > 0 {
+		return utils.HandlerError(c, utils.NewConflictError("facility is still used by rooms, rooming houses or assets"))
+	}
+
+	if err := fc.facilityRepo.DeleteFacilityByID(c.Request().Context(), facility.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to delete facility"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "facility deleted"})
+}
+
+// findOwnedFacility loads a custom facility of the rooming house owner; global
+// facilities are read-only.
+func (fc *FacilityController) findOwnedFacility(c echo.Context, userPayload *models.JWTPayload) (*models.Facility, *utils.APIError) {
+	facilityID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, utils.NewBadRequestError("invalid facility id")
+	}
+
+	facility, err := fc.facilityRepo.GetFacilityByID(facilityID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.NewNotFoundError("facility not found")
+		}
+		return nil, utils.NewInternalError("failed to get facility")
+	}
+
+	if facility.OwnerID == nil {
+		return nil, utils.NewForbiddenError("global facilities cannot be changed")
+	}
+
+	ownerID, err := requestOwnerID(fc.roomingHouseRepo, c, userPayload)
+	if err != nil || *facility.OwnerID != ownerID {
+		return nil, utils.NewNotFoundError("facility not found")
+	}
+
+	return facility, nil
+}
+
+func (fc *FacilityController) checkDuplicateName(name string, ownerID uuid.UUID, excludedID uuid.UUID) *utils.APIError {
+	if _, err := fc.facilityRepo.FindFacilityByName(name, ownerID, excludedID); err == nil {
+		return utils.NewConflictError("facility with this name already exists")
+	} else if err != gorm.ErrRecordNotFound {
+		return utils.NewInternalError("failed to check facility name")
+	}
+
+	return nil
+}
+
+func validateFacilityBody(facilityBody models.FacilityBody) *utils.APIError {
+	if facilityBody.Name == "" {
+		return utils.NewBadRequestError("name is required")
+	}
+
+	// A facility is either inside a room or shared by the whole rooming house
+	if facilityBody.IsPublic == facilityBody.IsRoom {
+		return utils.NewBadRequestError("facility must be either public or room facility")
+	}
+
+	return nil
+}
+
+// facilityAvailableToOwner reports whether the facility is global or one of the owner's custom facilities.
+func facilityAvailableToOwner(facility *models.Facility, ownerID uuid.UUID) bool {
+	return facility.OwnerID == nil || *facility.OwnerID == ownerID
+}
@@ -0,0 +1,401 @@
+package controllers
+
+import (
+	"net/http"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type FacilityAssetController struct {
+	facilityAssetRepo repositories.FacilityAssetRepository
+	facilityRepo      repositories.FacilityRepository
+	roomingHouseRepo  repositories.RoomingHouseRepository
+	roomRepo          repositories.RoomRepository
+}
+
+func NewFacilityAssetController(facilityAssetRepo repositories.FacilityAssetRepository, facilityRepo repositories.FacilityRepository, roomingHouseRepo repositories.RoomingHouseRepository, roomRepo repositories.RoomRepository) *FacilityAssetController {
+	return &FacilityAssetController{facilityAssetRepo: facilityAssetRepo, facilityRepo: facilityRepo, roomingHouseRepo: roomingHouseRepo, roomRepo: roomRepo}
+}
+
+func (fac *FacilityAssetController) CreateFacilityAsset(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var assetBody models.AddFacilityAssetBody
+
+	if err := c.Bind(&assetBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if assetBody.FacilityID == uuid.Nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("facility id is required"))
+	}
+
+	if assetBody.Condition == "" {
+		assetBody.Condition = constants.AssetConditionGood
+	}
+
+	details := models.UpdateFacilityAssetBody{
+		SerialNumber:     assetBody.SerialNumber,
+		Brand:            assetBody.Brand,
+		PurchaseDate:     assetBody.PurchaseDate,
+		PurchaseCost:     assetBody.PurchaseCost,
+		SalvageValue:     assetBody.SalvageValue,
+		UsefulLifeMonths: assetBody.UsefulLifeMonths,
+		WarrantyExpiry:   assetBody.WarrantyExpiry,
+		Condition:        assetBody.Condition,
+	}
+
+	newAsset, apiErr := validateFacilityAssetDetails(details)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	roomingHouseID, apiErr := targetRoomingHouseID(userPayload, assetBody.RoomingHouseID)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	roomingHouse, err := fac.roomingHouseRepo.FindRoomingHouseByID(roomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	facility, err := fac.facilityRepo.GetFacilityByID(assetBody.FacilityID)
+	if err == nil && !facilityAvailableToOwner(facility, roomingHouse.OwnerID) {
+		err = gorm.ErrRecordNotFound
+	}
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return utils.HandlerError(c, utils.NewBadRequestError("facility not found"))
+		}
+		return utils.HandlerError(c, utils.NewInternalError("failed to get facility"))
+	}
+
+	if apiErr := fac.checkAssetLocation(userPayload, facility, roomingHouseID, assetBody.RoomID); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if apiErr := fac.checkDuplicateSerialNumber(roomingHouseID, newAsset.SerialNumber, uuid.Nil); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	newAsset.FacilityID = facility.ID
+	newAsset.RoomingHouseID = roomingHouseID
+	newAsset.RoomID = assetBody.RoomID
+
+	if err := fac.facilityAssetRepo.CreateFacilityAsset(c.Request().Context(), newAsset); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to create facility asset"))
+	}
+
+	return c.JSON(http.StatusCreated, newAsset)
+}
+
+func (fac *FacilityAssetController) FindAllFacilityAssets(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	roomID, apiErr := parseOptionalUUIDParam(c, "room_id")
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(fac.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if len(roomingHouseIDs) == 0 {
+		return c.JSON(http.StatusOK, []models.FacilityAssetResponse{})
+	}
+
+	facilityAssets, err := fac.facilityAssetRepo.FindAllFacilityAssets(roomingHouseIDs, roomID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get facility assets"))
+	}
+
+	return c.JSON(http.StatusOK, facilityAssets)
+}
+
+// FindDepreciationReport reports straight-line depreciation of every asset as
+// of the as_of date (default today), grouped per rooming house.
+func (fac *FacilityAssetController) FindDepreciationReport(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	asOf := time.Now()
+	if asOfParam := c.QueryParam("as_of"); asOfParam != "" {
+		parsed, err := time.Parse(constants.DateLayout, asOfParam)
+		if err != nil {
+			return utils.HandlerError(c, utils.NewBadRequestError("as_of must use the format "+constants.DateLayout))
+		}
+		asOf = parsed
+	}
+
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(fac.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if len(roomingHouseIDs) == 0 {
+		return c.JSON(http.StatusOK, []models.DepreciationReportResponse{})
+	}
+
+	facilityAssets, err := fac.facilityAssetRepo.FindAllFacilityAssets(roomingHouseIDs, nil)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get facility assets"))
+	}
+
+	reports := []models.DepreciationReportResponse{}
+	reportIndex := map[uuid.UUID]int{}
+
+	for _, facilityAsset := range *facilityAssets {
+		index, ok := reportIndex[facilityAsset.RoomingHouse.ID]
+		if !ok {
+			reports = append(reports, models.DepreciationReportResponse{
+				RoomingHouse: facilityAsset.RoomingHouse,
+				AsOf:         asOf,
+				Assets:       []models.FacilityAssetDepreciation{},
+			})
+			index = len(reports) - 1
+			reportIndex[facilityAsset.RoomingHouse.ID] = index
+		}
+
+		var accumulated float64
+		if facilityAsset.PurchaseDate != nil {
+			accumulated = utils.StraightLineDepreciation(facilityAsset.PurchaseCost, facilityAsset.SalvageValue, facilityAsset.UsefulLifeMonths, *facilityAsset.PurchaseDate, asOf)
+		}
+
+		report := &reports[index]
+		report.Assets = append(report.Assets, models.FacilityAssetDepreciation{
+			FacilityAssetResponse:   facilityAsset,
+			AccumulatedDepreciation: accumulated,
+			BookValue:               facilityAsset.PurchaseCost - accumulated,
+		})
+		report.TotalCost += facilityAsset.PurchaseCost
+		report.TotalAccumulatedDepreciation += accumulated
+		report.TotalBookValue += facilityAsset.PurchaseCost - accumulated
+	}
+
+	return c.JSON(http.StatusOK, reports)
+}
+
+func (fac *FacilityAssetController) FindFacilityAssetByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	facilityAsset, apiErr := fac.findAccessibleFacilityAsset(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	return c.JSON(http.StatusOK, facilityAsset)
+}
+
+func (fac *FacilityAssetController) UpdateFacilityAssetByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var assetBody models.UpdateFacilityAssetBody
+
+	if err := c.Bind(&assetBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	updatedAsset, apiErr := validateFacilityAssetDetails(assetBody)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	facilityAsset, apiErr := fac.findAccessibleFacilityAsset(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if apiErr := fac.checkDuplicateSerialNumber(facilityAsset.RoomingHouseID, updatedAsset.SerialNumber, facilityAsset.ID); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if err := fac.facilityAssetRepo.UpdateFacilityAssetByID(c.Request().Context(), updatedAsset, facilityAsset.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to update facility asset"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "facility asset updated"})
+}
+
+// MoveFacilityAsset moves the asset into another room of the same rooming
+// house, or out to the shared area when room_id is null.
+func (fac *FacilityAssetController) MoveFacilityAsset(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var moveBody models.MoveFacilityAssetBody
+
+	if err := c.Bind(&moveBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	facilityAsset, apiErr := fac.findAccessibleFacilityAsset(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if (facilityAsset.RoomID == nil && moveBody.RoomID == nil) || (facilityAsset.RoomID != nil && moveBody.RoomID != nil && *facilityAsset.RoomID == *moveBody.RoomID) {
+		return utils.HandlerError(c, utils.NewBadRequestError("facility asset is already there"))
+	}
+
+	facility, err := fac.facilityRepo.GetFacilityByID(facilityAsset.FacilityID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get facility"))
+	}
+
+	if apiErr := fac.checkAssetLocation(userPayload, facility, facilityAsset.RoomingHouseID, moveBody.RoomID); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	movement := models.FacilityAssetMovement{
+		FacilityAssetID: facilityAsset.ID,
+		FromRoomID:      facilityAsset.RoomID,
+		ToRoomID:        moveBody.RoomID,
+		Reason:          moveBody.Reason,
+		MovedBy:         userPayload.UserID,
+		MoverRole:       userPayload.Role,
+	}
+
+	if err := fac.facilityAssetRepo.MoveFacilityAsset(c.Request().Context(), facilityAsset, &movement); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to move facility asset"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "facility asset moved"})
+}
+
+func (fac *FacilityAssetController) FindFacilityAssetMovements(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	facilityAsset, apiErr := fac.findAccessibleFacilityAsset(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	movements, err := fac.facilityAssetRepo.FindFacilityAssetMovements(facilityAsset.ID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get facility asset movements"))
+	}
+
+	return c.JSON(http.StatusOK, movements)
+}
+
+func (fac *FacilityAssetController) DeleteFacilityAssetByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	facilityAsset, apiErr := fac.findAccessibleFacilityAsset(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if err := fac.facilityAssetRepo.DeleteFacilityAssetByID(c.Request().Context(), facilityAsset.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to delete facility asset"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "facility asset deleted"})
+}
+
+func (fac *FacilityAssetController) findAccessibleFacilityAsset(c echo.Context, userPayload *models.JWTPayload) (*models.FacilityAsset, *utils.APIError) {
+	facilityAssetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, utils.NewBadRequestError("invalid facility asset id")
+	}
+
+	facilityAsset, err := fac.facilityAssetRepo.FindFacilityAssetByID(facilityAssetID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.NewNotFoundError("facility asset not found")
+		}
+		return nil, utils.NewInternalError("failed to get facility asset")
+	}
+
+	if !canAccessRoomingHouse(fac.roomingHouseRepo, userPayload, facilityAsset.RoomingHouseID) {
+		return nil, utils.NewNotFoundError("facility asset not found")
+	}
+
+	return facilityAsset, nil
+}
+
+// checkAssetLocation makes sure a room facility goes into a room of the
+// rooming house and a public facility stays in the shared area.
+func (fac *FacilityAssetController) checkAssetLocation(userPayload *models.JWTPayload, facility *models.Facility, roomingHouseID uuid.UUID, roomID *uuid.UUID) *utils.APIError {
+	if roomID == nil {
+		if !facility.IsPublic {
+			return utils.NewBadRequestError("facility is not public facility")
+		}
+		return nil
+	}
+
+	if !facility.IsRoom {
+		return utils.NewBadRequestError("facility is not room facility")
+	}
+
+	room, err := fac.roomRepo.FindRoomByID(*roomID, []uuid.UUID{roomingHouseID}, userPayload.UserID, userPayload.Role)
+	if err != nil || room.RoomingHouseID != roomingHouseID {
+		return utils.NewBadRequestError("room not found")
+	}
+
+	return nil
+}
+
+func (fac *FacilityAssetController) checkDuplicateSerialNumber(roomingHouseID uuid.UUID, serialNumber string, excludedID uuid.UUID) *utils.APIError {
+	if serialNumber == "" {
+		return nil
+	}
+
+	if _, err := fac.facilityAssetRepo.FindFacilityAssetBySerialNumber(roomingHouseID, serialNumber, excludedID); err == nil {
+		return utils.NewConflictError("serial number is already registered in this rooming house")
+	} else if err != gorm.ErrRecordNotFound {
+		return utils.NewInternalError("failed to check serial number")
+	}
+
+	return nil
+}
+
+func validateFacilityAssetDetails(assetBody models.UpdateFacilityAssetBody) (*models.FacilityAsset, *utils.APIError) {
+	if !constants.AssetConditions[assetBody.Condition] {
+		return nil, utils.NewBadRequestError("invalid condition")
+	}
+
+	if assetBody.PurchaseCost < 0 || assetBody.SalvageValue < 0 {
+		return nil, utils.NewBadRequestError("purchase cost and salvage value cannot be negative")
+	}
+
+	if assetBody.SalvageValue > assetBody.PurchaseCost {
+		return nil, utils.NewBadRequestError("salvage value cannot exceed purchase cost")
+	}
+
+	if assetBody.UsefulLifeMonths < 0 {
+		return nil, utils.NewBadRequestError("useful life months cannot be negative")
+	}
+
+	facilityAsset := models.FacilityAsset{
+		SerialNumber:     assetBody.SerialNumber,
+		Brand:            assetBody.Brand,
+		PurchaseCost:     assetBody.PurchaseCost,
+		SalvageValue:     assetBody.SalvageValue,
+		UsefulLifeMonths: assetBody.UsefulLifeMonths,
+		Condition:        assetBody.Condition,
+	}
+
+	if assetBody.PurchaseDate != "" {
+		purchaseDate, err := time.Parse(constants.DateLayout, assetBody.PurchaseDate)
+		if err != nil {
+			return nil, utils.NewBadRequestError("purchase date must use the format " + constants.DateLayout)
+		}
+		facilityAsset.PurchaseDate = &purchaseDate
+	}
+
+	if assetBody.WarrantyExpiry != "" {
+		warrantyExpiry, err := time.Parse(constants.DateLayout, assetBody.WarrantyExpiry)
+		if err != nil {
+			return nil, utils.NewBadRequestError("warranty expiry must use the format " + constants.DateLayout)
+		}
+		facilityAsset.WarrantyExpiry = &warrantyExpiry
+	}
+
+	return &facilityAsset, nil
+}
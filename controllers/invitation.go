@@ -0,0 +1,206 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/mailer"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type InvitationController struct {
+	invitationRepo   repositories.InvitationRepository
+	adminRepo        repositories.AdminRepository
+	roomingHouseRepo repositories.RoomingHouseRepository
+	roleRepo         repositories.RoleRepository
+	mailer           mailer.Mailer
+	acceptURL        string
+}
+
+// NewInvitationController sends invitations through mailer, linking to
+// acceptURL with the token as a query parameter.
+func NewInvitationController(invitationRepo repositories.InvitationRepository, adminRepo repositories.AdminRepository, roomingHouseRepo repositories.RoomingHouseRepository, roleRepo repositories.RoleRepository, mailer mailer.Mailer, acceptURL string) *InvitationController {
+	if acceptURL == "" {
+		acceptURL = constants.DefaultInvitationURL
+	}
+	return &InvitationController{invitationRepo: invitationRepo, adminRepo: adminRepo, roomingHouseRepo: roomingHouseRepo, roleRepo: roleRepo, mailer: mailer, acceptURL: acceptURL}
+}
+
+// CreateInvitation invites a new admin to one of the owner's rooming houses
+// and emails them a single-use link to set up their account.
+func (ic *InvitationController) CreateInvitation(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var invitationBody models.InvitationBody
+
+	if err := c.Bind(&invitationBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	email := strings.ToLower(strings.TrimSpace(invitationBody.Email))
+	if email == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("email is required"))
+	}
+
+	if _, err := mail.ParseAddress(email); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid email"))
+	}
+
+	if invitationBody.RoomingHouseID == uuid.Nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house id is required"))
+	}
+
+	roomingHouse, err := ic.roomingHouseRepo.FindRoomingHouseByID(invitationBody.RoomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	if roomingHouse.OwnerID != userPayload.UserID {
+		return utils.HandlerError(c, utils.NewForbiddenError("you are not the owner of this rooming house"))
+	}
+
+	if _, err := ic.adminRepo.FindAdminByEmail(email); err == nil {
+		return utils.HandlerError(c, utils.NewConflictError("an admin with this email already exists, assign them to the rooming house instead"))
+	}
+
+	role, apiErr := resolveAssignableRole(ic.roleRepo, invitationBody.RoleID, userPayload.UserID)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("could not generate token"))
+	}
+
+	invitation := models.Invitation{
+		OwnerID:        userPayload.UserID,
+		RoomingHouseID: invitationBody.RoomingHouseID,
+		RoleID:         role.ID,
+		Email:          email,
+		FullName:       strings.TrimSpace(invitationBody.FullName),
+		TokenHash:      utils.HashToken(token),
+		ExpiresAt:      time.Now().Add(constants.InvitationTTL),
+	}
+
+	if err := ic.invitationRepo.CreateInvitation(c.Request().Context(), &invitation); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to create invitation"))
+	}
+
+	link := ic.acceptURL + "?token=" + url.QueryEscape(token)
+	body := fmt.Sprintf("You have been invited to help manage %s as %s.\n\nSet up your account here: %s\n\nThe link expires in %d days and can only be used once. If you were not expecting this, you can ignore this email.", roomingHouse.Name, role.Name, link, int(constants.InvitationTTL.Hours()/24))
+	if err := ic.mailer.Send(email, "You have been invited to "+roomingHouse.Name, body); err != nil {
+		ic.invitationRepo.RevokeInvitation(c.Request().Context(), invitation.ID)
+		return utils.HandlerError(c, utils.NewInternalError("failed to send invitation email"))
+	}
+
+	return c.JSON(http.StatusCreated, invitation)
+}
+
+func (ic *InvitationController) GetAllPendingInvitations(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	invitations, err := ic.invitationRepo.FindPendingInvitations(userPayload.UserID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get invitations"))
+	}
+
+	return c.JSON(http.StatusOK, invitations)
+}
+
+func (ic *InvitationController) RevokeInvitation(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	invitationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid invitation id"))
+	}
+
+	invitation, err := ic.invitationRepo.FindInvitationByID(invitationID)
+	if err != nil || invitation.OwnerID != userPayload.UserID {
+		return utils.HandlerError(c, utils.NewNotFoundError("invitation not found"))
+	}
+
+	if err := ic.invitationRepo.RevokeInvitation(c.Request().Context(), invitation.ID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return utils.HandlerError(c, utils.NewConflictError("invitation is no longer pending"))
+		}
+		return utils.HandlerError(c, utils.NewInternalError("failed to revoke invitation"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "invitation revoked"})
+}
+
+// AcceptInvitation creates the invitee's admin account with the password they
+// choose and assigns it to the invited rooming house.
+func (ic *InvitationController) AcceptInvitation(c echo.Context) error {
+	var acceptBody models.AcceptInvitationBody
+
+	if err := c.Bind(&acceptBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if acceptBody.Token == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("token is required"))
+	}
+
+	if acceptBody.Username == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("username is required"))
+	}
+
+	if acceptBody.Password == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("password is required"))
+	}
+
+	if len(acceptBody.Password) < constants.PasswordMinLength {
+		return utils.HandlerError(c, utils.NewBadRequestError(fmt.Sprintf("password must be at least %d characters", constants.PasswordMinLength)))
+	}
+
+	invitation, err := ic.invitationRepo.FindInvitationByTokenHash(utils.HashToken(acceptBody.Token))
+	if err != nil || invitation.AcceptedAt != nil || invitation.RevokedAt != nil || time.Now().After(invitation.ExpiresAt) {
+		return utils.HandlerError(c, utils.NewBadRequestError("invitation is invalid or has expired"))
+	}
+
+	fullName := strings.TrimSpace(acceptBody.FullName)
+	if fullName == "" {
+		fullName = invitation.FullName
+	}
+
+	if fullName == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("full name is required"))
+	}
+
+	if _, err := ic.adminRepo.FindAdminByEmail(invitation.Email); err == nil {
+		return utils.HandlerError(c, utils.NewConflictError("an admin with this email already exists"))
+	}
+
+	if _, err := ic.adminRepo.FindAdminByUsername(acceptBody.Username); err == nil {
+		return utils.HandlerError(c, utils.NewConflictError("username is already taken"))
+	}
+
+	admin := models.Admin{
+		FullName: fullName,
+		Email:    invitation.Email,
+		Username: acceptBody.Username,
+		Password: acceptBody.Password,
+		Role:     "admin",
+	}
+
+	if err := ic.invitationRepo.AcceptInvitation(c.Request().Context(), invitation, &admin); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return utils.HandlerError(c, utils.NewBadRequestError("invitation is invalid or has expired"))
+		}
+		return utils.HandlerError(c, utils.NewInternalError("failed to accept invitation"))
+	}
+
+	return c.JSON(http.StatusCreated, map[string]string{"message": "invitation accepted, you can now login"})
+}
@@ -0,0 +1,370 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/utils"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type MaintenanceScheduleController struct {
+	maintenanceScheduleRepo  repositories.MaintenanceScheduleRepository
+	roomingHouseRepo         repositories.RoomingHouseRepository
+	roomRepo                 repositories.RoomRepository
+	roomingHouseFacilityRepo repositories.RoomingHouseFacilityRepository
+}
+
+func NewMaintenanceScheduleController(maintenanceScheduleRepo repositories.MaintenanceScheduleRepository, roomingHouseRepo repositories.RoomingHouseRepository, roomRepo repositories.RoomRepository, roomingHouseFacilityRepo repositories.RoomingHouseFacilityRepository) *MaintenanceScheduleController {
+	return &MaintenanceScheduleController{maintenanceScheduleRepo: maintenanceScheduleRepo, roomingHouseRepo: roomingHouseRepo, roomRepo: roomRepo, roomingHouseFacilityRepo: roomingHouseFacilityRepo}
+}
+
+func (msc *MaintenanceScheduleController) CreateMaintenanceSchedule(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var scheduleBody models.AddMaintenanceScheduleBody
+
+	if err := c.Bind(&scheduleBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if scheduleBody.Title == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("title is required"))
+	}
+
+	if scheduleBody.FacilityID == uuid.Nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("facility id is required"))
+	}
+
+	nextDueDate, apiErr := validateMaintenanceInterval(scheduleBody.IntervalUnit, scheduleBody.IntervalCount, scheduleBody.LeadDays, scheduleBody.NextDueDate)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	roomingHouseID, apiErr := targetRoomingHouseID(userPayload, scheduleBody.RoomingHouseID)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if _, err := msc.roomingHouseRepo.FindRoomingHouseByID(roomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs()); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	if scheduleBody.RoomID != nil {
+		room, err := msc.roomRepo.FindRoomByID(*scheduleBody.RoomID, []uuid.UUID{roomingHouseID}, userPayload.UserID, userPayload.Role)
+		if err != nil || room.RoomingHouseID != roomingHouseID {
+			return utils.HandlerError(c, utils.NewBadRequestError("room not found"))
+		}
+
+		hasFacility := false
+		for _, facility := range room.Facilities {
+			if facility.ID == scheduleBody.FacilityID {
+				hasFacility = true
+				break
+			}
+		}
+
+		if !hasFacility {
+			return utils.HandlerError(c, utils.NewBadRequestError("facility not found in this room"))
+		}
+	} else if _, err := msc.roomingHouseFacilityRepo.FindRoomingHouseFacilityByFacilityID(roomingHouseID, scheduleBody.FacilityID); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("facility not found in this rooming house"))
+	}
+
+	newSchedule := models.MaintenanceSchedule{
+		Title:          scheduleBody.Title,
+		Description:    scheduleBody.Description,
+		RoomingHouseID: roomingHouseID,
+		RoomID:         scheduleBody.RoomID,
+		FacilityID:     scheduleBody.FacilityID,
+		IntervalUnit:   scheduleBody.IntervalUnit,
+		IntervalCount:  scheduleBody.IntervalCount,
+		LeadDays:       scheduleBody.LeadDays,
+		NextDueDate:    nextDueDate,
+		IsActive:       true,
+	}
+
+	if err := msc.maintenanceScheduleRepo.CreateMaintenanceSchedule(c.Request().Context(), &newSchedule); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to create maintenance schedule"))
+	}
+
+	return c.JSON(http.StatusCreated, newSchedule)
+}
+
+func (msc *MaintenanceScheduleController) FindAllMaintenanceSchedules(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(msc.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if len(roomingHouseIDs) == 0 {
+		return c.JSON(http.StatusOK, []models.MaintenanceScheduleResponse{})
+	}
+
+	maintenanceSchedules, err := msc.maintenanceScheduleRepo.FindAllMaintenanceSchedules(roomingHouseIDs, nil)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get maintenance schedules"))
+	}
+
+	return c.JSON(http.StatusOK, maintenanceSchedules)
+}
+
+// FindUpcomingMaintenance lists overdue schedules and those due within the next "days" days.
+func (msc *MaintenanceScheduleController) FindUpcomingMaintenance(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	days := constants.MaintenanceUpcomingDays
+	if daysParam := c.QueryParam("days"); daysParam != "" {
+		parsedDays, err := strconv.Atoi(daysParam)
+		if err != nil || parsedDays < 0 {
+			return utils.HandlerError(c, utils.NewBadRequestError("invalid days"))
+		}
+		days = parsedDays
+	}
+
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(msc.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if len(roomingHouseIDs) == 0 {
+		return c.JSON(http.StatusOK, []models.MaintenanceScheduleResponse{})
+	}
+
+	dueBefore := time.Now().AddDate(0, 0, days)
+	maintenanceSchedules, err := msc.maintenanceScheduleRepo.FindAllMaintenanceSchedules(roomingHouseIDs, &dueBefore)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get upcoming maintenance"))
+	}
+
+	return c.JSON(http.StatusOK, maintenanceSchedules)
+}
+
+// FindMaintenanceLogs returns the completion history, optionally narrowed to
+// one asset via room_id and facility_id or to one schedule.
+func (msc *MaintenanceScheduleController) FindMaintenanceLogs(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	scheduleID, apiErr := parseOptionalUUIDParam(c, "maintenance_schedule_id")
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	roomID, apiErr := parseOptionalUUIDParam(c, "room_id")
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	facilityID, apiErr := parseOptionalUUIDParam(c, "facility_id")
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(msc.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if len(roomingHouseIDs) == 0 {
+		return c.JSON(http.StatusOK, []models.MaintenanceLogResponse{})
+	}
+
+	maintenanceLogs, err := msc.maintenanceScheduleRepo.FindMaintenanceLogs(roomingHouseIDs, scheduleID, roomID, facilityID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get maintenance history"))
+	}
+
+	return c.JSON(http.StatusOK, maintenanceLogs)
+}
+
+func (msc *MaintenanceScheduleController) FindMaintenanceScheduleByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	maintenanceSchedule, apiErr := msc.findAccessibleMaintenanceSchedule(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	return c.JSON(http.StatusOK, maintenanceSchedule)
+}
+
+func (msc *MaintenanceScheduleController) UpdateMaintenanceScheduleByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var scheduleBody models.UpdateMaintenanceScheduleBody
+
+	if err := c.Bind(&scheduleBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if scheduleBody.Title == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("title is required"))
+	}
+
+	nextDueDate, apiErr := validateMaintenanceInterval(scheduleBody.IntervalUnit, scheduleBody.IntervalCount, scheduleBody.LeadDays, scheduleBody.NextDueDate)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	maintenanceSchedule, apiErr := msc.findAccessibleMaintenanceSchedule(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	updatedSchedule := models.MaintenanceSchedule{
+		Title:         scheduleBody.Title,
+		Description:   scheduleBody.Description,
+		IntervalUnit:  scheduleBody.IntervalUnit,
+		IntervalCount: scheduleBody.IntervalCount,
+		LeadDays:      scheduleBody.LeadDays,
+		NextDueDate:   nextDueDate,
+		IsActive:      scheduleBody.IsActive,
+	}
+
+	if err := msc.maintenanceScheduleRepo.UpdateMaintenanceScheduleByID(c.Request().Context(), &updatedSchedule, maintenanceSchedule.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to update maintenance schedule"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "maintenance schedule updated"})
+}
+
+// CompleteMaintenanceSchedule records a service done outside of a generated work order.
+func (msc *MaintenanceScheduleController) CompleteMaintenanceSchedule(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var completeBody models.CompleteMaintenanceBody
+
+	if err := c.Bind(&completeBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if completeBody.Cost < 0 {
+		return utils.HandlerError(c, utils.NewBadRequestError("cost cannot be negative"))
+	}
+
+	maintenanceSchedule, apiErr := msc.findAccessibleMaintenanceSchedule(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if maintenanceSchedule.OpenWorkOrderID != nil {
+		return utils.HandlerError(c, utils.NewConflictError("complete the open work order of this schedule instead"))
+	}
+
+	if err := completeMaintenanceSchedule(c.Request().Context(), msc.maintenanceScheduleRepo, maintenanceSchedule, userPayload, completeBody.Note, completeBody.Cost, nil); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to complete maintenance schedule"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":       "maintenance completed",
+		"next_due_date": maintenanceSchedule.NextDueDate,
+	})
+}
+
+func (msc *MaintenanceScheduleController) DeleteMaintenanceScheduleByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	maintenanceSchedule, apiErr := msc.findAccessibleMaintenanceSchedule(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if err := msc.maintenanceScheduleRepo.DeleteMaintenanceScheduleByID(c.Request().Context(), maintenanceSchedule.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to delete maintenance schedule"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "maintenance schedule deleted"})
+}
+
+func (msc *MaintenanceScheduleController) findAccessibleMaintenanceSchedule(c echo.Context, userPayload *models.JWTPayload) (*models.MaintenanceSchedule, *utils.APIError) {
+	scheduleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, utils.NewBadRequestError("invalid maintenance schedule id")
+	}
+
+	maintenanceSchedule, err := msc.maintenanceScheduleRepo.FindMaintenanceScheduleByID(scheduleID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.NewNotFoundError("maintenance schedule not found")
+		}
+		return nil, utils.NewInternalError("failed to get maintenance schedule")
+	}
+
+	if !canAccessRoomingHouse(msc.roomingHouseRepo, userPayload, maintenanceSchedule.RoomingHouseID) {
+		return nil, utils.NewNotFoundError("maintenance schedule not found")
+	}
+
+	return maintenanceSchedule, nil
+}
+
+func parseOptionalUUIDParam(c echo.Context, name string) (*uuid.UUID, *utils.APIError) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := uuid.Parse(value)
+	if err != nil {
+		return nil, utils.NewBadRequestError("invalid " + name)
+	}
+
+	return &parsed, nil
+}
+
+func validateMaintenanceInterval(intervalUnit string, intervalCount int, leadDays int, nextDueDate string) (time.Time, *utils.APIError) {
+	if !constants.PeriodUnits[intervalUnit] {
+		return time.Time{}, utils.NewBadRequestError("invalid interval unit")
+	}
+
+	if intervalCount <= 0 {
+		return time.Time{}, utils.NewBadRequestError("interval count must be greater than 0")
+	}
+
+	if leadDays < 0 {
+		return time.Time{}, utils.NewBadRequestError("lead days cannot be negative")
+	}
+
+	if nextDueDate == "" {
+		return time.Time{}, utils.NewBadRequestError("next due date is required")
+	}
+
+	parsed, err := time.Parse(constants.DateLayout, nextDueDate)
+	if err != nil {
+		return time.Time{}, utils.NewBadRequestError("next due date must use the format " + constants.DateLayout)
+	}
+
+	return parsed, nil
+}
+
+// completeMaintenanceSchedule logs a completed service and schedules the next
+// one an interval after today.
+func completeMaintenanceSchedule(ctx context.Context, maintenanceScheduleRepo repositories.MaintenanceScheduleRepository, maintenanceSchedule *models.MaintenanceSchedule, userPayload *models.JWTPayload, note string, cost float64, workOrderID *uuid.UUID) error {
+	now := time.Now()
+
+	maintenanceLog := models.MaintenanceLog{
+		MaintenanceScheduleID: maintenanceSchedule.ID,
+		RoomingHouseID:        maintenanceSchedule.RoomingHouseID,
+		RoomID:                maintenanceSchedule.RoomID,
+		FacilityID:            maintenanceSchedule.FacilityID,
+		WorkOrderID:           workOrderID,
+		DueDate:               maintenanceSchedule.NextDueDate,
+		CompletedAt:           now,
+		CompletedBy:           userPayload.UserID,
+		CompleterRole:         userPayload.Role,
+		Note:                  note,
+		Cost:                  cost,
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	maintenanceSchedule.NextDueDate = utils.AddPeriod(today, maintenanceSchedule.IntervalUnit, maintenanceSchedule.IntervalCount)
+	maintenanceSchedule.LastCompletedAt = &now
+	maintenanceSchedule.OpenWorkOrderID = nil
+
+	return maintenanceScheduleRepo.CompleteMaintenanceSchedule(ctx, maintenanceSchedule, &maintenanceLog)
+}
@@ -0,0 +1,291 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/mailer"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// credential is the login identity of an owner, admin or tenant account.
+// For tenants UserID is the tenant ID, matching the ID carried in their tokens,
+// and RoomingHouseID is the tenant's house. Disabled is only set for admins
+// disabled by their owner.
+type credential struct {
+	UserID         uuid.UUID
+	Email          string
+	PasswordHash   string
+	RoomingHouseID uuid.UUID
+	Disabled       bool
+}
+
+func (uc *UserController) ChangePassword(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var passwordBody models.ChangePasswordBody
+
+	if err := c.Bind(&passwordBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if passwordBody.CurrentPassword == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("current password is required"))
+	}
+
+	if apiErr := validateNewPassword(passwordBody.NewPassword); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	userCredential, err := uc.findCredentialByID(userPayload.Role, userPayload.UserID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewNotFoundError("user not found"))
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(userCredential.PasswordHash), []byte(passwordBody.CurrentPassword)); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("current password is incorrect"))
+	}
+
+	if apiErr := uc.setPassword(c.Request().Context(), userPayload.Role, userPayload.UserID, passwordBody.NewPassword); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "password changed, please login again"})
+}
+
+// ForgotPassword emails a reset token. It answers the same way whether or not
+// the account exists, or the email could be sent, so it cannot be used to
+// discover registered emails. Requests are throttled per email and per IP
+// address.
+func (uc *UserController) ForgotPassword(c echo.Context) error {
+	var forgotBody models.ForgotPasswordBody
+
+	if err := c.Bind(&forgotBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if forgotBody.Email == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("email is required"))
+	}
+
+	request := models.PasswordResetRequest{
+		Email:     strings.ToLower(strings.TrimSpace(forgotBody.Email)),
+		Role:      forgotBody.Role,
+		IPAddress: c.RealIP(),
+	}
+
+	retryAfter, err := uc.passwordResetRetryAfter(request.Email, request.Role, request.IPAddress)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("could not check password reset requests"))
+	}
+
+	if retryAfter > 0 {
+		c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		return utils.HandlerError(c, utils.NewTooManyRequestsError("too many password reset requests, please try again later"))
+	}
+
+	uc.passwordResetRepo.CreatePasswordResetRequest(&request)
+
+	response := map[string]string{"message": "if the account exists, a reset token has been sent to its email"}
+
+	userCredential, err := uc.findCredentialByEmail(forgotBody.Role, request.Email)
+	if err != nil {
+		return c.JSON(http.StatusOK, response)
+	}
+
+	if err := sendPasswordReset(uc.passwordResetRepo, uc.mailer, userCredential, forgotBody.Role, "We received a request to reset your password."); err != nil {
+		log.Printf("forgot password: failed to send reset email to %s user %s: %v", forgotBody.Role, userCredential.UserID, err)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// passwordResetRetryAfter returns how long the account or the IP address has
+// to wait before it may request another password reset, or 0 if it may now.
+func (uc *UserController) passwordResetRetryAfter(email string, role string, ipAddress string) (time.Duration, error) {
+	now := time.Now()
+	since := now.Add(-constants.PasswordResetRequestWindow)
+
+	account, err := uc.passwordResetRepo.SummarizeAccountResetRequests(email, role, since)
+	if err != nil {
+		return 0, err
+	}
+
+	ip, err := uc.passwordResetRepo.SummarizeIPResetRequests(ipAddress, since)
+	if err != nil {
+		return 0, err
+	}
+
+	var retryAfter time.Duration
+	if account.FirstRequestedAt != nil && account.Requests >= constants.PasswordResetAccountLimit {
+		retryAfter = account.FirstRequestedAt.Add(constants.PasswordResetRequestWindow).Sub(now)
+	}
+
+	if ip.FirstRequestedAt != nil && ip.Requests >= constants.PasswordResetIPLimit {
+		if ipRetryAfter := ip.FirstRequestedAt.Add(constants.PasswordResetRequestWindow).Sub(now); ipRetryAfter > retryAfter {
+			retryAfter = ipRetryAfter
+		}
+	}
+
+	if retryAfter < 0 {
+		return 0, nil
+	}
+	return retryAfter, nil
+}
+
+func (uc *UserController) ResetPassword(c echo.Context) error {
+	var resetBody models.ResetPasswordBody
+
+	if err := c.Bind(&resetBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if resetBody.Token == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("token is required"))
+	}
+
+	if apiErr := validateNewPassword(resetBody.NewPassword); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	passwordReset, err := uc.passwordResetRepo.FindPasswordResetByTokenHash(utils.HashToken(resetBody.Token))
+	if err != nil || passwordReset.UsedAt != nil || time.Now().After(passwordReset.ExpiresAt) {
+		return utils.HandlerError(c, utils.NewBadRequestError("reset token is invalid or has expired"))
+	}
+
+	// Claim the token before touching the password so it cannot be replayed
+	if err := uc.passwordResetRepo.UsePasswordReset(passwordReset.ID); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("reset token is invalid or has expired"))
+	}
+
+	if apiErr := uc.setPassword(c.Request().Context(), passwordReset.Role, passwordReset.UserID, resetBody.NewPassword); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "password has been reset, please login again"})
+}
+
+// setPassword stores the new password and ends every session of the user.
+func (uc *UserController) setPassword(ctx context.Context, role string, userID uuid.UUID, password string) *utils.APIError {
+	passwordHash, err := utils.HashPassword(password)
+	if err != nil {
+		return utils.NewInternalError("failed to hash password")
+	}
+
+	switch role {
+	case "owner":
+		err = uc.ownerRepo.UpdateOwnerPassword(ctx, userID, passwordHash)
+	case "admin":
+		err = uc.adminRepo.UpdateAdminPassword(ctx, userID, passwordHash)
+	case "tenant":
+		err = uc.tenantAccountRepo.UpdateTenantAccountPassword(ctx, userID, passwordHash)
+	default:
+		return utils.NewBadRequestError("invalid role")
+	}
+
+	if err != nil {
+		return utils.NewInternalError("failed to update password")
+	}
+
+	if err := uc.sessionRepo.RevokeSessionsByUserID(userID); err != nil {
+		return utils.NewInternalError("failed to revoke sessions")
+	}
+
+	return nil
+}
+
+// sendPasswordReset creates a reset token for the account and emails it to
+// the account's address, opening with intro.
+func sendPasswordReset(passwordResetRepo repositories.PasswordResetRepository, outbox mailer.Mailer, userCredential *credential, role string, intro string) error {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	passwordReset := models.PasswordReset{
+		UserID:    userCredential.UserID,
+		Role:      role,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(constants.PasswordResetTTL),
+	}
+
+	if err := passwordResetRepo.CreatePasswordReset(&passwordReset); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("%s\n\nReset token: %s\n\nThe token expires in %d minutes and can only be used once. If you did not expect this, you can ignore this email.", intro, token, int(constants.PasswordResetTTL.Minutes()))
+	return outbox.Send(userCredential.Email, "Reset your password", body)
+}
+
+func (uc *UserController) findCredentialByEmail(role string, email string) (*credential, error) {
+	switch role {
+	case "owner":
+		owner, err := uc.ownerRepo.FindOwnerByEmail(email)
+		if err != nil {
+			return nil, err
+		}
+		return &credential{UserID: owner.ID, Email: owner.Email, PasswordHash: owner.Password}, nil
+	case "admin":
+		admin, err := uc.adminRepo.FindAdminByEmail(email)
+		if err != nil {
+			return nil, err
+		}
+		return &credential{UserID: admin.ID, Email: admin.Email, PasswordHash: admin.Password, Disabled: admin.DisabledAt != nil}, nil
+	case "tenant":
+		tenantAccount, err := uc.tenantAccountRepo.FindTenantAccountByEmail(email)
+		if err != nil {
+			return nil, err
+		}
+		return &credential{UserID: tenantAccount.TenantID, Email: tenantAccount.Email, PasswordHash: tenantAccount.Password, RoomingHouseID: tenantAccount.RoomingHouseID}, nil
+	}
+
+	return nil, fmt.Errorf("invalid role %q", role)
+}
+
+func (uc *UserController) findCredentialByID(role string, userID uuid.UUID) (*credential, error) {
+	switch role {
+	case "owner":
+		owner, err := uc.ownerRepo.FindOwnerByID(userID)
+		if err != nil {
+			return nil, err
+		}
+		return &credential{UserID: owner.ID, Email: owner.Email, PasswordHash: owner.Password}, nil
+	case "admin":
+		admin, err := uc.adminRepo.FindAdminByID(userID)
+		if err != nil {
+			return nil, err
+		}
+		return &credential{UserID: admin.ID, Email: admin.Email, PasswordHash: admin.Password, Disabled: admin.DisabledAt != nil}, nil
+	case "tenant":
+		tenantAccount, err := uc.tenantAccountRepo.FindTenantAccountByTenantID(userID)
+		if err != nil {
+			return nil, err
+		}
+		return &credential{UserID: tenantAccount.TenantID, Email: tenantAccount.Email, PasswordHash: tenantAccount.Password, RoomingHouseID: tenantAccount.RoomingHouseID}, nil
+	}
+
+	return nil, fmt.Errorf("invalid role %q", role)
+}
+
+func validateNewPassword(password string) *utils.APIError {
+	if password == "" {
+		return utils.NewBadRequestError("new password is required")
+	}
+
+	if len(password) < constants.PasswordMinLength {
+		return utils.NewBadRequestError(fmt.Sprintf("new password must be at least %d characters", constants.PasswordMinLength))
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// fakePasswordResetRepository keeps reset tokens and requests in memory.
+type fakePasswordResetRepository struct {
+	repositories.PasswordResetRepository
+	resets   []models.PasswordReset
+	requests []models.PasswordResetRequest
+}
+
+func (r *fakePasswordResetRepository) CreatePasswordReset(passwordReset *models.PasswordReset) error {
+	r.resets = append(r.resets, *passwordReset)
+	return nil
+}
+
+func (r *fakePasswordResetRepository) CreatePasswordResetRequest(passwordResetRequest *models.PasswordResetRequest) error {
+	passwordResetRequest.CreatedAt = time.Now()
+	r.requests = append(r.requests, *passwordResetRequest)
+	return nil
+}
+
+func (r *fakePasswordResetRepository) summarize(match func(models.PasswordResetRequest) bool, since time.Time) *models.PasswordResetRequestSummary {
+	summary := &models.PasswordResetRequestSummary{}
+	for i, request := range r.requests {
+		if !match(request) || !request.CreatedAt.After(since) {
+			continue
+		}
+		summary.Requests++
+		if summary.FirstRequestedAt == nil {
+			summary.FirstRequestedAt = &r.requests[i].CreatedAt
+		}
+	}
+	return summary
+}
+
+func (r *fakePasswordResetRepository) SummarizeAccountResetRequests(email string, role string, since time.Time) (*models.PasswordResetRequestSummary, error) {
+	return r.summarize(func(request models.PasswordResetRequest) bool {
+		return request.Email == email && request.Role == role
+	}, since), nil
+}
+
+func (r *fakePasswordResetRepository) SummarizeIPResetRequests(ipAddress string, since time.Time) (*models.PasswordResetRequestSummary, error) {
+	return r.summarize(func(request models.PasswordResetRequest) bool {
+		return request.IPAddress == ipAddress
+	}, since), nil
+}
+
+type fakeOwnerRepository struct {
+	repositories.OwnerRepository
+	owners map[string]*models.Owner
+}
+
+func (r *fakeOwnerRepository) FindOwnerByEmail(email string) (*models.Owner, error) {
+	owner, ok := r.owners[email]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return owner, nil
+}
+
+type failingMailer struct {
+	sent int
+}
+
+func (m *failingMailer) Send(string, string, string) error {
+	m.sent++
+	return errors.New("smtp unavailable")
+}
+
+func TestForgotPassword(t *testing.T) {
+	const knownEmail = "owner@example.com"
+
+	type attempt struct {
+		email      string
+		ipAddress  string
+		wantStatus int
+	}
+
+	tests := []struct {
+		name      string
+		attempts  []attempt
+		wantSent  int
+		wantReset int
+	}{
+		{
+			name:      "mail failure answers like an unknown account",
+			attempts:  []attempt{{email: knownEmail, ipAddress: "10.0.0.1", wantStatus: http.StatusOK}, {email: "nobody@example.com", ipAddress: "10.0.0.1", wantStatus: http.StatusOK}},
+			wantSent:  1,
+			wantReset: 1,
+		},
+		{
+			name: "account is throttled after the limit, known or not",
+			attempts: []attempt{
+				{email: "nobody@example.com", ipAddress: "10.0.0.1", wantStatus: http.StatusOK},
+				{email: "nobody@example.com", ipAddress: "10.0.0.2", wantStatus: http.StatusOK},
+				{email: " Nobody@Example.com ", ipAddress: "10.0.0.3", wantStatus: http.StatusOK},
+				{email: "nobody@example.com", ipAddress: "10.0.0.4", wantStatus: http.StatusTooManyRequests},
+				{email: knownEmail, ipAddress: "10.0.0.4", wantStatus: http.StatusOK},
+			},
+			wantSent:  1,
+			wantReset: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner := &models.Owner{Email: knownEmail}
+			owner.ID = uuid.New()
+
+			resetRepo := &fakePasswordResetRepository{}
+			outbox := &failingMailer{}
+			uc := &UserController{
+				ownerRepo:         &fakeOwnerRepository{owners: map[string]*models.Owner{knownEmail: owner}},
+				passwordResetRepo: resetRepo,
+				mailer:            outbox,
+			}
+			e := echo.New()
+
+			var okBody string
+			for i, a := range tt.attempts {
+				req := httptest.NewRequest(http.MethodPost, "/password/forgot", strings.NewReader(`{"email":"`+a.email+`","role":"owner"}`))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				req.Header.Set(echo.HeaderXRealIP, a.ipAddress)
+				rec := httptest.NewRecorder()
+
+				if err := uc.ForgotPassword(e.NewContext(req, rec)); err != nil {
+					t.Fatalf("attempt %d returned error: %v", i, err)
+				}
+
+				if rec.Code != a.wantStatus {
+					t.Fatalf("attempt %d status = %d, want %d: %s", i, rec.Code, a.wantStatus, rec.Body.String())
+				}
+
+				if rec.Code == http.StatusOK {
+					if okBody != "" && rec.Body.String() != okBody {
+						t.Errorf("attempt %d answered %q, want %q", i, rec.Body.String(), okBody)
+					}
+					okBody = rec.Body.String()
+				} else if rec.Header().Get("Retry-After") == "" {
+					t.Errorf("attempt %d has no Retry-After header", i)
+				}
+			}
+
+			if outbox.sent != tt.wantSent {
+				t.Errorf("sent %d emails, want %d", outbox.sent, tt.wantSent)
+			}
+			if len(resetRepo.resets) != tt.wantReset {
+				t.Errorf("created %d reset tokens, want %d", len(resetRepo.resets), tt.wantReset)
+			}
+		})
+	}
+}
+
+func TestPasswordResetIPLimit(t *testing.T) {
+	resetRepo := &fakePasswordResetRepository{}
+	uc := &UserController{passwordResetRepo: resetRepo}
+
+	for i := 0; i < constants.PasswordResetIPLimit; i++ {
+		resetRepo.CreatePasswordResetRequest(&models.PasswordResetRequest{Email: uuid.NewString(), Role: "owner", IPAddress: "10.0.0.9"})
+	}
+
+	retryAfter, err := uc.passwordResetRetryAfter("fresh@example.com", "owner", "10.0.0.9")
+	if err != nil {
+		t.Fatalf("passwordResetRetryAfter: %v", err)
+	}
+	if retryAfter <= 0 || retryAfter > constants.PasswordResetRequestWindow {
+		t.Errorf("retry after = %v, want within the request window", retryAfter)
+	}
+
+	retryAfter, err = uc.passwordResetRetryAfter("fresh@example.com", "owner", "10.0.0.10")
+	if err != nil {
+		t.Fatalf("passwordResetRetryAfter: %v", err)
+	}
+	if retryAfter != 0 {
+		t.Errorf("other address has to wait %v", retryAfter)
+	}
+}
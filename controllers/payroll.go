@@ -0,0 +1,331 @@
+package controllers
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/utils"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type PayrollController struct {
+	payrollRepo             repositories.PayrollRepository
+	staffRepo               repositories.StaffRepository
+	transactionCategoryRepo repositories.TransactionCategoryRepository
+	roomingHouseRepo        repositories.RoomingHouseRepository
+}
+
+func NewPayrollController(payrollRepo repositories.PayrollRepository, staffRepo repositories.StaffRepository, transactionCategoryRepo repositories.TransactionCategoryRepository, roomingHouseRepo repositories.RoomingHouseRepository) *PayrollController {
+	return &PayrollController{payrollRepo: payrollRepo, staffRepo: staffRepo, transactionCategoryRepo: transactionCategoryRepo, roomingHouseRepo: roomingHouseRepo}
+}
+
+// CreatePayrollRun generates the draft payroll of a rooming house for a month
+// with a payslip for every staff member employed during it.
+func (pc *PayrollController) CreatePayrollRun(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	var payrollBody models.PayrollRunBody
+	if err := c.Bind(&payrollBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid request body"))
+	}
+
+	if payrollBody.RoomingHouseID == uuid.Nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house id is required"))
+	}
+
+	if payrollBody.Month < 1 || payrollBody.Month > 12 {
+		return utils.HandlerError(c, utils.NewBadRequestError("month must be between 1 and 12"))
+	}
+
+	if payrollBody.Year == 0 {
+		return utils.HandlerError(c, utils.NewBadRequestError("year is required"))
+	}
+
+	now := time.Now()
+	if payrollBody.Year > now.Year() || (payrollBody.Year == now.Year() && payrollBody.Month > int(now.Month())) {
+		return utils.HandlerError(c, utils.NewBadRequestError("cannot run payroll for a future month"))
+	}
+
+	if !canAccessRoomingHouse(pc.roomingHouseRepo, userPayload, payrollBody.RoomingHouseID) {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	existing, err := pc.payrollRepo.CountPayrollRunsByPeriod(payrollBody.RoomingHouseID, payrollBody.Year, payrollBody.Month)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get payroll runs"))
+	}
+
+	if existing > 0 {
+		return utils.HandlerError(c, utils.NewConflictError("payroll for this month already exists"))
+	}
+
+	periodStart := time.Date(payrollBody.Year, time.Month(payrollBody.Month), 1, 0, 0, 0, 0, time.Local)
+	staff, err := pc.staffRepo.FindPayableStaff(payrollBody.RoomingHouseID, periodStart, periodStart.AddDate(0, 1, 0))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get staff"))
+	}
+
+	if len(*staff) == 0 {
+		return utils.HandlerError(c, utils.NewBadRequestError("no staff to pay for this month"))
+	}
+
+	payrollRun := models.PayrollRun{
+		RoomingHouseID: payrollBody.RoomingHouseID,
+		Year:           payrollBody.Year,
+		Month:          payrollBody.Month,
+		Status:         constants.PayrollStatusDraft,
+		CreatedByID:    userPayload.UserID,
+		CreatedByRole:  userPayload.Role,
+	}
+
+	for _, member := range *staff {
+		payslip := buildPayslip(member, periodStart)
+		payrollRun.TotalGross += payslip.BaseSalary + payslip.Allowances
+		payrollRun.TotalDeductions += payslip.Deductions
+		payrollRun.TotalNet += payslip.NetPay
+		payrollRun.Payslips = append(payrollRun.Payslips, payslip)
+	}
+
+	payrollRun.TotalGross = roundAmount(payrollRun.TotalGross)
+	payrollRun.TotalDeductions = roundAmount(payrollRun.TotalDeductions)
+	payrollRun.TotalNet = roundAmount(payrollRun.TotalNet)
+
+	if err := pc.payrollRepo.CreatePayrollRun(c.Request().Context(), &payrollRun); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to create payroll run"))
+	}
+
+	return c.JSON(http.StatusCreated, payrollRun)
+}
+
+func (pc *PayrollController) FindAllPayrollRuns(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	year := 0
+	if yearParam := c.QueryParam("year"); yearParam != "" {
+		parsedYear, err := strconv.Atoi(yearParam)
+		if err != nil {
+			return utils.HandlerError(c, utils.NewBadRequestError("invalid year"))
+		}
+		year = parsedYear
+	}
+
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(pc.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if len(roomingHouseIDs) == 0 {
+		return c.JSON(http.StatusOK, []models.PayrollRun{})
+	}
+
+	payrollRuns, err := pc.payrollRepo.FindAllPayrollRuns(roomingHouseIDs, year)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get payroll runs"))
+	}
+
+	return c.JSON(http.StatusOK, payrollRuns)
+}
+
+func (pc *PayrollController) FindPayrollRunByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	payrollRun, apiErr := pc.findAccessiblePayrollRun(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	return c.JSON(http.StatusOK, payrollRun)
+}
+
+// ApprovePayrollRun finalises a draft run and posts its Salary expense
+// transactions.
+func (pc *PayrollController) ApprovePayrollRun(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	payrollRun, apiErr := pc.findAccessiblePayrollRun(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if payrollRun.Status != constants.PayrollStatusDraft {
+		return utils.HandlerError(c, utils.NewConflictError("payroll run is already approved"))
+	}
+
+	salaryCategory, err := pc.transactionCategoryRepo.FindSystemTransactionCategory(constants.TransactionCategoryCodeSalary)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("salary transaction category not found"))
+	}
+
+	if err := pc.payrollRepo.ApprovePayrollRun(c.Request().Context(), payrollRun, salaryCategory.ID, userPayload.UserID, userPayload.Role); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.HandlerError(c, utils.NewConflictError("payroll run is already approved"))
+		}
+		return utils.HandlerError(c, utils.NewInternalError("failed to approve payroll run"))
+	}
+
+	return c.JSON(http.StatusOK, payrollRun)
+}
+
+// DeletePayrollRun discards a draft run so the month can be generated again.
+func (pc *PayrollController) DeletePayrollRun(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	payrollRun, apiErr := pc.findAccessiblePayrollRun(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if err := pc.payrollRepo.DeletePayrollRun(c.Request().Context(), payrollRun.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.HandlerError(c, utils.NewConflictError("approved payroll runs cannot be deleted"))
+		}
+		return utils.HandlerError(c, utils.NewInternalError("failed to delete payroll run"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "payroll run deleted"})
+}
+
+func (pc *PayrollController) FindPayslipByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	payslipID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid payslip id"))
+	}
+
+	payslip, err := pc.payrollRepo.FindPayslipByID(payslipID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.HandlerError(c, utils.NewNotFoundError("payslip not found"))
+		}
+		return utils.HandlerError(c, utils.NewInternalError("failed to get payslip"))
+	}
+
+	if !canAccessRoomingHouse(pc.roomingHouseRepo, userPayload, payslip.RoomingHouseID) {
+		return utils.HandlerError(c, utils.NewNotFoundError("payslip not found"))
+	}
+
+	roomingHouse, err := pc.roomingHouseRepo.FindRoomingHouseByID(payslip.RoomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
+	if err != nil {
+		return utils.HandlerError(c, utils.NewNotFoundError("payslip not found"))
+	}
+
+	payrollRun, err := pc.payrollRepo.FindPayrollRunByID(payslip.PayrollRunID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get payroll run"))
+	}
+
+	return c.JSON(http.StatusOK, models.PayslipResponse{
+		Payslip:          *payslip,
+		RoomingHouseName: roomingHouse.Name,
+		Status:           payrollRun.Status,
+		ApprovedAt:       payrollRun.ApprovedAt,
+	})
+}
+
+// FindMyPayslips returns the approved payslips of the staff records linked
+// to the signed in admin.
+func (pc *PayrollController) FindMyPayslips(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	if userPayload.Role != "admin" {
+		return c.JSON(http.StatusOK, []models.Payslip{})
+	}
+
+	payslips, err := pc.payrollRepo.FindPayslipsByAdminID(userPayload.UserID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get payslips"))
+	}
+
+	return c.JSON(http.StatusOK, payslips)
+}
+
+func (pc *PayrollController) findAccessiblePayrollRun(c echo.Context, userPayload *models.JWTPayload) (*models.PayrollRun, *utils.APIError) {
+	payrollRunID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, utils.NewBadRequestError("invalid payroll run id")
+	}
+
+	payrollRun, err := pc.payrollRepo.FindPayrollRunByID(payrollRunID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("payroll run not found")
+		}
+		return nil, utils.NewInternalError("failed to get payroll run")
+	}
+
+	if !canAccessRoomingHouse(pc.roomingHouseRepo, userPayload, payrollRun.RoomingHouseID) {
+		return nil, utils.NewNotFoundError("payroll run not found")
+	}
+
+	return payrollRun, nil
+}
+
+// buildPayslip computes the payslip of a staff member for the month starting at
+// periodStart. The base salary is prorated by the days the staff member was
+// employed during the month; allowances and deductions are fixed amounts.
+func buildPayslip(member models.Staff, periodStart time.Time) models.Payslip {
+	periodEnd := periodStart.AddDate(0, 1, 0)
+	daysInMonth := periodEnd.AddDate(0, 0, -1).Day()
+
+	employedFrom := periodStart
+	if member.JoinedAt != nil {
+		if joinedAt := startOfDay(*member.JoinedAt, periodStart.Location()); joinedAt.After(employedFrom) {
+			employedFrom = joinedAt
+		}
+	}
+
+	employedUntil := periodEnd
+	if member.InactiveAt != nil {
+		if inactiveAt := startOfDay(*member.InactiveAt, periodStart.Location()); inactiveAt.Before(employedUntil) {
+			employedUntil = inactiveAt
+		}
+	}
+
+	daysWorked := 0
+	if employedUntil.After(employedFrom) {
+		daysWorked = int(math.Round(employedUntil.Sub(employedFrom).Hours() / 24))
+	}
+
+	payslip := models.Payslip{
+		StaffID:        member.ID,
+		RoomingHouseID: member.RoomingHouseID,
+		Year:           periodStart.Year(),
+		Month:          int(periodStart.Month()),
+		StaffName:      member.Name,
+		Position:       member.Position,
+		BaseSalary:     roundAmount(member.BaseSalary * float64(daysWorked) / float64(daysInMonth)),
+		DaysWorked:     daysWorked,
+		DaysInMonth:    daysInMonth,
+	}
+
+	for _, component := range member.Components {
+		if component.Kind == constants.PayComponentDeduction {
+			payslip.Deductions += component.Amount
+		} else {
+			payslip.Allowances += component.Amount
+		}
+
+		payslip.Items = append(payslip.Items, models.PayslipItem{
+			Name:   component.Name,
+			Kind:   component.Kind,
+			Amount: component.Amount,
+		})
+	}
+
+	payslip.NetPay = roundAmount(payslip.BaseSalary + payslip.Allowances - payslip.Deductions)
+	return payslip
+}
+
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"testing"
+	"time"
+)
+
+func TestBuildPayslip(t *testing.T) {
+	periodStart := time.Date(2026, time.April, 1, 0, 0, 0, 0, time.Local)
+	components := []models.StaffPayComponent{
+		{Name: "Meal", Kind: constants.PayComponentAllowance, Amount: 100000},
+		{Name: "Loan", Kind: constants.PayComponentDeduction, Amount: 50000},
+	}
+	date := func(day int) *time.Time {
+		d := time.Date(2026, time.April, day, 15, 30, 0, 0, time.Local)
+		return &d
+	}
+	lastMonth := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name           string
+		joinedAt       *time.Time
+		inactiveAt     *time.Time
+		wantDaysWorked int
+		wantBaseSalary float64
+		wantNetPay     float64
+	}{
+		{name: "whole month", wantDaysWorked: 30, wantBaseSalary: 3000000, wantNetPay: 3050000},
+		{name: "joined before the month", joinedAt: &lastMonth, wantDaysWorked: 30, wantBaseSalary: 3000000, wantNetPay: 3050000},
+		{name: "joined mid month", joinedAt: date(16), wantDaysWorked: 15, wantBaseSalary: 1500000, wantNetPay: 1550000},
+		{name: "joined on the last day", joinedAt: date(30), wantDaysWorked: 1, wantBaseSalary: 100000, wantNetPay: 150000},
+		{name: "made inactive mid month", inactiveAt: date(11), wantDaysWorked: 10, wantBaseSalary: 1000000, wantNetPay: 1050000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			member := models.Staff{
+				Name:       "Budi",
+				Position:   "Cleaner",
+				BaseSalary: 3000000,
+				JoinedAt:   tt.joinedAt,
+				InactiveAt: tt.inactiveAt,
+				Components: components,
+			}
+
+			payslip := buildPayslip(member, periodStart)
+
+			if payslip.DaysWorked != tt.wantDaysWorked || payslip.DaysInMonth != 30 {
+				t.Errorf("days worked = %d/%d, want %d/30", payslip.DaysWorked, payslip.DaysInMonth, tt.wantDaysWorked)
+			}
+			if payslip.BaseSalary != tt.wantBaseSalary {
+				t.Errorf("base salary = %v, want %v", payslip.BaseSalary, tt.wantBaseSalary)
+			}
+			if payslip.Allowances != 100000 || payslip.Deductions != 50000 {
+				t.Errorf("allowances, deductions = %v, %v; want 100000, 50000", payslip.Allowances, payslip.Deductions)
+			}
+			if payslip.NetPay != tt.wantNetPay {
+				t.Errorf("net pay = %v, want %v", payslip.NetPay, tt.wantNetPay)
+			}
+		})
+	}
+}
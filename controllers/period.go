@@ -2,26 +2,199 @@ package controllers
 
 import (
 	"net/http"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
 	"rooming-house-cms-be/repositories"
 	"rooming-house-cms-be/utils"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 type PeriodController struct {
-	periodRepo repositories.PeriodRepository
+	periodRepo       repositories.PeriodRepository
+	roomingHouseRepo repositories.RoomingHouseRepository
 }
 
-func NewPeriodController(periodRepo repositories.PeriodRepository) *PeriodController {
-	return &PeriodController{periodRepo: periodRepo}
+func NewPeriodController(periodRepo repositories.PeriodRepository, roomingHouseRepo repositories.RoomingHouseRepository) *PeriodController {
+	return &PeriodController{periodRepo: periodRepo, roomingHouseRepo: roomingHouseRepo}
 }
 
 func (pc *PeriodController) GetAllPeriods(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
 
-	periods, err := pc.periodRepo.FindAllPeriods()
+	ownerID, err := requestOwnerID(pc.roomingHouseRepo, c, userPayload)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	periods, err := pc.periodRepo.FindAllPeriods(ownerID)
 	if err != nil {
 		return utils.HandlerError(c, utils.NewInternalError(err.Error()))
 	}
 
 	return c.JSON(http.StatusOK, periods)
 }
+
+func (pc *PeriodController) CreatePeriod(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var periodBody models.PeriodBody
+
+	if err := c.Bind(&periodBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if apiErr := validatePeriodBody(periodBody); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	ownerID, err := requestOwnerID(pc.roomingHouseRepo, c, userPayload)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	newPeriod := models.Period{
+		Name:    periodBody.Name,
+		Unit:    periodBody.Unit,
+		Count:   periodBody.Count,
+		OwnerID: &ownerID,
+	}
+
+	if err := pc.periodRepo.CreatePeriod(c.Request().Context(), &newPeriod); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to create period"))
+	}
+
+	return c.JSON(http.StatusCreated, newPeriod)
+}
+
+func (pc *PeriodController) UpdatePeriodByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var periodBody models.PeriodBody
+
+	if err := c.Bind(&periodBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if apiErr := validatePeriodBody(periodBody); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	period, apiErr := pc.findOwnedPeriod(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	// Changing the length would silently change the coverage of existing prices and tenants
+	if period.Unit != periodBody.Unit || period.Count != periodBody.Count {
+		references, err := pc.periodRepo.CountPeriodReferences(period.ID)
+		if err != nil {
+			return utils.HandlerError(c, utils.NewInternalError("failed to check period usage"))
+		}
+
+		if references > 0 {
+			return utils.HandlerError(c, utils.NewConflictError("period length cannot be changed while it is in use"))
+		}
+	}
+
+	updatedPeriod := models.Period{
+		Name:  periodBody.Name,
+		Unit:  periodBody.Unit,
+		Count: periodBody.Count,
+	}
+
+	if err := pc.periodRepo.UpdatePeriodByID(c.Request().Context(), &updatedPeriod, period.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to update period"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "period updated"})
+}
+
+func (pc *PeriodController) DeletePeriodByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	period, apiErr := pc.findOwnedPeriod(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	references, err := pc.periodRepo.CountPeriodReferences(period.ID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to check period usage"))
+	}
+
+	if references > 0 {
+		return utils.HandlerError(c, utils.NewConflictError("period is still used by prices or tenants"))
+	}
+
+	if err := pc.periodRepo.DeletePeriodByID(c.Request().Context(), period.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to delete period"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "period deleted"})
+}
+
+// findOwnedPeriod loads a custom period of the rooming house owner; global
+// periods are read-only.
+func (pc *PeriodController) findOwnedPeriod(c echo.Context, userPayload *models.JWTPayload) (*models.Period, *utils.APIError) {
+	periodID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, utils.NewBadRequestError("invalid period id")
+	}
+
+	period, err := pc.periodRepo.FindPeriodByID(periodID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.NewNotFoundError("period not found")
+		}
+		return nil, utils.NewInternalError("failed to get period")
+	}
+
+	if period.OwnerID == nil {
+		return nil, utils.NewForbiddenError("global periods cannot be changed")
+	}
+
+	ownerID, err := requestOwnerID(pc.roomingHouseRepo, c, userPayload)
+	if err != nil || *period.OwnerID != ownerID {
+		return nil, utils.NewNotFoundError("period not found")
+	}
+
+	return period, nil
+}
+
+func validatePeriodBody(periodBody models.PeriodBody) *utils.APIError {
+	if periodBody.Name == "" {
+		return utils.NewBadRequestError("name is required")
+	}
+
+	if !constants.PeriodUnits[periodBody.Unit] {
+		return utils.NewBadRequestError("invalid unit")
+	}
+
+	if periodBody.Count <= 0 {
+		return utils.NewBadRequestError("count must be greater than 0")
+	}
+
+	return nil
+}
+
+// validatePeriodPrices checks a period ID to price map from a request body:
+// every period must be available to the owner and every price positive.
+func validatePeriodPrices(periodRepo repositories.PeriodRepository, prices map[uuid.UUID]float64, ownerID uuid.UUID) *utils.APIError {
+	if len(prices) == 0 {
+		return utils.NewBadRequestError("prices is required")
+	}
+
+	for periodID, price := range prices {
+		period, err := periodRepo.FindPeriodByID(periodID)
+		if err != nil || (period.OwnerID != nil && *period.OwnerID != ownerID) {
+			return utils.NewBadRequestError("period " + periodID.String() + " not found")
+		}
+
+		if price <= 0 {
+			return utils.NewBadRequestError(period.Name + " price must be greater than 0")
+		}
+	}
+
+	return nil
+}
@@ -37,32 +37,18 @@ func (ppc *PricingPackageController) CreatePricingPackage(c echo.Context) error
 		return utils.HandlerError(c, utils.NewBadRequestError("rooming house id is required"))
 	}
 
-	if pricingPackageBody.DailyPrice == 0 {
-		return utils.HandlerError(c, utils.NewBadRequestError("daily price is required"))
+	roomingHouseID, apiErr := targetRoomingHouseID(userPayload, pricingPackageBody.RoomingHouseID)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
-	if pricingPackageBody.WeeklyPrice == 0 {
-		return utils.HandlerError(c, utils.NewBadRequestError("weekly price is required"))
-	}
-
-	if pricingPackageBody.MonthlyPrice == 0 {
-		return utils.HandlerError(c, utils.NewBadRequestError("monthly price is required"))
-	}
-
-	if pricingPackageBody.AnnualPrice == 0 {
-		return utils.HandlerError(c, utils.NewBadRequestError("yearly price is required"))
-	}
-
-	if userPayload.Role == "owner" && pricingPackageBody.RoomingHouseID == uuid.Nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("rooming house id is required"))
+	ownerID, err := resolveOwnerID(ppc.roomingHouseRepo, userPayload, roomingHouseID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get rooming house"))
 	}
 
-	var roomingHouseID uuid.UUID
-
-	if userPayload.Role == "owner" {
-		roomingHouseID = pricingPackageBody.RoomingHouseID
-	} else {
-		roomingHouseID = userPayload.RoomingHouseID
+	if apiErr := validatePeriodPrices(ppc.periodRepo, pricingPackageBody.Prices, ownerID); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
 	newPricingPackage := models.PricingPackage{
@@ -70,54 +56,20 @@ func (ppc *PricingPackageController) CreatePricingPackage(c echo.Context) error
 		RoomingHouseID: roomingHouseID,
 	}
 
-	if err := ppc.pricingPackageRepo.CreatePricingPackage(&newPricingPackage); err != nil {
+	if err := ppc.pricingPackageRepo.CreatePricingPackage(c.Request().Context(), &newPricingPackage); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("failed to create pricing package"))
 	}
 
-	daily, err := ppc.periodRepo.FindPeriodByName("Daily")
-	if err != nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("failed to find daily id"))
-	}
-
-	weekly, err := ppc.periodRepo.FindPeriodByName("Weekly")
-	if err != nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("failed to find weekly id"))
-	}
-
-	monthly, err := ppc.periodRepo.FindPeriodByName("Monthly")
-	if err != nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("failed to find monthly id"))
-	}
-
-	annual, err := ppc.periodRepo.FindPeriodByName("Annually")
-	if err != nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("failed to find annual id"))
-	}
-
-	periodPackage := []models.PeriodPackage{
-		{
+	var periodPackage []models.PeriodPackage
+	for periodID, price := range pricingPackageBody.Prices {
+		periodPackage = append(periodPackage, models.PeriodPackage{
 			PricingPackageID: newPricingPackage.ID,
-			PeriodID:         daily.ID,
-			Price:            pricingPackageBody.DailyPrice,
-		},
-		{
-			PricingPackageID: newPricingPackage.ID,
-			PeriodID:         weekly.ID,
-			Price:            pricingPackageBody.WeeklyPrice,
-		},
-		{
-			PricingPackageID: newPricingPackage.ID,
-			PeriodID:         monthly.ID,
-			Price:            pricingPackageBody.MonthlyPrice,
-		},
-		{
-			PricingPackageID: newPricingPackage.ID,
-			PeriodID:         annual.ID,
-			Price:            pricingPackageBody.AnnualPrice,
-		},
+			PeriodID:         periodID,
+			Price:            price,
+		})
 	}
 
-	if err := ppc.periodPackageRepo.CreatePeriodPackage(&periodPackage); err != nil {
+	if err := ppc.periodPackageRepo.CreatePeriodPackage(c.Request().Context(), &periodPackage); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("failed to create period package"))
 	}
 
@@ -126,31 +78,9 @@ func (ppc *PricingPackageController) CreatePricingPackage(c echo.Context) error
 
 func (ppc *PricingPackageController) GetAllPricingPackages(c echo.Context) error {
 	userPayload := c.Get("userPayload").(*models.JWTPayload)
-	filteredRoomingHouseID := c.QueryParam("rooming_house_id")
-
-	var roomingHouseIDs []uuid.UUID
-
-	if userPayload.Role == "admin" {
-		roomingHouseIDs = append(roomingHouseIDs, userPayload.RoomingHouseID)
-	} else {
-		if filteredRoomingHouseID == "" {
-			IDs, err := ppc.roomingHouseRepo.FindAllRoomingHouse(userPayload.RoomingHouseID, userPayload.UserID, userPayload.Role)
-
-			if err != nil {
-				return utils.HandlerError(c, utils.NewBadRequestError("failed to get rooming house"))
-			}
-
-			for _, roomingHouseID := range IDs {
-				roomingHouseIDs = append(roomingHouseIDs, roomingHouseID.ID)
-			}
-		} else {
-			roomingHouseUUID, err := uuid.Parse(filteredRoomingHouseID)
-			if err != nil {
-				return utils.HandlerError(c, utils.NewBadRequestError("invalid rooming house id"))
-			}
-
-			roomingHouseIDs = append(roomingHouseIDs, roomingHouseUUID)
-		}
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(ppc.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
 	pricingPackages, err := ppc.pricingPackageRepo.FindAllPricingPackages(roomingHouseIDs)
@@ -163,6 +93,7 @@ func (ppc *PricingPackageController) GetAllPricingPackages(c echo.Context) error
 
 func (ppc *PricingPackageController) UpdatePricingPackage(c echo.Context) error {
 	var pricingPackageBody models.UpdatePricingPackageBody
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
 	pricingPackageID := c.Param("id")
 
 	if err := c.Bind(&pricingPackageBody); err != nil {
@@ -173,82 +104,53 @@ func (ppc *PricingPackageController) UpdatePricingPackage(c echo.Context) error
 		return utils.HandlerError(c, utils.NewBadRequestError("name is required"))
 	}
 
-	if pricingPackageBody.DailyPrice == 0 {
-		return utils.HandlerError(c, utils.NewBadRequestError("daily price is required"))
-	}
-
-	if pricingPackageBody.WeeklyPrice == 0 {
-		return utils.HandlerError(c, utils.NewBadRequestError("weekly price is required"))
-	}
-
-	if pricingPackageBody.MonthlyPrice == 0 {
-		return utils.HandlerError(c, utils.NewBadRequestError("monthly price is required"))
-	}
-
-	if pricingPackageBody.AnnualPrice == 0 {
-		return utils.HandlerError(c, utils.NewBadRequestError("yearly price is required"))
-	}
-
 	pricingPackageUUID, err := uuid.Parse(pricingPackageID)
 	if err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("invalid pricing package id"))
 	}
 
 	pricingPackage, err := ppc.pricingPackageRepo.FindPricingPackageByID(pricingPackageUUID)
-	if err != nil {
+	if err != nil || !canAccessRoomingHouse(ppc.roomingHouseRepo, userPayload, pricingPackage.RoomingHouseID) {
 		return utils.HandlerError(c, utils.NewBadRequestError("pricing package not found"))
 	}
 
-	pricingPackage.Name = pricingPackageBody.Name
-
-	if err := ppc.pricingPackageRepo.UpdatePricingPackageByID(pricingPackage, pricingPackageUUID); err != nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("failed to update pricing package"))
+	ownerID, err := resolveOwnerID(ppc.roomingHouseRepo, userPayload, pricingPackage.RoomingHouseID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get rooming house"))
 	}
 
-	daily, err := ppc.periodRepo.FindPeriodByName("Daily")
-	if err != nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("failed to find daily id"))
+	if apiErr := validatePeriodPrices(ppc.periodRepo, pricingPackageBody.Prices, ownerID); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
-	weekly, err := ppc.periodRepo.FindPeriodByName("Weekly")
-	if err != nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("failed to find weekly id"))
+	var periodIDs []uuid.UUID
+	var periodPackage []models.PeriodPackage
+	for periodID, price := range pricingPackageBody.Prices {
+		periodIDs = append(periodIDs, periodID)
+		periodPackage = append(periodPackage, models.PeriodPackage{
+			PricingPackageID: pricingPackage.ID,
+			PeriodID:         periodID,
+			Price:            price,
+		})
 	}
 
-	monthly, err := ppc.periodRepo.FindPeriodByName("Monthly")
+	// Tenants already billed on a period must keep a price for it
+	tenantCount, err := ppc.periodPackageRepo.CountTenantsOutsidePeriods(pricingPackage.ID, periodIDs)
 	if err != nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("failed to find monthly id"))
+		return utils.HandlerError(c, utils.NewInternalError("failed to check tenant periods"))
 	}
 
-	annual, err := ppc.periodRepo.FindPeriodByName("Annually")
-	if err != nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("failed to find annual id"))
+	if tenantCount > 0 {
+		return utils.HandlerError(c, utils.NewConflictError("cannot remove a period that tenants of this package are billed on"))
 	}
 
-	periodPackage := []models.PeriodPackage{
-		{
-			PricingPackageID: pricingPackage.ID,
-			PeriodID:         daily.ID,
-			Price:            pricingPackageBody.DailyPrice,
-		},
-		{
-			PricingPackageID: pricingPackage.ID,
-			PeriodID:         weekly.ID,
-			Price:            pricingPackageBody.WeeklyPrice,
-		},
-		{
-			PricingPackageID: pricingPackage.ID,
-			PeriodID:         monthly.ID,
-			Price:            pricingPackageBody.MonthlyPrice,
-		},
-		{
-			PricingPackageID: pricingPackage.ID,
-			PeriodID:         annual.ID,
-			Price:            pricingPackageBody.AnnualPrice,
-		},
+	pricingPackage.Name = pricingPackageBody.Name
+
+	if err := ppc.pricingPackageRepo.UpdatePricingPackageByID(c.Request().Context(), pricingPackage, pricingPackageUUID); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("failed to update pricing package"))
 	}
 
-	if err := ppc.periodPackageRepo.UpdatePeriodPackageByPackageID(periodPackage, pricingPackageUUID); err != nil {
+	if err := ppc.periodPackageRepo.UpdatePeriodPackageByPackageID(c.Request().Context(), periodPackage, pricingPackageUUID); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("failed to update period package"))
 	}
 
@@ -256,13 +158,19 @@ func (ppc *PricingPackageController) UpdatePricingPackage(c echo.Context) error
 }
 
 func (ppc *PricingPackageController) DeletePricingPackage(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
 	pricingPackageID := c.Param("id")
 	pricingPackageUUID, err := uuid.Parse(pricingPackageID)
 	if err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("invalid pricing package id"))
 	}
 
-	if err := ppc.pricingPackageRepo.DeletePricingPackageByID(pricingPackageUUID); err != nil {
+	pricingPackage, err := ppc.pricingPackageRepo.FindPricingPackageByID(pricingPackageUUID)
+	if err != nil || !canAccessRoomingHouse(ppc.roomingHouseRepo, userPayload, pricingPackage.RoomingHouseID) {
+		return utils.HandlerError(c, utils.NewBadRequestError("pricing package not found"))
+	}
+
+	if err := ppc.pricingPackageRepo.DeletePricingPackageByID(c.Request().Context(), pricingPackageUUID); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("failed to delete pricing package"))
 	}
 
@@ -0,0 +1,408 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type RecurringExpenseController struct {
+	recurringExpenseRepo    repositories.RecurringExpenseRepository
+	transactionCategoryRepo repositories.TransactionCategoryRepository
+	roomingHouseRepo        repositories.RoomingHouseRepository
+}
+
+func NewRecurringExpenseController(recurringExpenseRepo repositories.RecurringExpenseRepository, transactionCategoryRepo repositories.TransactionCategoryRepository, roomingHouseRepo repositories.RoomingHouseRepository) *RecurringExpenseController {
+	return &RecurringExpenseController{recurringExpenseRepo: recurringExpenseRepo, transactionCategoryRepo: transactionCategoryRepo, roomingHouseRepo: roomingHouseRepo}
+}
+
+func (rec *RecurringExpenseController) CreateRecurringExpense(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var expenseBody models.AddRecurringExpenseBody
+
+	if err := c.Bind(&expenseBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	expenseBody.Name = strings.TrimSpace(expenseBody.Name)
+	if expenseBody.Name == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("name is required"))
+	}
+
+	if expenseBody.RoomingHouseID == uuid.Nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house id is required"))
+	}
+
+	if apiErr := validateRecurringExpense(expenseBody.Amount, expenseBody.DayOfMonth); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	startDate := time.Now()
+	if expenseBody.StartDate != "" {
+		parsed, err := time.ParseInLocation(constants.DateLayout, expenseBody.StartDate, time.Local)
+		if err != nil {
+			return utils.HandlerError(c, utils.NewBadRequestError("start date must use the format "+constants.DateLayout))
+		}
+		startDate = parsed
+	}
+	startDate = time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.Local)
+
+	endDate, apiErr := parseRecurringExpenseEndDate(expenseBody.EndDate, startDate)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if !canAccessRoomingHouse(rec.roomingHouseRepo, userPayload, expenseBody.RoomingHouseID) {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	if apiErr := rec.checkExpenseCategory(expenseBody.TransactionCategoryID, expenseBody.RoomingHouseID, userPayload); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	// The first draft is due on the first matching day on or after the start
+	nextDueDate := utils.MonthDay(startDate.Year(), startDate.Month(), expenseBody.DayOfMonth)
+	if nextDueDate.Before(startDate) {
+		nextDueDate = utils.MonthDay(startDate.Year(), startDate.Month()+1, expenseBody.DayOfMonth)
+	}
+
+	recurringExpense := models.RecurringExpense{
+		Name:                  expenseBody.Name,
+		RoomingHouseID:        expenseBody.RoomingHouseID,
+		TransactionCategoryID: expenseBody.TransactionCategoryID,
+		Amount:                expenseBody.Amount,
+		DayOfMonth:            expenseBody.DayOfMonth,
+		EndDate:               endDate,
+		NextDueDate:           nextDueDate,
+		IsActive:              true,
+	}
+
+	if err := rec.recurringExpenseRepo.CreateRecurringExpense(c.Request().Context(), &recurringExpense); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to create recurring expense"))
+	}
+
+	return c.JSON(http.StatusOK, recurringExpense)
+}
+
+func (rec *RecurringExpenseController) FindAllRecurringExpenses(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(rec.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if len(roomingHouseIDs) == 0 {
+		return c.JSON(http.StatusOK, []models.RecurringExpenseResponse{})
+	}
+
+	recurringExpenses, err := rec.recurringExpenseRepo.FindAllRecurringExpenses(roomingHouseIDs)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get recurring expenses"))
+	}
+
+	return c.JSON(http.StatusOK, recurringExpenses)
+}
+
+func (rec *RecurringExpenseController) FindRecurringExpenseByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	recurringExpense, apiErr := rec.findAccessibleRecurringExpense(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	return c.JSON(http.StatusOK, recurringExpense)
+}
+
+// UpdateRecurringExpenseByID changes the template. Drafts already created keep
+// their amount; a new day of month moves the next draft within its month.
+func (rec *RecurringExpenseController) UpdateRecurringExpenseByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var expenseBody models.UpdateRecurringExpenseBody
+
+	if err := c.Bind(&expenseBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	expenseBody.Name = strings.TrimSpace(expenseBody.Name)
+	if expenseBody.Name == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("name is required"))
+	}
+
+	if apiErr := validateRecurringExpense(expenseBody.Amount, expenseBody.DayOfMonth); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	recurringExpense, apiErr := rec.findAccessibleRecurringExpense(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	nextDueDate := utils.MonthDay(recurringExpense.NextDueDate.Year(), recurringExpense.NextDueDate.Month(), expenseBody.DayOfMonth)
+
+	endDate, apiErr := parseRecurringExpenseEndDate(expenseBody.EndDate, time.Time{})
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if apiErr := rec.checkExpenseCategory(expenseBody.TransactionCategoryID, recurringExpense.RoomingHouseID, userPayload); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	updatedExpense := models.RecurringExpense{
+		Name:                  expenseBody.Name,
+		TransactionCategoryID: expenseBody.TransactionCategoryID,
+		Amount:                expenseBody.Amount,
+		DayOfMonth:            expenseBody.DayOfMonth,
+		EndDate:               endDate,
+		NextDueDate:           nextDueDate,
+		IsActive:              expenseBody.IsActive,
+	}
+
+	if err := rec.recurringExpenseRepo.UpdateRecurringExpenseByID(c.Request().Context(), &updatedExpense, recurringExpense.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to update recurring expense"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "recurring expense updated"})
+}
+
+func (rec *RecurringExpenseController) DeleteRecurringExpenseByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	recurringExpense, apiErr := rec.findAccessibleRecurringExpense(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if err := rec.recurringExpenseRepo.DeleteRecurringExpenseByID(c.Request().Context(), recurringExpense.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to delete recurring expense"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "recurring expense deleted"})
+}
+
+// FindAllRecurringExpenseDrafts lists the drafts, filtered by status and, with
+// skipped=true, to those flagged as skipped.
+func (rec *RecurringExpenseController) FindAllRecurringExpenseDrafts(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	status := c.QueryParam("status")
+	if status != "" && !constants.RecurringExpenseDraftStatuses[status] {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid status"))
+	}
+
+	skippedOnly := false
+	if skippedParam := c.QueryParam("skipped"); skippedParam != "" {
+		parsed, err := strconv.ParseBool(skippedParam)
+		if err != nil {
+			return utils.HandlerError(c, utils.NewBadRequestError("skipped must be true or false"))
+		}
+		skippedOnly = parsed
+	}
+
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(rec.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if len(roomingHouseIDs) == 0 {
+		return c.JSON(http.StatusOK, []models.RecurringExpenseDraftResponse{})
+	}
+
+	drafts, err := rec.recurringExpenseRepo.FindAllRecurringExpenseDrafts(roomingHouseIDs, status, skippedOnly)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get recurring expense drafts"))
+	}
+
+	return c.JSON(http.StatusOK, drafts)
+}
+
+// ConfirmRecurringExpenseDraft posts the draft as an expense transaction,
+// with the amount, date or description adjusted when given.
+func (rec *RecurringExpenseController) ConfirmRecurringExpenseDraft(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var confirmBody models.ConfirmRecurringExpenseDraftBody
+
+	if err := c.Bind(&confirmBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if confirmBody.Amount < 0 {
+		return utils.HandlerError(c, utils.NewBadRequestError("amount cannot be negative"))
+	}
+
+	draft, apiErr := rec.findAccessibleRecurringExpenseDraft(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if draft.Status != constants.RecurringExpenseDraftPending {
+		return utils.HandlerError(c, utils.NewConflictError("recurring expense draft is already "+draft.Status))
+	}
+
+	date := draft.DueDate
+	if confirmBody.Date != "" {
+		parsed, err := time.Parse(constants.DateLayout, confirmBody.Date)
+		if err != nil {
+			return utils.HandlerError(c, utils.NewBadRequestError("date must use the format "+constants.DateLayout))
+		}
+		date = parsed
+	}
+
+	transaction := models.Transaction{
+		Day:                   date.Day(),
+		Month:                 int(date.Month()),
+		Year:                  date.Year(),
+		Amount:                draft.Amount,
+		Description:           draft.Description,
+		TransactionCategoryID: draft.TransactionCategoryID,
+		RoomingHouseID:        draft.RoomingHouseID,
+	}
+
+	if confirmBody.Amount > 0 {
+		transaction.Amount = confirmBody.Amount
+	}
+
+	if description := strings.TrimSpace(confirmBody.Description); description != "" {
+		transaction.Description = description
+	}
+
+	if err := rec.recurringExpenseRepo.ConfirmRecurringExpenseDraft(c.Request().Context(), draft, &transaction, userPayload.UserID, userPayload.Role); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.HandlerError(c, utils.NewConflictError("recurring expense draft is no longer pending"))
+		}
+		return utils.HandlerError(c, utils.NewInternalError("failed to confirm recurring expense draft"))
+	}
+
+	return c.JSON(http.StatusOK, draft)
+}
+
+// DismissRecurringExpenseDraft closes a draft for a month the expense did not
+// happen, without posting a transaction.
+func (rec *RecurringExpenseController) DismissRecurringExpenseDraft(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	draft, apiErr := rec.findAccessibleRecurringExpenseDraft(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if draft.Status != constants.RecurringExpenseDraftPending {
+		return utils.HandlerError(c, utils.NewConflictError("recurring expense draft is already "+draft.Status))
+	}
+
+	if err := rec.recurringExpenseRepo.DismissRecurringExpenseDraft(c.Request().Context(), draft, userPayload.UserID, userPayload.Role); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.HandlerError(c, utils.NewConflictError("recurring expense draft is no longer pending"))
+		}
+		return utils.HandlerError(c, utils.NewInternalError("failed to dismiss recurring expense draft"))
+	}
+
+	return c.JSON(http.StatusOK, draft)
+}
+
+func (rec *RecurringExpenseController) findAccessibleRecurringExpense(c echo.Context, userPayload *models.JWTPayload) (*models.RecurringExpense, *utils.APIError) {
+	recurringExpenseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, utils.NewBadRequestError("invalid recurring expense id")
+	}
+
+	recurringExpense, err := rec.recurringExpenseRepo.FindRecurringExpenseByID(recurringExpenseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("recurring expense not found")
+		}
+		return nil, utils.NewInternalError("failed to get recurring expense")
+	}
+
+	if !canAccessRoomingHouse(rec.roomingHouseRepo, userPayload, recurringExpense.RoomingHouseID) {
+		return nil, utils.NewNotFoundError("recurring expense not found")
+	}
+
+	return recurringExpense, nil
+}
+
+func (rec *RecurringExpenseController) findAccessibleRecurringExpenseDraft(c echo.Context, userPayload *models.JWTPayload) (*models.RecurringExpenseDraft, *utils.APIError) {
+	draftID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, utils.NewBadRequestError("invalid recurring expense draft id")
+	}
+
+	draft, err := rec.recurringExpenseRepo.FindRecurringExpenseDraftByID(draftID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("recurring expense draft not found")
+		}
+		return nil, utils.NewInternalError("failed to get recurring expense draft")
+	}
+
+	if !canAccessRoomingHouse(rec.roomingHouseRepo, userPayload, draft.RoomingHouseID) {
+		return nil, utils.NewNotFoundError("recurring expense draft not found")
+	}
+
+	return draft, nil
+}
+
+// checkExpenseCategory accepts expense categories of the rooming house other
+// than the system ones, which are posted by their own flows.
+func (rec *RecurringExpenseController) checkExpenseCategory(transactionCategoryID uuid.UUID, roomingHouseID uuid.UUID, userPayload *models.JWTPayload) *utils.APIError {
+	if transactionCategoryID == uuid.Nil {
+		return utils.NewBadRequestError("transaction category id is required")
+	}
+
+	roomingHouse, err := rec.roomingHouseRepo.FindRoomingHouseByID(roomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
+	if err != nil {
+		return utils.NewBadRequestError("rooming house not found")
+	}
+
+	transactionCategory, err := rec.transactionCategoryRepo.FindTransactionCategoryByID(transactionCategoryID)
+	if err != nil || !transactionCategoryAvailableToRoomingHouse(transactionCategory, roomingHouse) {
+		return utils.NewBadRequestError("transaction category not found")
+	}
+
+	if !transactionCategory.IsExpense || transactionCategory.Code != "" {
+		return utils.NewBadRequestError("transaction category must be a non-system expense category")
+	}
+
+	return nil
+}
+
+func validateRecurringExpense(amount float64, dayOfMonth int) *utils.APIError {
+	if amount <= 0 {
+		return utils.NewBadRequestError("amount must be greater than 0")
+	}
+
+	if dayOfMonth < 1 || dayOfMonth > 31 {
+		return utils.NewBadRequestError("day of month must be between 1 and 31")
+	}
+
+	return nil
+}
+
+func parseRecurringExpenseEndDate(endDate string, startDate time.Time) (*time.Time, *utils.APIError) {
+	if endDate == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.ParseInLocation(constants.DateLayout, endDate, time.Local)
+	if err != nil {
+		return nil, utils.NewBadRequestError("end date must use the format " + constants.DateLayout)
+	}
+
+	if parsed.Before(startDate) {
+		return nil, utils.NewBadRequestError("end date cannot be before the start date")
+	}
+
+	return &parsed, nil
+}
@@ -0,0 +1,227 @@
+package controllers
+
+import (
+	"net/http"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/utils"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type RoleController struct {
+	roleRepo repositories.RoleRepository
+}
+
+func NewRoleController(roleRepo repositories.RoleRepository) *RoleController {
+	return &RoleController{roleRepo: roleRepo}
+}
+
+// GetAllPermissions lists the permissions that can be granted to a role.
+func (rc *RoleController) GetAllPermissions(c echo.Context) error {
+	var resources []string
+	for resource := range constants.PermissionActions {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+
+	permissions := []models.PermissionResponse{}
+	for _, resource := range resources {
+		var actions []string
+		for _, action := range constants.PermissionActions[resource] {
+			if !constants.OwnerOnlyPermissions[constants.Permission(resource, action)] {
+				actions = append(actions, action)
+			}
+		}
+
+		if len(actions) > 0 {
+			permissions = append(permissions, models.PermissionResponse{Resource: resource, Actions: actions})
+		}
+	}
+
+	return c.JSON(http.StatusOK, permissions)
+}
+
+func (rc *RoleController) GetAllRoles(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	roles, err := rc.roleRepo.FindAllRoles(userPayload.UserID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get roles"))
+	}
+
+	return c.JSON(http.StatusOK, roles)
+}
+
+func (rc *RoleController) CreateRole(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var roleBody models.RoleBody
+
+	if err := c.Bind(&roleBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	permissions, apiErr := validateRoleBody(roleBody)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	newRole := models.Role{
+		OwnerID:     &userPayload.UserID,
+		Name:        roleBody.Name,
+		Description: roleBody.Description,
+		Permissions: permissions,
+	}
+
+	if err := rc.roleRepo.CreateRole(c.Request().Context(), &newRole); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to create role"))
+	}
+
+	return c.JSON(http.StatusCreated, newRole)
+}
+
+func (rc *RoleController) UpdateRoleByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var roleBody models.RoleBody
+
+	if err := c.Bind(&roleBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	permissions, apiErr := validateRoleBody(roleBody)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	role, apiErr := rc.findOwnedRole(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	role.Name = roleBody.Name
+	role.Description = roleBody.Description
+	role.Permissions = permissions
+
+	if err := rc.roleRepo.UpdateRole(c.Request().Context(), role); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to update role"))
+	}
+
+	return c.JSON(http.StatusOK, role)
+}
+
+func (rc *RoleController) DeleteRoleByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	role, apiErr := rc.findOwnedRole(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	assignments, err := rc.roleRepo.CountRoleAssignments(role.ID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to check role usage"))
+	}
+
+	if assignments > 0 {
+		return utils.HandlerError(c, utils.NewConflictError("role is still assigned to admins"))
+	}
+
+	if err := rc.roleRepo.DeleteRoleByID(c.Request().Context(), role.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to delete role"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "role deleted"})
+}
+
+// findOwnedRole loads a custom role of the owner; system roles are read-only.
+func (rc *RoleController) findOwnedRole(c echo.Context, userPayload *models.JWTPayload) (*models.Role, *utils.APIError) {
+	roleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, utils.NewBadRequestError("invalid role id")
+	}
+
+	role, err := rc.roleRepo.FindRoleByID(roleID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.NewNotFoundError("role not found")
+		}
+		return nil, utils.NewInternalError("failed to get role")
+	}
+
+	if role.OwnerID == nil {
+		return nil, utils.NewForbiddenError("system roles cannot be changed")
+	}
+
+	if *role.OwnerID != userPayload.UserID {
+		return nil, utils.NewNotFoundError("role not found")
+	}
+
+	return role, nil
+}
+
+// validateRoleBody checks the name and turns the "resource:action" strings
+// into role permissions. Owner-only permissions cannot be granted.
+func validateRoleBody(roleBody models.RoleBody) ([]models.RolePermission, *utils.APIError) {
+	if roleBody.Name == "" {
+		return nil, utils.NewBadRequestError("name is required")
+	}
+
+	if len(roleBody.Permissions) == 0 {
+		return nil, utils.NewBadRequestError("permissions are required")
+	}
+
+	seen := map[string]bool{}
+	var permissions []models.RolePermission
+	for _, permission := range roleBody.Permissions {
+		resource, action, _ := strings.Cut(permission, ":")
+		if !permissionExists(resource, action) {
+			return nil, utils.NewBadRequestError("invalid permission " + permission)
+		}
+
+		if constants.OwnerOnlyPermissions[permission] {
+			return nil, utils.NewBadRequestError("permission " + permission + " is reserved for the owner")
+		}
+
+		if seen[permission] {
+			continue
+		}
+		seen[permission] = true
+
+		permissions = append(permissions, models.RolePermission{Resource: resource, Action: action})
+	}
+
+	return permissions, nil
+}
+
+func permissionExists(resource string, action string) bool {
+	for _, existing := range constants.PermissionActions[resource] {
+		if existing == action {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveAssignableRole returns the role given to an admin assignment: the
+// requested system or own role, or the manager role when none is given.
+func resolveAssignableRole(roleRepo repositories.RoleRepository, roleID *uuid.UUID, ownerID uuid.UUID) (*models.Role, *utils.APIError) {
+	if roleID == nil || *roleID == uuid.Nil {
+		role, err := roleRepo.FindSystemRoleByCode(constants.RoleCodeManager)
+		if err != nil {
+			return nil, utils.NewInternalError("failed to get default role")
+		}
+		return role, nil
+	}
+
+	role, err := roleRepo.FindRoleByID(*roleID)
+	if err != nil || (role.OwnerID != nil && *role.OwnerID != ownerID) {
+		return nil, utils.NewBadRequestError("role not found")
+	}
+
+	return role, nil
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"fmt"
 	"net/http"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/models"
 	"rooming-house-cms-be/repositories"
 	"rooming-house-cms-be/utils"
@@ -45,19 +46,12 @@ func (rc *RoomController) CreateRoom(c echo.Context) error {
 		return utils.HandlerError(c, utils.NewBadRequestError("max capacity is required"))
 	}
 
-	if roomBody.RoomingHouseID == uuid.Nil && userPayload.Role == "owner" {
-		return utils.HandlerError(c, utils.NewBadRequestError("rooming house id is required"))
+	roomingHouseID, apiErr := targetRoomingHouseID(userPayload, roomBody.RoomingHouseID)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
-	var roomingHouseID uuid.UUID
-
-	if userPayload.Role == "owner" {
-		roomingHouseID = roomBody.RoomingHouseID
-	} else {
-		roomingHouseID = userPayload.RoomingHouseID
-	}
-
-	roomingHouse, err := rc.roomingHouseRepo.FindRoomingHouseByID(roomBody.RoomingHouseID, userPayload.UserID, userPayload.Role)
+	roomingHouse, err := rc.roomingHouseRepo.FindRoomingHouseByID(roomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
@@ -117,6 +111,10 @@ func (rc *RoomController) CreateRoom(c echo.Context) error {
 		if !facility.IsRoom {
 			return utils.HandlerError(c, utils.NewBadRequestError("facility is not room facility"))
 		}
+
+		if !facilityAvailableToOwner(facility, roomingHouse.OwnerID) {
+			return utils.HandlerError(c, utils.NewBadRequestError("facility not found"))
+		}
 	}
 
 	newRoom := models.Room{
@@ -129,7 +127,7 @@ func (rc *RoomController) CreateRoom(c echo.Context) error {
 		RoomingHouseID: roomingHouseID,
 	}
 
-	if err := rc.roomRepo.CreateRoom(&newRoom); err != nil {
+	if err := rc.roomRepo.CreateRoom(c.Request().Context(), &newRoom); err != nil {
 		return utils.HandlerError(c, utils.NewInternalError("failed to create room"))
 	}
 
@@ -142,7 +140,7 @@ func (rc *RoomController) CreateRoom(c echo.Context) error {
 		roomFacilities = append(roomFacilities, roomFacility)
 	}
 
-	if err := rc.roomFacilityRepo.CreateRoomFacility(&roomFacilities); err != nil {
+	if err := rc.roomFacilityRepo.CreateRoomFacility(c.Request().Context(), &roomFacilities); err != nil {
 		return utils.HandlerError(c, utils.NewInternalError("failed to create room facility"))
 	}
 
@@ -158,15 +156,7 @@ func (rc *RoomController) GetRoomByID(c echo.Context) error {
 		return utils.HandlerError(c, utils.NewBadRequestError("invalid room id"))
 	}
 
-	var roomingHouseID uuid.UUID
-
-	if userPayload.Role == "admin" {
-		roomingHouseID = userPayload.RoomingHouseID
-	} else {
-		roomingHouseID = uuid.Nil
-	}
-
-	room, err := rc.roomRepo.FindRoomByID(parsedRoomID, roomingHouseID, userPayload.UserID, userPayload.Role)
+	room, err := rc.roomRepo.FindRoomByID(parsedRoomID, userPayload.ScopedRoomingHouseIDs(), userPayload.UserID, userPayload.Role)
 	if err != nil {
 		return utils.HandlerError(c, utils.NewInternalError("failed to get room"))
 	}
@@ -177,33 +167,18 @@ func (rc *RoomController) GetRoomByID(c echo.Context) error {
 func (rc *RoomController) GetAllRooms(c echo.Context) error {
 	userPayload := c.Get("userPayload").(*models.JWTPayload)
 
-	filteredRoomingHouseID := c.QueryParam("rooming_house_id")
+	status := c.QueryParam("status")
 
-	var roomingHouseIDs []uuid.UUID
-
-	if userPayload.Role == "admin" {
-		roomingHouseIDs = append(roomingHouseIDs, userPayload.RoomingHouseID)
-	} else {
-		if filteredRoomingHouseID == "" {
-			roomingHouses, err := rc.roomingHouseRepo.FindAllRoomingHouse(userPayload.RoomingHouseID, userPayload.UserID, userPayload.Role)
-			if err != nil {
-				return utils.HandlerError(c, utils.NewBadRequestError("failed to get rooming house"))
-			}
-
-			for _, roomingHouseID := range roomingHouses {
-				roomingHouseIDs = append(roomingHouseIDs, roomingHouseID.ID)
-			}
-		} else {
-			parsedRoomingHouseID, err := uuid.Parse(filteredRoomingHouseID)
-			if err != nil {
-				return utils.HandlerError(c, utils.NewBadRequestError("invalid rooming house id"))
-			}
+	if status != "" && !constants.RoomStatuses[status] {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid room status"))
+	}
 
-			roomingHouseIDs = append(roomingHouseIDs, parsedRoomingHouseID)
-		}
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(rc.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
-	rooms, err := rc.roomRepo.FindAllRooms(roomingHouseIDs)
+	rooms, err := rc.roomRepo.FindAllRooms(roomingHouseIDs, status)
 	if err != nil {
 		fmt.Println(err)
 		return utils.HandlerError(c, utils.NewInternalError("failed to get rooms"))
@@ -211,6 +186,16 @@ func (rc *RoomController) GetAllRooms(c echo.Context) error {
 
 	fmt.Println(roomingHouseIDs)
 
+	if c.QueryParam("available") == "true" {
+		availableRooms := []models.AllRoomResponse{}
+		for _, room := range *rooms {
+			if room.IsAvailable {
+				availableRooms = append(availableRooms, room)
+			}
+		}
+		return c.JSON(http.StatusOK, availableRooms)
+	}
+
 	return c.JSON(http.StatusOK, rooms)
 }
 
@@ -252,15 +237,7 @@ func (rc *RoomController) UpdateRoomByID(c echo.Context) error {
 		return utils.HandlerError(c, utils.NewBadRequestError("invalid room id"))
 	}
 
-	var roomingHouseID uuid.UUID
-
-	if userPayload.Role == "admin" {
-		roomingHouseID = userPayload.RoomingHouseID
-	} else {
-		roomingHouseID = uuid.Nil
-	}
-
-	roomByID, err := rc.roomRepo.FindRoomByID(parsedRoomID, roomingHouseID, userPayload.UserID, userPayload.Role)
+	roomByID, err := rc.roomRepo.FindRoomByID(parsedRoomID, userPayload.ScopedRoomingHouseIDs(), userPayload.UserID, userPayload.Role)
 	if err != nil {
 		return utils.HandlerError(c, utils.NewInternalError("room not found"))
 	}
@@ -269,7 +246,7 @@ func (rc *RoomController) UpdateRoomByID(c echo.Context) error {
 		return utils.HandlerError(c, utils.NewBadRequestError(fmt.Sprintf("max capacity is less than current tenant count (%d)", len(roomByID.Tenants.TenantAssists)+1)))
 	}
 
-	roomingHouse, err := rc.roomingHouseRepo.FindRoomingHouseByID(roomByID.RoomingHouseID, userPayload.UserID, userPayload.Role)
+	roomingHouse, err := rc.roomingHouseRepo.FindRoomingHouseByID(roomByID.RoomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
@@ -318,6 +295,10 @@ func (rc *RoomController) UpdateRoomByID(c echo.Context) error {
 		if !facility.IsRoom {
 			return utils.HandlerError(c, utils.NewBadRequestError("facility is not room facility"))
 		}
+
+		if !facilityAvailableToOwner(facility, roomingHouse.OwnerID) {
+			return utils.HandlerError(c, utils.NewBadRequestError("facility not found"))
+		}
 	}
 
 	updatedRoom := models.Room{
@@ -328,7 +309,7 @@ func (rc *RoomController) UpdateRoomByID(c echo.Context) error {
 		PackageID:   roomBody.PackageID,
 	}
 
-	if err := rc.roomRepo.UpdateRoomByID(&updatedRoom, parsedRoomID); err != nil {
+	if err := rc.roomRepo.UpdateRoomByID(c.Request().Context(), &updatedRoom, parsedRoomID); err != nil {
 		return utils.HandlerError(c, utils.NewInternalError("failed to update room"))
 	}
 
@@ -341,14 +322,61 @@ func (rc *RoomController) UpdateRoomByID(c echo.Context) error {
 		roomFacilities = append(roomFacilities, roomFacility)
 	}
 
-	if err := rc.roomFacilityRepo.UpdateRoomFacilityByRoomID(&roomFacilities, parsedRoomID); err != nil {
+	if err := rc.roomFacilityRepo.UpdateRoomFacilityByRoomID(c.Request().Context(), &roomFacilities, parsedRoomID); err != nil {
 		return utils.HandlerError(c, utils.NewInternalError("failed to update room facility"))
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "success to update room"})
 }
 
-func (rc *RoomController) DeleteRoomByID(c echo.Context) error {
+func (rc *RoomController) UpdateRoomStatus(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	roomID := c.Param("id")
+	var statusBody models.UpdateRoomStatusBody
+
+	if err := c.Bind(&statusBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if !constants.RoomStatuses[statusBody.Status] {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid room status"))
+	}
+
+	if constants.RoomUnassignableStatuses[statusBody.Status] && statusBody.Reason == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("reason is required"))
+	}
+
+	parsedRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid room id"))
+	}
+
+	room, err := rc.roomRepo.FindRoomByID(parsedRoomID, userPayload.ScopedRoomingHouseIDs(), userPayload.UserID, userPayload.Role)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewNotFoundError("room not found"))
+	}
+
+	if room.Status == statusBody.Status {
+		return utils.HandlerError(c, utils.NewBadRequestError("room is already "+statusBody.Status))
+	}
+
+	roomStatusHistory := models.RoomStatusHistory{
+		RoomID:      parsedRoomID,
+		FromStatus:  room.Status,
+		ToStatus:    statusBody.Status,
+		Reason:      statusBody.Reason,
+		ChangedBy:   userPayload.UserID,
+		ChangerRole: userPayload.Role,
+	}
+
+	if err := rc.roomRepo.UpdateRoomStatus(c.Request().Context(), &roomStatusHistory); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to update room status"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "success to update room status"})
+}
+
+func (rc *RoomController) GetRoomStatusHistories(c echo.Context) error {
 	userPayload := c.Get("userPayload").(*models.JWTPayload)
 	roomID := c.Param("id")
 
@@ -357,19 +385,32 @@ func (rc *RoomController) DeleteRoomByID(c echo.Context) error {
 		return utils.HandlerError(c, utils.NewBadRequestError("invalid room id"))
 	}
 
-	var roomingHouseID uuid.UUID
+	if _, err := rc.roomRepo.FindRoomByID(parsedRoomID, userPayload.ScopedRoomingHouseIDs(), userPayload.UserID, userPayload.Role); err != nil {
+		return utils.HandlerError(c, utils.NewNotFoundError("room not found"))
+	}
+
+	roomStatusHistories, err := rc.roomRepo.FindRoomStatusHistories(parsedRoomID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get room status history"))
+	}
+
+	return c.JSON(http.StatusOK, roomStatusHistories)
+}
+
+func (rc *RoomController) DeleteRoomByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	roomID := c.Param("id")
 
-	if userPayload.Role == "admin" {
-		roomingHouseID = userPayload.RoomingHouseID
-	} else {
-		roomingHouseID = uuid.Nil
+	parsedRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid room id"))
 	}
 
-	if _, err := rc.roomRepo.FindRoomByID(parsedRoomID, roomingHouseID, userPayload.UserID, userPayload.Role); err != nil {
+	if _, err := rc.roomRepo.FindRoomByID(parsedRoomID, userPayload.ScopedRoomingHouseIDs(), userPayload.UserID, userPayload.Role); err != nil {
 		return utils.HandlerError(c, utils.NewInternalError("room not found"))
 	}
 
-	if err := rc.roomRepo.DeleteRoomByID(parsedRoomID); err != nil {
+	if err := rc.roomRepo.DeleteRoomByID(c.Request().Context(), parsedRoomID); err != nil {
 		return utils.HandlerError(c, utils.NewInternalError("failed to delete room"))
 	}
 
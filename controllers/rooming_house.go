@@ -1,10 +1,14 @@
 package controllers
 
 import (
+	"fmt"
+	"math"
 	"net/http"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/models"
 	"rooming-house-cms-be/repositories"
 	"rooming-house-cms-be/utils"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -14,10 +18,12 @@ type RoomingHouseController struct {
 	roomingHouseRepo         repositories.RoomingHouseRepository
 	roomingHouseFacilityRepo repositories.RoomingHouseFacilityRepository
 	facilityRepo             repositories.FacilityRepository
+	roomingHouseOwnerRepo    repositories.RoomingHouseOwnerRepository
+	ownerRepo                repositories.OwnerRepository
 }
 
-func NewRoomingHouseController(roomingHouseRepo repositories.RoomingHouseRepository, roomingHouseFacilityRepo repositories.RoomingHouseFacilityRepository, facilityRepo repositories.FacilityRepository) *RoomingHouseController {
-	return &RoomingHouseController{roomingHouseRepo: roomingHouseRepo, roomingHouseFacilityRepo: roomingHouseFacilityRepo, facilityRepo: facilityRepo}
+func NewRoomingHouseController(roomingHouseRepo repositories.RoomingHouseRepository, roomingHouseFacilityRepo repositories.RoomingHouseFacilityRepository, facilityRepo repositories.FacilityRepository, roomingHouseOwnerRepo repositories.RoomingHouseOwnerRepository, ownerRepo repositories.OwnerRepository) *RoomingHouseController {
+	return &RoomingHouseController{roomingHouseRepo: roomingHouseRepo, roomingHouseFacilityRepo: roomingHouseFacilityRepo, facilityRepo: facilityRepo, roomingHouseOwnerRepo: roomingHouseOwnerRepo, ownerRepo: ownerRepo}
 }
 
 func (rhc *RoomingHouseController) CreateRoomingHouse(c echo.Context) error {
@@ -48,6 +54,12 @@ func (rhc *RoomingHouseController) CreateRoomingHouse(c echo.Context) error {
 		return utils.HandlerError(c, utils.NewBadRequestError("rooming house facility is required"))
 	}
 
+	for _, roomingHouseFacilityID := range roomingHouseBody.RoomingHouseFacilityIDs {
+		if apiErr := rhc.checkRoomingHouseFacility(roomingHouseFacilityID, userPayload.UserID); apiErr != nil {
+			return utils.HandlerError(c, apiErr)
+		}
+	}
+
 	newRoomingHouse := models.RoomingHouse{
 		Name:        roomingHouseBody.Name,
 		Address:     roomingHouseBody.Address,
@@ -56,28 +68,20 @@ func (rhc *RoomingHouseController) CreateRoomingHouse(c echo.Context) error {
 		OwnerID:     userPayload.UserID,
 	}
 
-	if err := rhc.roomingHouseRepo.CreateRoomingHouse(&newRoomingHouse); err != nil {
+	if err := rhc.roomingHouseRepo.CreateRoomingHouse(c.Request().Context(), &newRoomingHouse); err != nil {
 		return utils.HandlerError(c, utils.NewInternalError("failed to create rooming house"))
 	}
 
 	var RoomingHouseFacilities []models.RoomingHouseFacility
 	for _, roomingHouseFacilityID := range roomingHouseBody.RoomingHouseFacilityIDs {
-		facility, err := rhc.facilityRepo.GetFacilityByID(roomingHouseFacilityID)
-		if err != nil {
-			return utils.HandlerError(c, utils.NewNotFoundError("facility not found"))
-		}
-		if facility.IsPublic {
-			roomingHouseFacility := models.RoomingHouseFacility{
-				RoomingHouseID: newRoomingHouse.ID,
-				FacilityID:     roomingHouseFacilityID,
-			}
-			RoomingHouseFacilities = append(RoomingHouseFacilities, roomingHouseFacility)
-		} else {
-			return utils.HandlerError(c, utils.NewBadRequestError("facility is not for room"))
+		roomingHouseFacility := models.RoomingHouseFacility{
+			RoomingHouseID: newRoomingHouse.ID,
+			FacilityID:     roomingHouseFacilityID,
 		}
+		RoomingHouseFacilities = append(RoomingHouseFacilities, roomingHouseFacility)
 	}
 
-	if err := rhc.roomingHouseFacilityRepo.CreateRoomingHouseFacility(&RoomingHouseFacilities); err != nil {
+	if err := rhc.roomingHouseFacilityRepo.CreateRoomingHouseFacility(c.Request().Context(), &RoomingHouseFacilities); err != nil {
 		return utils.HandlerError(c, utils.NewInternalError("failed to create rooming house facility"))
 	}
 
@@ -86,7 +90,7 @@ func (rhc *RoomingHouseController) CreateRoomingHouse(c echo.Context) error {
 
 func (rhc *RoomingHouseController) GetAllRoomingHouse(c echo.Context) error {
 	userPayload := c.Get("userPayload").(*models.JWTPayload)
-	roomingHouses, err := rhc.roomingHouseRepo.FindAllRoomingHouse(userPayload.RoomingHouseID, userPayload.UserID, userPayload.Role)
+	roomingHouses, err := rhc.roomingHouseRepo.FindAllRoomingHouse(userPayload.UserID, userPayload.Role)
 	if err != nil {
 		return utils.HandlerError(c, utils.NewInternalError("failed to get all rooming house"))
 	}
@@ -102,7 +106,7 @@ func (rhc *RoomingHouseController) GetRoomingHouseByID(c echo.Context) error {
 		return utils.HandlerError(c, utils.NewBadRequestError("invalid rooming house ID"))
 	}
 
-	roomingHouse, err := rhc.roomingHouseRepo.FindRoomingHouseByID(roomingHouseID, userPayload.UserID, userPayload.Role)
+	roomingHouse, err := rhc.roomingHouseRepo.FindRoomingHouseByID(roomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
 	if err != nil {
 		return utils.HandlerError(c, utils.NewNotFoundError("rooming house not found"))
 	}
@@ -111,11 +115,18 @@ func (rhc *RoomingHouseController) GetRoomingHouseByID(c echo.Context) error {
 }
 
 func (rhc *RoomingHouseController) UpdateRoomingHouseByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
 	roomingHouseID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("invalid rooming house ID"))
 	}
 
+	roomingHouse, err := rhc.roomingHouseRepo.FindRoomingHouseByID(roomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
+	if err != nil {
+		return utils.HandlerError(c, utils.NewNotFoundError("rooming house not found"))
+	}
+
 	var roomingHouseBody models.RoomingHouseBody
 	if err := c.Bind(&roomingHouseBody); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("invalid request body"))
@@ -141,6 +152,12 @@ func (rhc *RoomingHouseController) UpdateRoomingHouseByID(c echo.Context) error
 		return utils.HandlerError(c, utils.NewBadRequestError("rooming house facility is required"))
 	}
 
+	for _, roomingHouseFacilityID := range roomingHouseBody.RoomingHouseFacilityIDs {
+		if apiErr := rhc.checkRoomingHouseFacility(roomingHouseFacilityID, roomingHouse.OwnerID); apiErr != nil {
+			return utils.HandlerError(c, apiErr)
+		}
+	}
+
 	edittedRoomingHouse := models.RoomingHouse{
 		Name:        roomingHouseBody.Name,
 		Address:     roomingHouseBody.Address,
@@ -148,7 +165,7 @@ func (rhc *RoomingHouseController) UpdateRoomingHouseByID(c echo.Context) error
 		FloorTotal:  roomingHouseBody.FloorTotal,
 	}
 
-	if err := rhc.roomingHouseRepo.UpdateRoomingHouse(&edittedRoomingHouse, roomingHouseID); err != nil {
+	if err := rhc.roomingHouseRepo.UpdateRoomingHouse(c.Request().Context(), &edittedRoomingHouse, roomingHouseID); err != nil {
 		return utils.HandlerError(c, utils.NewInternalError("failed to update rooming house"))
 	}
 
@@ -161,22 +178,145 @@ func (rhc *RoomingHouseController) UpdateRoomingHouseByID(c echo.Context) error
 		RoomingHouseFacilities = append(RoomingHouseFacilities, roomingHouseFacility)
 	}
 
-	if err := rhc.roomingHouseFacilityRepo.UpdateRoomingHouseFacilityByRoomingHouseID(&RoomingHouseFacilities, roomingHouseID); err != nil {
+	if err := rhc.roomingHouseFacilityRepo.UpdateRoomingHouseFacilityByRoomingHouseID(c.Request().Context(), &RoomingHouseFacilities, roomingHouseID); err != nil {
 		return utils.HandlerError(c, utils.NewInternalError("failed to update rooming house facility"))
 	}
 
 	return c.JSON(http.StatusOK, edittedRoomingHouse)
 }
 
+// DeleteRoomingHouseByID deletes the rooming house. Only its primary owner
+// may delete it, not the co-owners.
 func (rhc *RoomingHouseController) DeleteRoomingHouseByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
 	roomingHouseID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("invalid rooming house ID"))
 	}
 
-	if err := rhc.roomingHouseRepo.DeleteRoomingHouse(roomingHouseID); err != nil {
+	roomingHouse, err := rhc.roomingHouseRepo.FindRoomingHouseByID(roomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
+	if err != nil {
+		return utils.HandlerError(c, utils.NewNotFoundError("rooming house not found"))
+	}
+
+	if roomingHouse.OwnerID != userPayload.UserID {
+		return utils.HandlerError(c, utils.NewForbiddenError("only the primary owner can delete this rooming house"))
+	}
+
+	if err := rhc.roomingHouseRepo.DeleteRoomingHouse(c.Request().Context(), roomingHouseID); err != nil {
 		return utils.HandlerError(c, utils.NewInternalError("failed to delete rooming house"))
 	}
 
 	return c.JSON(http.StatusOK, "rooming house deleted")
 }
+
+// GetRoomingHouseOwners lists the owners of the rooming house with their shares.
+func (rhc *RoomingHouseController) GetRoomingHouseOwners(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	if userPayload.Role != "owner" {
+		return utils.HandlerError(c, utils.NewForbiddenError("only owner can access this resource"))
+	}
+
+	roomingHouseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid rooming house ID"))
+	}
+
+	if _, err := rhc.roomingHouseRepo.FindRoomingHouseByID(roomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs()); err != nil {
+		return utils.HandlerError(c, utils.NewNotFoundError("rooming house not found"))
+	}
+
+	owners, err := rhc.roomingHouseOwnerRepo.FindRoomingHouseOwners([]uuid.UUID{roomingHouseID})
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get rooming house owners"))
+	}
+
+	return c.JSON(http.StatusOK, owners)
+}
+
+// UpdateRoomingHouseOwners replaces the co-owners of the rooming house and
+// their shares. Owners are given by the email they registered with. The
+// primary owner must keep a share and the shares must add up to 100.
+func (rhc *RoomingHouseController) UpdateRoomingHouseOwners(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	roomingHouseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid rooming house ID"))
+	}
+
+	var ownersBody models.UpdateRoomingHouseOwnersBody
+	if err := c.Bind(&ownersBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid request body"))
+	}
+
+	roomingHouse, err := rhc.roomingHouseRepo.FindRoomingHouseByID(roomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
+	if err != nil {
+		return utils.HandlerError(c, utils.NewNotFoundError("rooming house not found"))
+	}
+
+	if roomingHouse.OwnerID != userPayload.UserID {
+		return utils.HandlerError(c, utils.NewForbiddenError("only the primary owner can change the owners of this rooming house"))
+	}
+
+	if len(ownersBody.Owners) == 0 {
+		return utils.HandlerError(c, utils.NewBadRequestError("owners are required"))
+	}
+
+	var owners []models.RoomingHouseOwner
+	var total float64
+	seen := map[uuid.UUID]bool{}
+	for _, share := range ownersBody.Owners {
+		owner, err := rhc.ownerRepo.FindOwnerByEmail(strings.TrimSpace(share.Email))
+		if err != nil {
+			return utils.HandlerError(c, utils.NewBadRequestError("no owner is registered with email "+share.Email))
+		}
+
+		if seen[owner.ID] {
+			return utils.HandlerError(c, utils.NewBadRequestError("duplicate owner "+share.Email))
+		}
+		seen[owner.ID] = true
+
+		if share.Percentage <= 0 || share.Percentage > constants.OwnershipTotalPercentage {
+			return utils.HandlerError(c, utils.NewBadRequestError("percentage of "+share.Email+" must be greater than 0 and at most 100"))
+		}
+		total += share.Percentage
+
+		owners = append(owners, models.RoomingHouseOwner{
+			RoomingHouseID: roomingHouseID,
+			OwnerID:        owner.ID,
+			Percentage:     share.Percentage,
+		})
+	}
+
+	if !seen[roomingHouse.OwnerID] {
+		return utils.HandlerError(c, utils.NewBadRequestError("the primary owner must keep a share"))
+	}
+
+	if math.Abs(total-constants.OwnershipTotalPercentage) > constants.OwnershipPercentageTolerance {
+		return utils.HandlerError(c, utils.NewBadRequestError(fmt.Sprintf("percentages must add up to 100, got %.2f", total)))
+	}
+
+	if err := rhc.roomingHouseOwnerRepo.ReplaceRoomingHouseOwners(c.Request().Context(), roomingHouseID, owners); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to update rooming house owners"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "rooming house owners updated"})
+}
+
+// checkRoomingHouseFacility makes sure the facility exists, is available to
+// the owner and is a public facility.
+func (rhc *RoomingHouseController) checkRoomingHouseFacility(facilityID uuid.UUID, ownerID uuid.UUID) *utils.APIError {
+	facility, err := rhc.facilityRepo.GetFacilityByID(facilityID)
+	if err != nil || !facilityAvailableToOwner(facility, ownerID) {
+		return utils.NewNotFoundError("facility not found")
+	}
+
+	if !facility.IsPublic {
+		return utils.NewBadRequestError("facility is not for room")
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"errors"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/utils"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// resolveOwnerID returns the owner whose catalogue (facilities, periods,
+// transaction categories and blacklist) the user works on: the primary owner
+// of the target rooming house, falling back to the selected house. Without
+// either, the user's houses must all belong to the same primary owner, except
+// that owners who also co-own someone else's house default to their own.
+func resolveOwnerID(roomingHouseRepo repositories.RoomingHouseRepository, userPayload *models.JWTPayload, roomingHouseID uuid.UUID) (uuid.UUID, error) {
+	if roomingHouseID == uuid.Nil {
+		roomingHouseID = userPayload.RoomingHouseID
+	}
+
+	if roomingHouseID != uuid.Nil {
+		roomingHouse, err := roomingHouseRepo.FindRoomingHouseByID(roomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return roomingHouse.OwnerID, nil
+	}
+
+	ownerIDs := map[uuid.UUID]bool{}
+	if isOwner(userPayload) {
+		roomingHouses, err := roomingHouseRepo.FindAllRoomingHouse(userPayload.UserID, userPayload.Role)
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		for _, roomingHouse := range roomingHouses {
+			ownerIDs[roomingHouse.OwnerID] = true
+		}
+
+		// Owners keep their own catalogue, even before their first rooming house
+		if len(ownerIDs) == 0 || ownerIDs[userPayload.UserID] {
+			return userPayload.UserID, nil
+		}
+	} else {
+		for _, id := range userPayload.ScopedRoomingHouseIDs() {
+			roomingHouse, err := roomingHouseRepo.FindRoomingHouseByID(id, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
+			if err != nil {
+				return uuid.Nil, err
+			}
+			ownerIDs[roomingHouse.OwnerID] = true
+		}
+
+		if len(ownerIDs) == 0 {
+			return uuid.Nil, errors.New("no rooming house assigned")
+		}
+	}
+
+	if len(ownerIDs) > 1 {
+		return uuid.Nil, errors.New("rooming houses belong to different owners, select a rooming house")
+	}
+
+	var ownerID uuid.UUID
+	for id := range ownerIDs {
+		ownerID = id
+	}
+
+	return ownerID, nil
+}
+
+// requestOwnerID resolves the owner of the rooming house named by the
+// rooming_house_id query param, as resolveOwnerID does without one.
+func requestOwnerID(roomingHouseRepo repositories.RoomingHouseRepository, c echo.Context, userPayload *models.JWTPayload) (uuid.UUID, error) {
+	roomingHouseID := uuid.Nil
+	if roomingHouseParam := c.QueryParam("rooming_house_id"); roomingHouseParam != "" {
+		parsedRoomingHouseID, err := uuid.Parse(roomingHouseParam)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		roomingHouseID = parsedRoomingHouseID
+	}
+
+	return resolveOwnerID(roomingHouseRepo, userPayload, roomingHouseID)
+}
+
+// isOwner reports whether the request comes from an owner, who works across
+// all of their rooming houses and on owner-wide records rather than within an
+// assigned scope.
+func isOwner(userPayload *models.JWTPayload) bool {
+	return userPayload.Role == "owner"
+}
+
+// targetRoomingHouseID returns the rooming house a new record goes into: the
+// one named in the body for owners, the selected house for admins.
+func targetRoomingHouseID(userPayload *models.JWTPayload, requestedRoomingHouseID uuid.UUID) (uuid.UUID, *utils.APIError) {
+	roomingHouseID := userPayload.RoomingHouseID
+	if isOwner(userPayload) {
+		roomingHouseID = requestedRoomingHouseID
+	}
+
+	if roomingHouseID == uuid.Nil {
+		return uuid.Nil, utils.NewBadRequestError("rooming house id is required")
+	}
+
+	return roomingHouseID, nil
+}
+
+// scopedRoomingHouseIDs returns every rooming house the user may read: the
+// admin's selected or assigned houses, or all of the owner's houses.
+func scopedRoomingHouseIDs(roomingHouseRepo repositories.RoomingHouseRepository, userPayload *models.JWTPayload) ([]uuid.UUID, *utils.APIError) {
+	if !isOwner(userPayload) {
+		return userPayload.ScopedRoomingHouseIDs(), nil
+	}
+
+	roomingHouses, err := roomingHouseRepo.FindAllRoomingHouse(userPayload.UserID, userPayload.Role)
+	if err != nil {
+		return nil, utils.NewBadRequestError("failed to get rooming house")
+	}
+
+	var roomingHouseIDs []uuid.UUID
+	for _, roomingHouse := range roomingHouses {
+		roomingHouseIDs = append(roomingHouseIDs, roomingHouse.ID)
+	}
+
+	return roomingHouseIDs, nil
+}
+
+// accessibleRoomingHouseIDs returns the rooming houses the user may read,
+// narrowed by the rooming_house_id query param.
+func accessibleRoomingHouseIDs(roomingHouseRepo repositories.RoomingHouseRepository, c echo.Context, userPayload *models.JWTPayload) ([]uuid.UUID, *utils.APIError) {
+	roomingHouseIDs, apiErr := scopedRoomingHouseIDs(roomingHouseRepo, userPayload)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	return filterRoomingHouseIDs(roomingHouseIDs, c.QueryParam("rooming_house_id")), nil
+}
+
+// filterRoomingHouseIDs narrows the rooming houses down to the filtered one,
+// leaving them as they are without a filter.
+func filterRoomingHouseIDs(roomingHouseIDs []uuid.UUID, filteredRoomingHouseID string) []uuid.UUID {
+	if filteredRoomingHouseID == "" {
+		return roomingHouseIDs
+	}
+
+	var filteredRoomingHouseIDs []uuid.UUID
+	for _, roomingHouseID := range roomingHouseIDs {
+		if roomingHouseID.String() == filteredRoomingHouseID {
+			filteredRoomingHouseIDs = append(filteredRoomingHouseIDs, roomingHouseID)
+		}
+	}
+
+	return filteredRoomingHouseIDs
+}
+
+// canAccessRoomingHouse reports whether the rooming house is within the
+// request's reach: admins only within the request scope, owners on their own
+// houses. Whether the action itself is allowed is left to RequirePermission.
+func canAccessRoomingHouse(roomingHouseRepo repositories.RoomingHouseRepository, userPayload *models.JWTPayload, roomingHouseID uuid.UUID) bool {
+	if !isOwner(userPayload) {
+		return userPayload.HasRoomingHouse(roomingHouseID)
+	}
+
+	_, err := roomingHouseRepo.FindRoomingHouseByID(roomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
+	return err == nil
+}
+
+// screenTenant looks up blacklist entries and active tenants across all of the
+// owner's rooming houses sharing the given phone number or ID number.
+func screenTenant(tenantBlacklistRepo repositories.TenantBlacklistRepository, tenantRepo repositories.TenantRepository, roomingHouseRepo repositories.RoomingHouseRepository, ownerID uuid.UUID, phoneNumber string, idNumber string) ([]models.TenantScreeningMatch, error) {
+	matches, err := tenantBlacklistRepo.FindTenantBlacklistMatches(ownerID, phoneNumber, idNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	roomingHouses, err := roomingHouseRepo.FindAllRoomingHouse(ownerID, "owner")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(roomingHouses) > 0 {
+		var roomingHouseIDs []uuid.UUID
+		for _, roomingHouse := range roomingHouses {
+			roomingHouseIDs = append(roomingHouseIDs, roomingHouse.ID)
+		}
+
+		tenantMatches, err := tenantRepo.FindActiveTenantMatches(roomingHouseIDs, phoneNumber, idNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		matches = append(matches, tenantMatches...)
+	}
+
+	for i := range matches {
+		matches[i].MatchedOn = []string{}
+		if phoneNumber != "" && matches[i].PhoneNumber == phoneNumber {
+			matches[i].MatchedOn = append(matches[i].MatchedOn, "phone_number")
+		}
+		if idNumber != "" && matches[i].IDNumber == idNumber {
+			matches[i].MatchedOn = append(matches[i].MatchedOn, "id_number")
+		}
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,466 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// fakeRoomingHouse is a rooming house with its primary owner and co-owners.
+type fakeRoomingHouse struct {
+	ownerID    uuid.UUID
+	coOwnerIDs []uuid.UUID
+}
+
+func (rh fakeRoomingHouse) ownedBy(userID uuid.UUID) bool {
+	if rh.ownerID == userID {
+		return true
+	}
+	for _, coOwnerID := range rh.coOwnerIDs {
+		if coOwnerID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+type fakeRoomingHouseRepository struct {
+	repositories.RoomingHouseRepository
+	roomingHouses map[uuid.UUID]fakeRoomingHouse
+}
+
+func (r *fakeRoomingHouseRepository) FindRoomingHouseByID(roomingHouseID uuid.UUID, userID uuid.UUID, role string, scopedRoomingHouseIDs []uuid.UUID) (*models.RoomingHouseByIDResponse, error) {
+	roomingHouse, ok := r.roomingHouses[roomingHouseID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	inScope := role == "owner" && roomingHouse.ownedBy(userID)
+	for _, scopedRoomingHouseID := range scopedRoomingHouseIDs {
+		if role == "admin" && scopedRoomingHouseID == roomingHouseID {
+			inScope = true
+		}
+	}
+	if !inScope {
+		return nil, errors.New("rooming house not found")
+	}
+
+	return &models.RoomingHouseByIDResponse{ID: roomingHouseID, OwnerID: roomingHouse.ownerID}, nil
+}
+
+func (r *fakeRoomingHouseRepository) FindAllRoomingHouse(userID uuid.UUID, role string) ([]models.AllRoomingHouseResponse, error) {
+	var roomingHouses []models.AllRoomingHouseResponse
+	for id, roomingHouse := range r.roomingHouses {
+		if roomingHouse.ownedBy(userID) {
+			roomingHouses = append(roomingHouses, models.AllRoomingHouseResponse{ID: id, OwnerID: roomingHouse.ownerID})
+		}
+	}
+	return roomingHouses, nil
+}
+
+// coOwnership is a primary owner with a rooming house shared with a co-owner
+// who has no rooming house of their own, and another owner who co-owns it too
+// next to a house of their own.
+type coOwnership struct {
+	primaryOwnerID uuid.UUID
+	coOwnerID      uuid.UUID
+	otherOwnerID   uuid.UUID
+	sharedHouseID  uuid.UUID
+	otherHouseID   uuid.UUID
+	roomingHouses  *fakeRoomingHouseRepository
+}
+
+func newCoOwnership() coOwnership {
+	co := coOwnership{
+		primaryOwnerID: uuid.New(),
+		coOwnerID:      uuid.New(),
+		otherOwnerID:   uuid.New(),
+		sharedHouseID:  uuid.New(),
+		otherHouseID:   uuid.New(),
+	}
+	co.roomingHouses = &fakeRoomingHouseRepository{roomingHouses: map[uuid.UUID]fakeRoomingHouse{
+		co.sharedHouseID: {ownerID: co.primaryOwnerID, coOwnerIDs: []uuid.UUID{co.coOwnerID, co.otherOwnerID}},
+		co.otherHouseID:  {ownerID: co.otherOwnerID},
+	}}
+	return co
+}
+
+func TestResolveOwnerID(t *testing.T) {
+	co := newCoOwnership()
+	adminOfBoth := &models.JWTPayload{UserID: uuid.New(), Role: "admin", RoomingHouseIDs: []uuid.UUID{co.sharedHouseID, co.otherHouseID}}
+	newOwner := &models.JWTPayload{UserID: uuid.New(), Role: "owner"}
+
+	tests := []struct {
+		name           string
+		userPayload    *models.JWTPayload
+		roomingHouseID uuid.UUID
+		want           uuid.UUID
+		wantErr        bool
+	}{
+		{name: "co-owner works on the primary owner's catalogue", userPayload: &models.JWTPayload{UserID: co.coOwnerID, Role: "owner"}, want: co.primaryOwnerID},
+		{name: "co-owner of the target house", userPayload: &models.JWTPayload{UserID: co.otherOwnerID, Role: "owner"}, roomingHouseID: co.sharedHouseID, want: co.primaryOwnerID},
+		{name: "owner with a house of their own defaults to it", userPayload: &models.JWTPayload{UserID: co.otherOwnerID, Role: "owner"}, want: co.otherOwnerID},
+		{name: "primary owner", userPayload: &models.JWTPayload{UserID: co.primaryOwnerID, Role: "owner"}, roomingHouseID: co.sharedHouseID, want: co.primaryOwnerID},
+		{name: "owner without rooming houses", userPayload: newOwner, want: newOwner.UserID},
+		{name: "house the owner has no share in", userPayload: &models.JWTPayload{UserID: co.coOwnerID, Role: "owner"}, roomingHouseID: co.otherHouseID, wantErr: true},
+		{name: "admin of houses of different owners must select one", userPayload: adminOfBoth, wantErr: true},
+		{name: "admin with a target house", userPayload: adminOfBoth, roomingHouseID: co.otherHouseID, want: co.otherOwnerID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveOwnerID(co.roomingHouses, tt.userPayload, tt.roomingHouseID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveOwnerID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("resolveOwnerID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTargetRoomingHouseID(t *testing.T) {
+	selectedHouseID, requestedHouseID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name        string
+		userPayload *models.JWTPayload
+		requested   uuid.UUID
+		want        uuid.UUID
+		wantErr     bool
+	}{
+		{name: "owner picks the house", userPayload: &models.JWTPayload{Role: "owner"}, requested: requestedHouseID, want: requestedHouseID},
+		{name: "owner must pick a house", userPayload: &models.JWTPayload{Role: "owner"}, wantErr: true},
+		{name: "admin works on the selected house", userPayload: &models.JWTPayload{Role: "admin", RoomingHouseID: selectedHouseID}, requested: requestedHouseID, want: selectedHouseID},
+		{name: "admin without a selected house", userPayload: &models.JWTPayload{Role: "admin", RoomingHouseIDs: []uuid.UUID{selectedHouseID}}, requested: requestedHouseID, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, apiErr := targetRoomingHouseID(tt.userPayload, tt.requested)
+			if (apiErr != nil) != tt.wantErr {
+				t.Fatalf("targetRoomingHouseID() error = %v, wantErr %v", apiErr, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("targetRoomingHouseID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccessibleRoomingHouseIDs(t *testing.T) {
+	co := newCoOwnership()
+	outsideHouseID := uuid.New()
+
+	tests := []struct {
+		name        string
+		userPayload *models.JWTPayload
+		filter      string
+		want        []uuid.UUID
+	}{
+		{name: "owner reads their houses", userPayload: &models.JWTPayload{UserID: co.otherOwnerID, Role: "owner"}, want: []uuid.UUID{co.sharedHouseID, co.otherHouseID}},
+		{name: "owner narrows to one house", userPayload: &models.JWTPayload{UserID: co.otherOwnerID, Role: "owner"}, filter: co.otherHouseID.String(), want: []uuid.UUID{co.otherHouseID}},
+		{name: "owner cannot filter on a house they have no share in", userPayload: &models.JWTPayload{UserID: co.coOwnerID, Role: "owner"}, filter: co.otherHouseID.String()},
+		{name: "admin reads the assigned houses", userPayload: &models.JWTPayload{Role: "admin", RoomingHouseIDs: []uuid.UUID{co.sharedHouseID}}, want: []uuid.UUID{co.sharedHouseID}},
+		{name: "admin cannot filter outside the scope", userPayload: &models.JWTPayload{Role: "admin", RoomingHouseIDs: []uuid.UUID{co.sharedHouseID}}, filter: outsideHouseID.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := "/"
+			if tt.filter != "" {
+				target += "?rooming_house_id=" + tt.filter
+			}
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, target, nil), httptest.NewRecorder())
+
+			got, apiErr := accessibleRoomingHouseIDs(co.roomingHouses, c, tt.userPayload)
+			if apiErr != nil {
+				t.Fatalf("accessibleRoomingHouseIDs() error = %v", apiErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("accessibleRoomingHouseIDs() = %v, want %v", got, tt.want)
+			}
+			for _, id := range tt.want {
+				if !slices.Contains(got, id) {
+					t.Errorf("accessibleRoomingHouseIDs() = %v, missing %v", got, id)
+				}
+			}
+		})
+	}
+}
+
+// fakeCatalogueRepository records the owner the catalogues are read and
+// written for.
+type fakeCatalogueRepository struct {
+	repositories.FacilityRepository
+	repositories.PeriodRepository
+	repositories.TransactionCategoryRepository
+	listedFor  uuid.UUID
+	facilities []models.Facility
+	periods    []models.Period
+}
+
+func (r *fakeCatalogueRepository) GetAllFacilities(ownerID uuid.UUID) (*[]models.Facility, error) {
+	r.listedFor = ownerID
+	return &r.facilities, nil
+}
+
+func (r *fakeCatalogueRepository) GetFacilityByID(id uuid.UUID) (*models.Facility, error) {
+	for i := range r.facilities {
+		if r.facilities[i].ID == id {
+			return &r.facilities[i], nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeCatalogueRepository) FindFacilityByName(string, uuid.UUID, uuid.UUID) (*models.Facility, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeCatalogueRepository) CountFacilityReferences(uuid.UUID) (int64, error) {
+	return 0, nil
+}
+
+func (r *fakeCatalogueRepository) CreateFacility(_ context.Context, facility *models.Facility) error {
+	r.facilities = append(r.facilities, *facility)
+	return nil
+}
+
+func (r *fakeCatalogueRepository) DeleteFacilityByID(context.Context, uuid.UUID) error {
+	return nil
+}
+
+func (r *fakeCatalogueRepository) FindAllPeriods(ownerID uuid.UUID) (*[]models.Period, error) {
+	r.listedFor = ownerID
+	return &r.periods, nil
+}
+
+func (r *fakeCatalogueRepository) FindPeriodByID(id uuid.UUID) (*models.Period, error) {
+	for i := range r.periods {
+		if r.periods[i].ID == id {
+			return &r.periods[i], nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeCatalogueRepository) CreatePeriod(_ context.Context, period *models.Period) error {
+	r.periods = append(r.periods, *period)
+	return nil
+}
+
+func (r *fakeCatalogueRepository) FindAllTransactionCategories(ownerID uuid.UUID, _ []uuid.UUID) (*[]models.TransactionCategory, error) {
+	r.listedFor = ownerID
+	return &[]models.TransactionCategory{}, nil
+}
+
+func (r *fakeCatalogueRepository) CreateTransactionCategory(context.Context, *models.TransactionCategory) error {
+	return nil
+}
+
+// fakeTenantBlacklistRepository keeps blacklist entries in memory.
+type fakeTenantBlacklistRepository struct {
+	repositories.TenantBlacklistRepository
+	entries   []models.TenantBlacklist
+	overrides []models.TenantScreeningOverride
+}
+
+func (r *fakeTenantBlacklistRepository) FindTenantBlacklistMatches(ownerID uuid.UUID, phoneNumber string, idNumber string) ([]models.TenantScreeningMatch, error) {
+	var matches []models.TenantScreeningMatch
+	for _, entry := range r.entries {
+		if entry.OwnerID == ownerID && ((phoneNumber != "" && entry.PhoneNumber == phoneNumber) || (idNumber != "" && entry.IDNumber == idNumber)) {
+			matches = append(matches, models.TenantScreeningMatch{Source: "blacklist", ID: entry.ID, PhoneNumber: entry.PhoneNumber, IDNumber: entry.IDNumber})
+		}
+	}
+	return matches, nil
+}
+
+func (r *fakeTenantBlacklistRepository) CreateScreeningOverride(_ context.Context, override *models.TenantScreeningOverride) error {
+	r.overrides = append(r.overrides, *override)
+	return nil
+}
+
+func (r *fakeTenantBlacklistRepository) CreateTenantBlacklist(_ context.Context, tenantBlacklist *models.TenantBlacklist) error {
+	tenantBlacklist.ID = uuid.New()
+	r.entries = append(r.entries, *tenantBlacklist)
+	return nil
+}
+
+func (r *fakeTenantBlacklistRepository) FindAllTenantBlacklists(ownerID uuid.UUID) (*[]models.TenantBlacklistResponse, error) {
+	tenantBlacklists := []models.TenantBlacklistResponse{}
+	for _, entry := range r.entries {
+		if entry.OwnerID == ownerID {
+			tenantBlacklists = append(tenantBlacklists, models.TenantBlacklistResponse{ID: entry.ID})
+		}
+	}
+	return &tenantBlacklists, nil
+}
+
+func (r *fakeTenantBlacklistRepository) FindTenantBlacklistByID(id uuid.UUID, ownerID uuid.UUID) (*models.TenantBlacklist, error) {
+	for i := range r.entries {
+		if r.entries[i].ID == id && r.entries[i].OwnerID == ownerID {
+			return &r.entries[i], nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeTenantBlacklistRepository) DeleteTenantBlacklistByID(_ context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+	for i := range r.entries {
+		if r.entries[i].ID == id && r.entries[i].OwnerID == ownerID {
+			r.entries = append(r.entries[:i], r.entries[i+1:]...)
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+// serveAs runs the handler for a request made by the user and returns the response.
+func serveAs(userPayload *models.JWTPayload, handler echo.HandlerFunc, method string, target string, body string, paramValues ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	c := echo.New().NewContext(req, rec)
+	if len(paramValues) > 0 {
+		c.SetParamNames("id")
+		c.SetParamValues(paramValues...)
+	}
+	c.Set("userPayload", userPayload)
+
+	handler(c)
+	return rec
+}
+
+func TestCoOwnerFacilities(t *testing.T) {
+	co := newCoOwnership()
+	coOwner := &models.JWTPayload{UserID: co.coOwnerID, Role: "owner"}
+	primaryFacility := models.Facility{Name: "Laundry", IsPublic: true, OwnerID: &co.primaryOwnerID}
+	primaryFacility.ID = uuid.New()
+
+	catalogue := &fakeCatalogueRepository{facilities: []models.Facility{primaryFacility}}
+	fc := NewFacilityController(catalogue, co.roomingHouses)
+
+	if rec := serveAs(coOwner, fc.GetAllFacilities, http.MethodGet, "/facilities", ""); rec.Code != http.StatusOK || catalogue.listedFor != co.primaryOwnerID {
+		t.Errorf("list: status %d for owner %v, want 200 for the primary owner", rec.Code, catalogue.listedFor)
+	}
+
+	if rec := serveAs(coOwner, fc.CreateFacility, http.MethodPost, "/facilities", `{"name":"Parking","is_public":true}`); rec.Code != http.StatusCreated {
+		t.Fatalf("create: status %d, want 201", rec.Code)
+	}
+	created := catalogue.facilities[len(catalogue.facilities)-1]
+	if created.OwnerID == nil || *created.OwnerID != co.primaryOwnerID {
+		t.Errorf("created facility is owned by %v, want the primary owner", created.OwnerID)
+	}
+	if !facilityAvailableToOwner(&created, co.primaryOwnerID) {
+		t.Errorf("created facility is not available on the shared rooming house")
+	}
+
+	if rec := serveAs(coOwner, fc.DeleteFacilityByID, http.MethodDelete, "/facilities/"+primaryFacility.ID.String(), "", primaryFacility.ID.String()); rec.Code != http.StatusOK {
+		t.Errorf("delete: status %d, want 200", rec.Code)
+	}
+
+	otherOwner := &models.JWTPayload{UserID: co.otherOwnerID, Role: "owner"}
+	if rec := serveAs(otherOwner, fc.DeleteFacilityByID, http.MethodDelete, "/facilities/"+primaryFacility.ID.String(), "", primaryFacility.ID.String()); rec.Code != http.StatusNotFound {
+		t.Errorf("delete without selecting the shared house: status %d, want 404", rec.Code)
+	}
+	if rec := serveAs(otherOwner, fc.DeleteFacilityByID, http.MethodDelete, "/facilities/"+primaryFacility.ID.String()+"?rooming_house_id="+co.sharedHouseID.String(), "", primaryFacility.ID.String()); rec.Code != http.StatusOK {
+		t.Errorf("delete for the shared house: status %d, want 200", rec.Code)
+	}
+}
+
+func TestCoOwnerPeriods(t *testing.T) {
+	co := newCoOwnership()
+	coOwner := &models.JWTPayload{UserID: co.coOwnerID, Role: "owner"}
+	primaryPeriod := models.Period{Name: "Weekly", OwnerID: &co.primaryOwnerID}
+	primaryPeriod.ID = uuid.New()
+
+	catalogue := &fakeCatalogueRepository{periods: []models.Period{primaryPeriod}}
+	pc := NewPeriodController(catalogue, co.roomingHouses)
+
+	if rec := serveAs(coOwner, pc.GetAllPeriods, http.MethodGet, "/periods", ""); rec.Code != http.StatusOK || catalogue.listedFor != co.primaryOwnerID {
+		t.Errorf("list: status %d for owner %v, want 200 for the primary owner", rec.Code, catalogue.listedFor)
+	}
+
+	if rec := serveAs(coOwner, pc.CreatePeriod, http.MethodPost, "/periods", `{"name":"Fortnightly","unit":"week","count":2}`); rec.Code != http.StatusCreated {
+		t.Fatalf("create: status %d, want 201", rec.Code)
+	}
+	created := catalogue.periods[len(catalogue.periods)-1]
+	if created.OwnerID == nil || *created.OwnerID != co.primaryOwnerID {
+		t.Errorf("created period is owned by %v, want the primary owner", created.OwnerID)
+	}
+
+	ownerID, err := resolveOwnerID(co.roomingHouses, coOwner, co.sharedHouseID)
+	if err != nil {
+		t.Fatalf("resolveOwnerID: %v", err)
+	}
+	if apiErr := validatePeriodPrices(catalogue, map[uuid.UUID]float64{primaryPeriod.ID: 500000}, ownerID); apiErr != nil {
+		t.Errorf("primary owner's period is rejected on the shared house: %v", apiErr.Message)
+	}
+}
+
+func TestCoOwnerTransactionCategories(t *testing.T) {
+	co := newCoOwnership()
+	coOwner := &models.JWTPayload{UserID: co.coOwnerID, Role: "owner"}
+
+	catalogue := &fakeCatalogueRepository{}
+	tcc := NewTransactionCategoryController(catalogue, co.roomingHouses)
+
+	if rec := serveAs(coOwner, tcc.FindAllTransactionCategories, http.MethodGet, "/transaction-categories", ""); rec.Code != http.StatusOK || catalogue.listedFor != co.primaryOwnerID {
+		t.Errorf("list: status %d for owner %v, want 200 for the primary owner", rec.Code, catalogue.listedFor)
+	}
+
+	rec := serveAs(coOwner, tcc.CreateTransactionCategory, http.MethodPost, "/transaction-categories", `{"name":"Cleaning","is_expense":true,"rooming_house_id":"`+co.sharedHouseID.String()+`"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: status %d, want 201", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), co.primaryOwnerID.String()) {
+		t.Errorf("created category is not owned by the primary owner: %s", rec.Body.String())
+	}
+
+	category := &models.TransactionCategory{OwnerID: &co.primaryOwnerID, RoomingHouseID: &co.sharedHouseID}
+	if !transactionCategoryAvailableToRoomingHouse(category, &models.RoomingHouseByIDResponse{ID: co.sharedHouseID, OwnerID: co.primaryOwnerID}) {
+		t.Errorf("created category is not available on the shared rooming house")
+	}
+}
+
+func TestCoOwnerTenantBlacklist(t *testing.T) {
+	co := newCoOwnership()
+	coOwner := &models.JWTPayload{UserID: co.coOwnerID, Role: "owner"}
+
+	blacklist := &fakeTenantBlacklistRepository{}
+	tbc := NewTenantBlacklistController(blacklist, nil, co.roomingHouses)
+
+	rec := serveAs(coOwner, tbc.CreateTenantBlacklist, http.MethodPost, "/blacklists", `{"rooming_house_id":"`+co.sharedHouseID.String()+`","name":"Andi","phoneNumber":"08123456789","reason":"unpaid rent"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: status %d, want 201: %s", rec.Code, rec.Body.String())
+	}
+	entryID := blacklist.entries[0].ID.String()
+
+	if rec := serveAs(coOwner, tbc.FindAllTenantBlacklists, http.MethodGet, "/blacklists", ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), entryID) {
+		t.Errorf("list: status %d without the new entry: %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := serveAs(coOwner, tbc.FindTenantBlacklistByID, http.MethodGet, "/blacklists/"+entryID, "", entryID); rec.Code != http.StatusOK {
+		t.Errorf("get: status %d, want 200", rec.Code)
+	}
+
+	if rec := serveAs(coOwner, tbc.DeleteTenantBlacklistByID, http.MethodDelete, "/blacklists/"+entryID, "", entryID); rec.Code != http.StatusOK {
+		t.Errorf("delete: status %d, want 200", rec.Code)
+	}
+	if len(blacklist.entries) != 0 {
+		t.Errorf("got %d entries after delete, want 0", len(blacklist.entries))
+	}
+}
@@ -0,0 +1,449 @@
+package controllers
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type SettlementController struct {
+	settlementRepo        repositories.SettlementRepository
+	transactionRepo       repositories.TransactionRepository
+	roomingHouseRepo      repositories.RoomingHouseRepository
+	roomingHouseOwnerRepo repositories.RoomingHouseOwnerRepository
+}
+
+func NewSettlementController(settlementRepo repositories.SettlementRepository, transactionRepo repositories.TransactionRepository, roomingHouseRepo repositories.RoomingHouseRepository, roomingHouseOwnerRepo repositories.RoomingHouseOwnerRepository) *SettlementController {
+	return &SettlementController{settlementRepo: settlementRepo, transactionRepo: transactionRepo, roomingHouseRepo: roomingHouseRepo, roomingHouseOwnerRepo: roomingHouseOwnerRepo}
+}
+
+// GetManagementFee returns the management fee of the rooming house. A house
+// without one has no fee and pays its whole net income to the owners.
+func (sc *SettlementController) GetManagementFee(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	roomingHouseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid rooming house id"))
+	}
+
+	if !canAccessRoomingHouse(sc.roomingHouseRepo, userPayload, roomingHouseID) {
+		return utils.HandlerError(c, utils.NewNotFoundError("rooming house not found"))
+	}
+
+	fee, err := sc.settlementRepo.FindManagementFee(roomingHouseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.JSON(http.StatusOK, models.ManagementFee{RoomingHouseID: roomingHouseID})
+		}
+		return utils.HandlerError(c, utils.NewInternalError("failed to get management fee"))
+	}
+
+	return c.JSON(http.StatusOK, fee)
+}
+
+func (sc *SettlementController) UpdateManagementFee(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	roomingHouseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid rooming house id"))
+	}
+
+	var feeBody models.ManagementFeeBody
+	if err := c.Bind(&feeBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid request body"))
+	}
+
+	feeBody.FeeType = strings.ToLower(strings.TrimSpace(feeBody.FeeType))
+	if !constants.ManagementFeeTypes[feeBody.FeeType] {
+		return utils.HandlerError(c, utils.NewBadRequestError("fee type must be percentage or fixed"))
+	}
+
+	if feeBody.Value < 0 {
+		return utils.HandlerError(c, utils.NewBadRequestError("value cannot be negative"))
+	}
+
+	if feeBody.FeeType == constants.ManagementFeePercentage && feeBody.Value > constants.OwnershipTotalPercentage {
+		return utils.HandlerError(c, utils.NewBadRequestError("percentage cannot be more than 100"))
+	}
+
+	if !canAccessRoomingHouse(sc.roomingHouseRepo, userPayload, roomingHouseID) {
+		return utils.HandlerError(c, utils.NewNotFoundError("rooming house not found"))
+	}
+
+	fee := models.ManagementFee{
+		RoomingHouseID: roomingHouseID,
+		FeeType:        feeBody.FeeType,
+		Value:          feeBody.Value,
+	}
+
+	if err := sc.settlementRepo.SaveManagementFee(c.Request().Context(), &fee); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to update management fee"))
+	}
+
+	return c.JSON(http.StatusOK, fee)
+}
+
+// CreateSettlement closes the books of a rooming house for a month. It totals
+// the month's transactions per category, takes the management fee from the
+// net income and splits the rest into payouts by the owners' shares.
+func (sc *SettlementController) CreateSettlement(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	var settlementBody models.SettlementBody
+	if err := c.Bind(&settlementBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid request body"))
+	}
+
+	if settlementBody.RoomingHouseID == uuid.Nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house id is required"))
+	}
+
+	if settlementBody.Month < 1 || settlementBody.Month > 12 {
+		return utils.HandlerError(c, utils.NewBadRequestError("month must be between 1 and 12"))
+	}
+
+	if settlementBody.Year == 0 {
+		return utils.HandlerError(c, utils.NewBadRequestError("year is required"))
+	}
+
+	now := time.Now()
+	if settlementBody.Year > now.Year() || (settlementBody.Year == now.Year() && settlementBody.Month > int(now.Month())) {
+		return utils.HandlerError(c, utils.NewBadRequestError("cannot settle a future month"))
+	}
+
+	if !canAccessRoomingHouse(sc.roomingHouseRepo, userPayload, settlementBody.RoomingHouseID) {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	settled, err := sc.settlementRepo.CountSettlementsByPeriod(settlementBody.RoomingHouseID, settlementBody.Year, settlementBody.Month)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get settlements"))
+	}
+
+	if settled > 0 {
+		return utils.HandlerError(c, utils.NewConflictError("month is already settled"))
+	}
+
+	totals, err := sc.transactionRepo.SumTransactionsByCategory(settlementBody.RoomingHouseID, settlementBody.Year, settlementBody.Month)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get transactions"))
+	}
+
+	settlement := models.Settlement{
+		RoomingHouseID: settlementBody.RoomingHouseID,
+		Year:           settlementBody.Year,
+		Month:          settlementBody.Month,
+		CreatedByID:    userPayload.UserID,
+		CreatedByRole:  userPayload.Role,
+	}
+
+	fee, err := sc.settlementRepo.FindManagementFee(settlementBody.RoomingHouseID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get management fee"))
+	}
+
+	settleTotals(&settlement, *totals, fee)
+
+	if settlement.OwnerPayout > 0 {
+		owners, err := sc.roomingHouseOwnerRepo.FindRoomingHouseOwners([]uuid.UUID{settlementBody.RoomingHouseID})
+		if err != nil {
+			return utils.HandlerError(c, utils.NewInternalError("failed to get rooming house owners"))
+		}
+
+		settlement.Payouts = splitOwnerPayout(settlement.OwnerPayout, *owners)
+	}
+
+	if err := sc.settlementRepo.CreateSettlement(c.Request().Context(), &settlement); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to create settlement"))
+	}
+
+	statement, apiErr := sc.settlementStatement(settlement.ID, []uuid.UUID{settlement.RoomingHouseID}, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	return c.JSON(http.StatusOK, statement)
+}
+
+func (sc *SettlementController) FindAllSettlements(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	year := 0
+	if yearParam := c.QueryParam("year"); yearParam != "" {
+		parsedYear, err := strconv.Atoi(yearParam)
+		if err != nil {
+			return utils.HandlerError(c, utils.NewBadRequestError("invalid year"))
+		}
+		year = parsedYear
+	}
+
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(sc.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if len(roomingHouseIDs) == 0 {
+		return c.JSON(http.StatusOK, []models.Settlement{})
+	}
+
+	settlements, err := sc.settlementRepo.FindAllSettlements(roomingHouseIDs, year)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get settlements"))
+	}
+
+	return c.JSON(http.StatusOK, settlements)
+}
+
+// FindSettlementStatement returns the statement of a settlement: the category
+// totals, the fee taken and what has been paid out to each owner.
+func (sc *SettlementController) FindSettlementStatement(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	settlementID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid settlement id"))
+	}
+
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(sc.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	statement, apiErr := sc.settlementStatement(settlementID, roomingHouseIDs, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	return c.JSON(http.StatusOK, statement)
+}
+
+func (sc *SettlementController) RecordPayout(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	settlementID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid settlement id"))
+	}
+
+	payoutID, err := uuid.Parse(c.Param("payoutID"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid payout id"))
+	}
+
+	var payoutBody models.RecordPayoutBody
+	if err := c.Bind(&payoutBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid request body"))
+	}
+
+	paidAt := time.Now()
+	if payoutBody.PaidAt != "" {
+		parsedPaidAt, err := time.ParseInLocation(constants.DateLayout, payoutBody.PaidAt, time.Local)
+		if err != nil {
+			return utils.HandlerError(c, utils.NewBadRequestError("paid at must be in YYYY-MM-DD format"))
+		}
+		paidAt = parsedPaidAt
+	}
+
+	settlement, apiErr := sc.findWritableSettlement(settlementID, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if err := sc.settlementRepo.RecordPayout(c.Request().Context(), settlement.ID, payoutID, paidAt, strings.TrimSpace(payoutBody.Reference), userPayload.UserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.HandlerError(c, utils.NewNotFoundError("unpaid payout not found"))
+		}
+		return utils.HandlerError(c, utils.NewInternalError("failed to record payout"))
+	}
+
+	statement, apiErr := sc.settlementStatement(settlement.ID, []uuid.UUID{settlement.RoomingHouseID}, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	return c.JSON(http.StatusOK, statement)
+}
+
+// DeleteSettlement reopens a settled month, as long as none of its payouts
+// has been paid.
+func (sc *SettlementController) DeleteSettlement(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	settlementID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid settlement id"))
+	}
+
+	settlement, apiErr := sc.findWritableSettlement(settlementID, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	paid, err := sc.settlementRepo.CountPaidPayouts(settlement.ID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get payouts"))
+	}
+
+	if paid > 0 {
+		return utils.HandlerError(c, utils.NewConflictError("settlement has paid payouts"))
+	}
+
+	if err := sc.settlementRepo.DeleteSettlement(c.Request().Context(), settlement.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to delete settlement"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "settlement deleted"})
+}
+
+func (sc *SettlementController) findWritableSettlement(settlementID uuid.UUID, userPayload *models.JWTPayload) (*models.Settlement, *utils.APIError) {
+	roomingHouseIDs, apiErr := scopedRoomingHouseIDs(sc.roomingHouseRepo, userPayload)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	if len(roomingHouseIDs) == 0 {
+		return nil, utils.NewNotFoundError("settlement not found")
+	}
+
+	settlement, err := sc.settlementRepo.FindSettlementByID(settlementID, roomingHouseIDs)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("settlement not found")
+		}
+		return nil, utils.NewInternalError("failed to get settlement")
+	}
+
+	return settlement, nil
+}
+
+func (sc *SettlementController) settlementStatement(settlementID uuid.UUID, roomingHouseIDs []uuid.UUID, userPayload *models.JWTPayload) (*models.SettlementStatementResponse, *utils.APIError) {
+	if len(roomingHouseIDs) == 0 {
+		return nil, utils.NewNotFoundError("settlement not found")
+	}
+
+	settlement, err := sc.settlementRepo.FindSettlementByID(settlementID, roomingHouseIDs)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("settlement not found")
+		}
+		return nil, utils.NewInternalError("failed to get settlement")
+	}
+
+	payouts, err := sc.settlementRepo.FindSettlementPayouts(settlement.ID)
+	if err != nil {
+		return nil, utils.NewInternalError("failed to get payouts")
+	}
+
+	roomingHouse, err := sc.roomingHouseRepo.FindRoomingHouseByID(settlement.RoomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
+	if err != nil {
+		return nil, utils.NewNotFoundError("settlement not found")
+	}
+
+	statement := models.SettlementStatementResponse{
+		ID:               settlement.ID,
+		RoomingHouseID:   settlement.RoomingHouseID,
+		RoomingHouseName: roomingHouse.Name,
+		Year:             settlement.Year,
+		Month:            settlement.Month,
+		Income:           settlement.Income,
+		Expense:          settlement.Expense,
+		NetIncome:        settlement.NetIncome,
+		FeeType:          settlement.FeeType,
+		FeeValue:         settlement.FeeValue,
+		ManagementFee:    settlement.ManagementFee,
+		OwnerPayout:      settlement.OwnerPayout,
+		SettledAt:        settlement.CreatedAt,
+		Lines:            settlement.Lines,
+		Payouts:          *payouts,
+	}
+
+	if statement.Lines == nil {
+		statement.Lines = []models.SettlementLine{}
+	}
+
+	for _, payout := range *payouts {
+		if payout.PaidAt != nil {
+			statement.PaidTotal += payout.Amount
+		}
+	}
+	statement.PaidTotal = roundAmount(statement.PaidTotal)
+	statement.Outstanding = roundAmount(math.Max(settlement.OwnerPayout, 0) - statement.PaidTotal)
+
+	return &statement, nil
+}
+
+// settleTotals fills the settlement's lines and totals from the month's
+// category totals and charges the management fee, if any.
+func settleTotals(settlement *models.Settlement, totals []models.TransactionCategoryTotal, fee *models.ManagementFee) {
+	for _, total := range totals {
+		if total.IsExpense {
+			settlement.Expense += total.Amount
+		} else {
+			settlement.Income += total.Amount
+		}
+
+		settlement.Lines = append(settlement.Lines, models.SettlementLine{
+			TransactionCategoryID: total.TransactionCategoryID,
+			CategoryName:          total.CategoryName,
+			IsExpense:             total.IsExpense,
+			Amount:                total.Amount,
+		})
+	}
+	settlement.NetIncome = settlement.Income - settlement.Expense
+
+	if fee != nil {
+		settlement.FeeType = fee.FeeType
+		settlement.FeeValue = fee.Value
+
+		// A percentage fee is taken from the income, not the net, so the
+		// manager is not paid less for the expenses they run the house with.
+		if fee.FeeType == constants.ManagementFeePercentage {
+			settlement.ManagementFee = roundAmount(settlement.Income * fee.Value / 100)
+		} else {
+			settlement.ManagementFee = fee.Value
+		}
+	}
+	settlement.OwnerPayout = roundAmount(settlement.NetIncome - settlement.ManagementFee)
+}
+
+// splitOwnerPayout splits the owner payout by ownership share. Each share is
+// rounded and the remaining cents go to the last owner so the payouts add up
+// to the owner payout.
+func splitOwnerPayout(ownerPayout float64, owners []models.RoomingHouseOwnerResponse) []models.SettlementPayout {
+	var payouts []models.SettlementPayout
+
+	remaining := ownerPayout
+	for i, owner := range owners {
+		amount := roundAmount(ownerPayout * owner.Percentage / constants.OwnershipTotalPercentage)
+		if i == len(owners)-1 {
+			amount = roundAmount(remaining)
+		}
+		remaining -= amount
+
+		payouts = append(payouts, models.SettlementPayout{
+			OwnerID:    owner.OwnerID,
+			Percentage: owner.Percentage,
+			Amount:     amount,
+		})
+	}
+
+	return payouts
+}
+
+// roundAmount rounds a currency amount to cents.
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSettleTotals(t *testing.T) {
+	rent := models.TransactionCategoryTotal{TransactionCategoryID: uuid.New(), CategoryName: "Rent", Amount: 3000000}
+	electricity := models.TransactionCategoryTotal{TransactionCategoryID: uuid.New(), CategoryName: "Electricity", IsExpense: true, Amount: 500000}
+
+	tests := []struct {
+		name          string
+		totals        []models.TransactionCategoryTotal
+		fee           *models.ManagementFee
+		wantIncome    float64
+		wantExpense   float64
+		wantFee       float64
+		wantPayout    float64
+		wantLineCount int
+	}{
+		{
+			// SumTransactionsByCategory leaves deposits out, so a month with
+			// only a deposit has no totals to settle.
+			name:   "deposit only month",
+			totals: nil,
+		},
+		{
+			name:          "no management fee",
+			totals:        []models.TransactionCategoryTotal{rent, electricity},
+			wantIncome:    3000000,
+			wantExpense:   500000,
+			wantPayout:    2500000,
+			wantLineCount: 2,
+		},
+		{
+			name:          "percentage fee is charged on income",
+			totals:        []models.TransactionCategoryTotal{rent, electricity},
+			fee:           &models.ManagementFee{FeeType: constants.ManagementFeePercentage, Value: 10},
+			wantIncome:    3000000,
+			wantExpense:   500000,
+			wantFee:       300000,
+			wantPayout:    2200000,
+			wantLineCount: 2,
+		},
+		{
+			name:          "fixed fee can exceed the net",
+			totals:        []models.TransactionCategoryTotal{electricity},
+			fee:           &models.ManagementFee{FeeType: constants.ManagementFeeFixed, Value: 250000},
+			wantExpense:   500000,
+			wantFee:       250000,
+			wantPayout:    -750000,
+			wantLineCount: 1,
+		},
+		{
+			name:          "percentage fee is rounded to cents",
+			totals:        []models.TransactionCategoryTotal{{TransactionCategoryID: uuid.New(), CategoryName: "Rent", Amount: 100.05}},
+			fee:           &models.ManagementFee{FeeType: constants.ManagementFeePercentage, Value: 7.5},
+			wantIncome:    100.05,
+			wantFee:       7.5,
+			wantPayout:    92.55,
+			wantLineCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var settlement models.Settlement
+			settleTotals(&settlement, tt.totals, tt.fee)
+
+			if settlement.Income != tt.wantIncome || settlement.Expense != tt.wantExpense {
+				t.Errorf("income, expense = %v, %v; want %v, %v", settlement.Income, settlement.Expense, tt.wantIncome, tt.wantExpense)
+			}
+			if settlement.ManagementFee != tt.wantFee {
+				t.Errorf("management fee = %v, want %v", settlement.ManagementFee, tt.wantFee)
+			}
+			if settlement.OwnerPayout != tt.wantPayout {
+				t.Errorf("owner payout = %v, want %v", settlement.OwnerPayout, tt.wantPayout)
+			}
+			if len(settlement.Lines) != tt.wantLineCount {
+				t.Errorf("got %d lines, want %d", len(settlement.Lines), tt.wantLineCount)
+			}
+		})
+	}
+}
+
+func TestSplitOwnerPayout(t *testing.T) {
+	tests := []struct {
+		name        string
+		ownerPayout float64
+		percentages []float64
+		want        []float64
+	}{
+		{
+			name:        "single owner takes everything",
+			ownerPayout: 1000.01,
+			percentages: []float64{100},
+			want:        []float64{1000.01},
+		},
+		{
+			name:        "even split",
+			ownerPayout: 1000,
+			percentages: []float64{50, 50},
+			want:        []float64{500, 500},
+		},
+		{
+			name:        "last owner takes the rounding remainder",
+			ownerPayout: 100,
+			percentages: []float64{33.33, 33.33, 33.34},
+			want:        []float64{33.33, 33.33, 33.34},
+		},
+		{
+			name:        "thirds of an odd cent amount",
+			ownerPayout: 100.01,
+			percentages: []float64{33.3333, 33.3333, 33.3334},
+			want:        []float64{33.34, 33.34, 33.33},
+		},
+		{
+			name:        "shares rounding up are taken back from the last owner",
+			ownerPayout: 0.05,
+			percentages: []float64{50, 50},
+			want:        []float64{0.03, 0.02},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var owners []models.RoomingHouseOwnerResponse
+			for _, percentage := range tt.percentages {
+				owners = append(owners, models.RoomingHouseOwnerResponse{OwnerID: uuid.New(), Percentage: percentage})
+			}
+
+			payouts := splitOwnerPayout(tt.ownerPayout, owners)
+			if len(payouts) != len(tt.want) {
+				t.Fatalf("got %d payouts, want %d", len(payouts), len(tt.want))
+			}
+
+			total := 0.0
+			for i, payout := range payouts {
+				if payout.Amount != tt.want[i] {
+					t.Errorf("payout %d = %v, want %v", i, payout.Amount, tt.want[i])
+				}
+				if payout.OwnerID != owners[i].OwnerID {
+					t.Errorf("payout %d went to the wrong owner", i)
+				}
+				total += payout.Amount
+			}
+
+			if roundAmount(total) != tt.ownerPayout {
+				t.Errorf("payouts add up to %v, want %v", roundAmount(total), tt.ownerPayout)
+			}
+		})
+	}
+}
@@ -39,16 +39,9 @@ func (sc *SizeController) CreateSize(c echo.Context) error {
 		return utils.HandlerError(c, utils.NewBadRequestError("width is required"))
 	}
 
-	if userPayload.Role == "owner" && sizeBody.RoomingHouseID == uuid.Nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("rooming house id is required"))
-	}
-
-	var roomingHouseID uuid.UUID
-
-	if userPayload.Role == "owner" {
-		roomingHouseID = sizeBody.RoomingHouseID
-	} else {
-		roomingHouseID = userPayload.RoomingHouseID
+	roomingHouseID, apiErr := targetRoomingHouseID(userPayload, sizeBody.RoomingHouseID)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
 	newSize := models.Size{
@@ -58,7 +51,7 @@ func (sc *SizeController) CreateSize(c echo.Context) error {
 		RoomingHouseID: roomingHouseID,
 	}
 
-	if err := sc.sizeRepo.CreateSize(&newSize); err != nil {
+	if err := sc.sizeRepo.CreateSize(c.Request().Context(), &newSize); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("failed to create size"))
 	}
 
@@ -85,30 +78,13 @@ func (sc *SizeController) FindAllSizes(c echo.Context) error {
 	userPayload := c.Get("userPayload").(*models.JWTPayload)
 	filteredRoomingHouseID := c.QueryParam("roomingHouseID")
 
-	var roomingHouseIDs []uuid.UUID
-
-	if userPayload.Role == "admin" {
-		roomingHouseIDs = append(roomingHouseIDs, userPayload.RoomingHouseID)
-	} else {
-		if filteredRoomingHouseID == "" {
-			IDs, err := sc.roomingHouseRepo.FindAllRoomingHouse(userPayload.RoomingHouseID, userPayload.UserID, userPayload.Role)
-			if err != nil {
-				return utils.HandlerError(c, utils.NewInternalError("failed to get rooming house"))
-			}
-
-			for _, roomingHouseID := range IDs {
-				roomingHouseIDs = append(roomingHouseIDs, roomingHouseID.ID)
-			}
-		} else {
-			parseRoomingHouseID, err := uuid.Parse(filteredRoomingHouseID)
-			if err != nil {
-				return utils.HandlerError(c, utils.NewBadRequestError("invalid rooming house ID"))
-			}
-
-			roomingHouseIDs = append(roomingHouseIDs, parseRoomingHouseID)
-		}
+	roomingHouseIDs, apiErr := scopedRoomingHouseIDs(sc.roomingHouseRepo, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
+	roomingHouseIDs = filterRoomingHouseIDs(roomingHouseIDs, filteredRoomingHouseID)
+
 	sizes, err := sc.sizeRepo.FindAllSizes(roomingHouseIDs)
 	if err != nil {
 		return utils.HandlerError(c, utils.NewInternalError("failed to get sizes"))
@@ -118,8 +94,8 @@ func (sc *SizeController) FindAllSizes(c echo.Context) error {
 }
 
 func (sc *SizeController) UpdateSizeByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
 	var sizeBody models.UpdateSizeBody
-	sizeID := c.Param("id")
 
 	if err := c.Bind(&sizeBody); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
@@ -137,9 +113,9 @@ func (sc *SizeController) UpdateSizeByID(c echo.Context) error {
 		return utils.HandlerError(c, utils.NewBadRequestError("width is required"))
 	}
 
-	parsedSizeID, err := uuid.Parse(sizeID)
-	if err != nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("invalid size ID"))
+	size, apiErr := sc.findWritableSize(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
 	newSize := models.Size{
@@ -148,7 +124,7 @@ func (sc *SizeController) UpdateSizeByID(c echo.Context) error {
 		Width: sizeBody.Width,
 	}
 
-	if err := sc.sizeRepo.UpdateSizeByID(&newSize, parsedSizeID); err != nil {
+	if err := sc.sizeRepo.UpdateSizeByID(c.Request().Context(), &newSize, size.ID); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("failed to update size"))
 	}
 
@@ -156,16 +132,32 @@ func (sc *SizeController) UpdateSizeByID(c echo.Context) error {
 }
 
 func (sc *SizeController) DeleteSizeByID(c echo.Context) error {
-	sizeID := c.Param("id")
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
 
-	parsedSizeID, err := uuid.Parse(sizeID)
-	if err != nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("invalid size ID"))
+	size, apiErr := sc.findWritableSize(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
-	if err := sc.sizeRepo.DeleteSizeByID(parsedSizeID); err != nil {
+	if err := sc.sizeRepo.DeleteSizeByID(c.Request().Context(), size.ID); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("failed to delete size"))
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "size deleted"})
 }
+
+// findWritableSize loads the size from the id param if it belongs to a
+// rooming house the request may change.
+func (sc *SizeController) findWritableSize(c echo.Context, userPayload *models.JWTPayload) (*models.Size, *utils.APIError) {
+	sizeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, utils.NewBadRequestError("invalid size ID")
+	}
+
+	size, err := sc.sizeRepo.FindSizeByID(sizeID)
+	if err != nil || !canAccessRoomingHouse(sc.roomingHouseRepo, userPayload, size.RoomingHouseID) {
+		return nil, utils.NewNotFoundError("size not found")
+	}
+
+	return size, nil
+}
@@ -0,0 +1,259 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type StaffController struct {
+	staffRepo        repositories.StaffRepository
+	payrollRepo      repositories.PayrollRepository
+	adminRepo        repositories.AdminRepository
+	roomingHouseRepo repositories.RoomingHouseRepository
+}
+
+func NewStaffController(staffRepo repositories.StaffRepository, payrollRepo repositories.PayrollRepository, adminRepo repositories.AdminRepository, roomingHouseRepo repositories.RoomingHouseRepository) *StaffController {
+	return &StaffController{staffRepo: staffRepo, payrollRepo: payrollRepo, adminRepo: adminRepo, roomingHouseRepo: roomingHouseRepo}
+}
+
+func (sc *StaffController) CreateStaff(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	var staffBody models.AddStaffBody
+	if err := c.Bind(&staffBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid request body"))
+	}
+
+	if staffBody.RoomingHouseID == uuid.Nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house id is required"))
+	}
+
+	if !canAccessRoomingHouse(sc.roomingHouseRepo, userPayload, staffBody.RoomingHouseID) {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	staff := models.Staff{RoomingHouseID: staffBody.RoomingHouseID}
+	if apiErr := sc.applyStaffDetails(&staff, staffBody.AdminID, staffBody.Name, staffBody.Position, staffBody.PhoneNumber, staffBody.BaseSalary, staffBody.JoinedAt, staffBody.Components); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if err := sc.staffRepo.CreateStaff(c.Request().Context(), &staff); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to create staff"))
+	}
+
+	return c.JSON(http.StatusOK, staff)
+}
+
+// FindAllStaff lists the staff of the accessible rooming houses. Inactive
+// staff are left out unless include_inactive is true.
+func (sc *StaffController) FindAllStaff(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	includeInactive := false
+	if includeParam := c.QueryParam("include_inactive"); includeParam != "" {
+		parsed, err := strconv.ParseBool(includeParam)
+		if err != nil {
+			return utils.HandlerError(c, utils.NewBadRequestError("include_inactive must be true or false"))
+		}
+		includeInactive = parsed
+	}
+
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(sc.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if len(roomingHouseIDs) == 0 {
+		return c.JSON(http.StatusOK, []models.Staff{})
+	}
+
+	staff, err := sc.staffRepo.FindAllStaff(roomingHouseIDs, includeInactive)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get staff"))
+	}
+
+	return c.JSON(http.StatusOK, staff)
+}
+
+func (sc *StaffController) FindStaffByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	staff, apiErr := sc.findAccessibleStaff(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	return c.JSON(http.StatusOK, staff)
+}
+
+func (sc *StaffController) UpdateStaffByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	staff, apiErr := sc.findAccessibleStaff(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	var staffBody models.UpdateStaffBody
+	if err := c.Bind(&staffBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid request body"))
+	}
+
+	if apiErr := sc.applyStaffDetails(staff, staffBody.AdminID, staffBody.Name, staffBody.Position, staffBody.PhoneNumber, staffBody.BaseSalary, staffBody.JoinedAt, staffBody.Components); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if staffBody.Active != nil {
+		if !*staffBody.Active && staff.InactiveAt == nil {
+			now := time.Now()
+			staff.InactiveAt = &now
+		} else if *staffBody.Active {
+			staff.InactiveAt = nil
+		}
+	}
+
+	if err := sc.staffRepo.UpdateStaff(c.Request().Context(), staff); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to update staff"))
+	}
+
+	return c.JSON(http.StatusOK, staff)
+}
+
+// DeleteStaffByID removes staff added by mistake. Staff who have been paid
+// keep their record for the payslips and are made inactive instead.
+func (sc *StaffController) DeleteStaffByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	staff, apiErr := sc.findAccessibleStaff(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	payslips, err := sc.payrollRepo.CountPayslipsByStaffID(staff.ID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get payslips"))
+	}
+
+	if payslips > 0 {
+		return utils.HandlerError(c, utils.NewConflictError("staff has payslips, make them inactive instead"))
+	}
+
+	if err := sc.staffRepo.DeleteStaffByID(c.Request().Context(), staff.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to delete staff"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "staff deleted"})
+}
+
+// FindStaffPayslips returns every payslip of the staff member, newest first.
+func (sc *StaffController) FindStaffPayslips(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	staff, apiErr := sc.findAccessibleStaff(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	payslips, err := sc.payrollRepo.FindPayslipsByStaffID(staff.ID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get payslips"))
+	}
+
+	return c.JSON(http.StatusOK, payslips)
+}
+
+func (sc *StaffController) findAccessibleStaff(c echo.Context, userPayload *models.JWTPayload) (*models.Staff, *utils.APIError) {
+	staffID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, utils.NewBadRequestError("invalid staff id")
+	}
+
+	staff, err := sc.staffRepo.FindStaffByID(staffID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("staff not found")
+		}
+		return nil, utils.NewInternalError("failed to get staff")
+	}
+
+	if !canAccessRoomingHouse(sc.roomingHouseRepo, userPayload, staff.RoomingHouseID) {
+		return nil, utils.NewNotFoundError("staff not found")
+	}
+
+	return staff, nil
+}
+
+// applyStaffDetails validates the staff details shared by create and update
+// and sets them on the staff member.
+func (sc *StaffController) applyStaffDetails(staff *models.Staff, adminID *uuid.UUID, name string, position string, phoneNumber string, baseSalary float64, joinedAt string, components []models.StaffPayComponentBody) *utils.APIError {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return utils.NewBadRequestError("name is required")
+	}
+
+	position = strings.TrimSpace(position)
+	if position == "" {
+		return utils.NewBadRequestError("position is required")
+	}
+
+	if baseSalary < 0 {
+		return utils.NewBadRequestError("base salary cannot be negative")
+	}
+
+	staff.JoinedAt = nil
+	if joinedAt != "" {
+		parsedJoinedAt, err := time.ParseInLocation(constants.DateLayout, joinedAt, time.Local)
+		if err != nil {
+			return utils.NewBadRequestError("joined at must be in YYYY-MM-DD format")
+		}
+		staff.JoinedAt = &parsedJoinedAt
+	}
+
+	if adminID != nil {
+		if _, err := sc.adminRepo.FindAdminRoomingHouse(*adminID, staff.RoomingHouseID); err != nil {
+			return utils.NewBadRequestError("admin is not assigned to the rooming house")
+		}
+	}
+
+	staff.Components = []models.StaffPayComponent{}
+	for _, component := range components {
+		component.Name = strings.TrimSpace(component.Name)
+		if component.Name == "" {
+			return utils.NewBadRequestError("component name is required")
+		}
+
+		component.Kind = strings.ToLower(strings.TrimSpace(component.Kind))
+		if !constants.PayComponentKinds[component.Kind] {
+			return utils.NewBadRequestError("component kind must be allowance or deduction")
+		}
+
+		if component.Amount <= 0 {
+			return utils.NewBadRequestError("component amount must be greater than 0")
+		}
+
+		staff.Components = append(staff.Components, models.StaffPayComponent{
+			Name:   component.Name,
+			Kind:   component.Kind,
+			Amount: component.Amount,
+		})
+	}
+
+	staff.AdminID = adminID
+	staff.Name = name
+	staff.Position = position
+	staff.PhoneNumber = strings.TrimSpace(phoneNumber)
+	staff.BaseSalary = baseSalary
+
+	return nil
+}
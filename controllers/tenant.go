@@ -1,13 +1,18 @@
 package controllers
 
 import (
+	"encoding/json"
 	"net/http"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/models"
 	"rooming-house-cms-be/repositories"
 	"rooming-house-cms-be/utils"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 type TenantController struct {
@@ -15,10 +20,14 @@ type TenantController struct {
 	tenantAdditionalPriceRepo repositories.TenantAdditionalRepository
 	roomingHouseRepo          repositories.RoomingHouseRepository
 	roomRepo                  repositories.RoomRepository
+	tenantVehicleRepo         repositories.TenantVehicleRepository
+	tenantBlacklistRepo       repositories.TenantBlacklistRepository
+	tenantAccountRepo         repositories.TenantAccountRepository
+	sessionRepo               repositories.SessionRepository
 }
 
-func NewTenantController(tenantRepo repositories.TenantRepository, tenantAdditionalRepo repositories.TenantAdditionalRepository, roomingHouseRepo repositories.RoomingHouseRepository, roomRepo repositories.RoomRepository) *TenantController {
-	return &TenantController{tenantRepo: tenantRepo, tenantAdditionalPriceRepo: tenantAdditionalRepo, roomingHouseRepo: roomingHouseRepo, roomRepo: roomRepo}
+func NewTenantController(tenantRepo repositories.TenantRepository, tenantAdditionalRepo repositories.TenantAdditionalRepository, roomingHouseRepo repositories.RoomingHouseRepository, roomRepo repositories.RoomRepository, tenantVehicleRepo repositories.TenantVehicleRepository, tenantBlacklistRepo repositories.TenantBlacklistRepository, tenantAccountRepo repositories.TenantAccountRepository, sessionRepo repositories.SessionRepository) *TenantController {
+	return &TenantController{tenantRepo: tenantRepo, tenantAdditionalPriceRepo: tenantAdditionalRepo, roomingHouseRepo: roomingHouseRepo, roomRepo: roomRepo, tenantVehicleRepo: tenantVehicleRepo, tenantBlacklistRepo: tenantBlacklistRepo, tenantAccountRepo: tenantAccountRepo, sessionRepo: sessionRepo}
 }
 
 func (tc *TenantController) CreateTenant(c echo.Context) error {
@@ -41,12 +50,16 @@ func (tc *TenantController) CreateTenant(c echo.Context) error {
 		return utils.HandlerError(c, utils.NewBadRequestError("phone number is required"))
 	}
 
-	var roomingHouseID uuid.UUID
+	tenantBody.IDNumber = strings.TrimSpace(tenantBody.IDNumber)
 
-	if userPayload.Role == "owner" {
-		roomingHouseID = tenantBody.RoomingHouseID
-	} else {
-		roomingHouseID = userPayload.RoomingHouseID
+	dateOfBirth, vehicles, apiErr := validateTenantProfile(tenantBody.IDNumber, tenantBody.DateOfBirth, tenantBody.Vehicles)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	roomingHouseID, apiErr := targetRoomingHouseID(userPayload, tenantBody.RoomingHouseID)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
 	if tenantBody.IsTenant {
@@ -69,7 +82,7 @@ func (tc *TenantController) CreateTenant(c echo.Context) error {
 		var room *models.RoomDetailResponse
 		var err error
 
-		room, err = tc.roomRepo.FindRoomByID(*tenantBody.RoomID, roomingHouseID, userPayload.UserID, userPayload.Role)
+		room, err = tc.roomRepo.FindRoomByID(*tenantBody.RoomID, []uuid.UUID{roomingHouseID}, userPayload.UserID, userPayload.Role)
 		if err != nil {
 			if err.Error() == "record not found" {
 				return utils.HandlerError(c, utils.NewBadRequestError("room not found"))
@@ -80,6 +93,10 @@ func (tc *TenantController) CreateTenant(c echo.Context) error {
 			return utils.HandlerError(c, utils.NewBadRequestError("room not found"))
 		}
 
+		if constants.RoomUnassignableStatuses[room.Status] {
+			return utils.HandlerError(c, utils.NewBadRequestError("room is not available for assignment ("+room.Status+")"))
+		}
+
 		tenantBody.TenantID = uuid.Nil
 	} else {
 		if tenantBody.TenantID == uuid.Nil {
@@ -91,13 +108,17 @@ func (tc *TenantController) CreateTenant(c echo.Context) error {
 			return utils.HandlerError(c, utils.NewBadRequestError("tenant not found"))
 		}
 
-		room, err := tc.roomRepo.FindRoomByID(tenant.BookedRoomID, roomingHouseID, userPayload.UserID, userPayload.Role)
+		room, err := tc.roomRepo.FindRoomByID(tenant.BookedRoomID, []uuid.UUID{roomingHouseID}, userPayload.UserID, userPayload.Role)
 		if err != nil {
 			if err.Error() == "record not found" {
 				return utils.HandlerError(c, utils.NewBadRequestError("room not found"))
 			}
 		}
 
+		if constants.RoomUnassignableStatuses[room.Status] {
+			return utils.HandlerError(c, utils.NewBadRequestError("room is not available for assignment ("+room.Status+")"))
+		}
+
 		if room.MaxCapacity-1 < len(tenant.TenantAssists) {
 			return utils.HandlerError(c, utils.NewBadRequestError("room is full"))
 		}
@@ -106,16 +127,28 @@ func (tc *TenantController) CreateTenant(c echo.Context) error {
 		tenantBody.PeriodID = nil
 	}
 
-	_, err := tc.roomingHouseRepo.FindRoomingHouseByID(roomingHouseID, userPayload.UserID, userPayload.Role)
+	roomingHouse, err := tc.roomingHouseRepo.FindRoomingHouseByID(roomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
 	if err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
 	}
 
+	tenantBody.PhoneNumber = strings.TrimSpace(tenantBody.PhoneNumber)
+
+	matches, apiErr := tc.screenTenantProfile(userPayload, roomingHouse, uuid.Nil, tenantBody.PhoneNumber, tenantBody.IDNumber, tenantBody.OverrideJustification)
+	if apiErr != nil {
+		return tenantScreeningError(c, apiErr, matches)
+	}
+
 	newTenant := models.Tenant{
 		Name:                   tenantBody.Name,
 		Gender:                 tenantBody.Gender,
 		PhoneNumber:            tenantBody.PhoneNumber,
 		EmergencyContact:       tenantBody.EmergencyContact,
+		IDNumber:               tenantBody.IDNumber,
+		DateOfBirth:            dateOfBirth,
+		Occupation:             tenantBody.Occupation,
+		Institution:            tenantBody.Institution,
+		OriginAddress:          tenantBody.OriginAddress,
 		IsTenant:               tenantBody.IsTenant,
 		RegularPaymentDuration: tenantBody.RegularPaymentDuration,
 		RoomingHouseID:         roomingHouseID,
@@ -124,10 +157,14 @@ func (tc *TenantController) CreateTenant(c echo.Context) error {
 		TenantID:               (uuid.UUID)(tenantBody.TenantID),
 	}
 
-	if err := tc.tenantRepo.CreateTenant(&newTenant); err != nil {
+	if err := tc.tenantRepo.CreateTenant(c.Request().Context(), &newTenant); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("failed to create tenant"))
 	}
 
+	if apiErr := tc.recordScreeningOverride(c, userPayload, roomingHouse, newTenant.ID, matches, tenantBody.OverrideJustification); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
 	if len(tenantBody.TenantAdditionalIDs) > 0 {
 		var tenantAdditionalPrices []models.TenantAdditionalPrice
 		for _, tenantAdditionalID := range tenantBody.TenantAdditionalIDs {
@@ -139,11 +176,21 @@ func (tc *TenantController) CreateTenant(c echo.Context) error {
 			tenantAdditionalPrices = append(tenantAdditionalPrices, tenantAdditionalPrice)
 		}
 
-		if err := tc.tenantAdditionalPriceRepo.CreateTenantAdditional(&tenantAdditionalPrices); err != nil {
+		if err := tc.tenantAdditionalPriceRepo.CreateTenantAdditional(c.Request().Context(), &tenantAdditionalPrices); err != nil {
 			return utils.HandlerError(c, utils.NewBadRequestError("failed to create tenant additional prices"))
 		}
 	}
 
+	if len(vehicles) > 0 {
+		for i := range vehicles {
+			vehicles[i].TenantID = newTenant.ID
+		}
+
+		if err := tc.tenantVehicleRepo.CreateTenantVehicles(c.Request().Context(), &vehicles); err != nil {
+			return utils.HandlerError(c, utils.NewBadRequestError("failed to create tenant vehicles"))
+		}
+	}
+
 	return c.JSON(http.StatusCreated, map[string]string{"message": "success to create tenant"})
 }
 
@@ -152,24 +199,15 @@ func (tc *TenantController) FindAllTenants(c echo.Context) error {
 	is_tenant := c.QueryParam("is_tenant")
 	var IsTenant bool
 
-	var roomingHouseIDs []uuid.UUID
 	if is_tenant == "true" {
 		IsTenant = true
 	} else {
 		IsTenant = false
 	}
 
-	if userPayload.Role == "admin" {
-		roomingHouseIDs = append(roomingHouseIDs, userPayload.RoomingHouseID)
-	} else {
-		roomingHouses, err := tc.roomingHouseRepo.FindAllRoomingHouse(uuid.Nil, userPayload.UserID, userPayload.Role)
-		if err != nil {
-			return utils.HandlerError(c, utils.NewBadRequestError("failed to find rooming houses"))
-		}
-
-		for _, ID := range roomingHouses {
-			roomingHouseIDs = append(roomingHouseIDs, ID.ID)
-		}
+	roomingHouseIDs, apiErr := scopedRoomingHouseIDs(tc.roomingHouseRepo, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
 	tenants, err := tc.tenantRepo.FindAllTenants(roomingHouseIDs, IsTenant)
@@ -190,28 +228,110 @@ func (tc *TenantController) FindTenantByID(c echo.Context) error {
 		return utils.HandlerError(c, utils.NewBadRequestError("invalid tenant id"))
 	}
 
-	var roomingHouseIDs []uuid.UUID
+	roomingHouseIDs, apiErr := scopedRoomingHouseIDs(tc.roomingHouseRepo, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
 
-	if userPayload.Role == "admin" {
-		roomingHouseIDs = append(roomingHouseIDs, userPayload.RoomingHouseID)
-	} else {
-		roomingHouses, err := tc.roomingHouseRepo.FindAllRoomingHouse(uuid.Nil, userPayload.UserID, userPayload.Role)
-		if err != nil {
-			return utils.HandlerError(c, utils.NewBadRequestError("failed to find rooming houses"))
-		}
+	tenant, err := tc.tenantRepo.FindTenantByID(parsedTenantID, roomingHouseIDs)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("failed to find tenant"))
+	}
 
-		for _, ID := range roomingHouses {
-			roomingHouseIDs = append(roomingHouseIDs, ID.ID)
-		}
+	return c.JSON(http.StatusOK, tenant)
+}
+
+func (tc *TenantController) UpdateTenantByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var tenantBody models.UpdateTenantBody
+
+	parsedTenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid tenant id"))
+	}
+
+	if err := c.Bind(&tenantBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if tenantBody.Name == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("name is required"))
+	}
+
+	if tenantBody.Gender == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("gender is required"))
+	}
+
+	if tenantBody.PhoneNumber == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("phone number is required"))
+	}
 
+	tenantBody.PhoneNumber = strings.TrimSpace(tenantBody.PhoneNumber)
+	tenantBody.IDNumber = strings.TrimSpace(tenantBody.IDNumber)
+
+	dateOfBirth, vehicles, apiErr := validateTenantProfile(tenantBody.IDNumber, tenantBody.DateOfBirth, tenantBody.Vehicles)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	roomingHouseIDs, apiErr := scopedRoomingHouseIDs(tc.roomingHouseRepo, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
 	tenant, err := tc.tenantRepo.FindTenantByID(parsedTenantID, roomingHouseIDs)
 	if err != nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("failed to find tenant"))
+		return utils.HandlerError(c, utils.NewNotFoundError("tenant not found"))
 	}
 
-	return c.JSON(http.StatusOK, tenant)
+	roomingHouse, err := tc.roomingHouseRepo.FindRoomingHouseByID(tenant.RoomingHouse.ID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	// Only a changed phone or ID number is screened again
+	screenedPhoneNumber, screenedIDNumber := tenantBody.PhoneNumber, tenantBody.IDNumber
+	if screenedPhoneNumber == tenant.PhoneNumber {
+		screenedPhoneNumber = ""
+	}
+	if screenedIDNumber == tenant.IDNumber {
+		screenedIDNumber = ""
+	}
+
+	matches, apiErr := tc.screenTenantProfile(userPayload, roomingHouse, tenant.ID, screenedPhoneNumber, screenedIDNumber, tenantBody.OverrideJustification)
+	if apiErr != nil {
+		return tenantScreeningError(c, apiErr, matches)
+	}
+
+	updatedTenant := models.Tenant{
+		Name:             tenantBody.Name,
+		Gender:           tenantBody.Gender,
+		PhoneNumber:      tenantBody.PhoneNumber,
+		EmergencyContact: tenantBody.EmergencyContact,
+		IDNumber:         tenantBody.IDNumber,
+		DateOfBirth:      dateOfBirth,
+		Occupation:       tenantBody.Occupation,
+		Institution:      tenantBody.Institution,
+		OriginAddress:    tenantBody.OriginAddress,
+	}
+
+	if err := tc.tenantRepo.UpdateTenantProfileByID(c.Request().Context(), &updatedTenant, tenant.ID); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("failed to update tenant"))
+	}
+
+	if apiErr := tc.recordScreeningOverride(c, userPayload, roomingHouse, tenant.ID, matches, tenantBody.OverrideJustification); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	for i := range vehicles {
+		vehicles[i].TenantID = tenant.ID
+	}
+
+	if err := tc.tenantVehicleRepo.UpdateTenantVehiclesByTenantID(c.Request().Context(), &vehicles, tenant.ID); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("failed to update tenant vehicles"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "success to update tenant"})
 }
 
 func (tc *TenantController) DeleteTenantByID(c echo.Context) error {
@@ -222,9 +342,230 @@ func (tc *TenantController) DeleteTenantByID(c echo.Context) error {
 		return utils.HandlerError(c, utils.NewBadRequestError("invalid tenant id"))
 	}
 
-	if err := tc.tenantRepo.DeleteTenantByID(parsedTenantID); err != nil {
+	if err := tc.tenantRepo.DeleteTenantByID(c.Request().Context(), parsedTenantID); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("failed to delete tenant"))
 	}
 
+	if err := tc.tenantAccountRepo.DeleteTenantAccountByTenantID(c.Request().Context(), parsedTenantID); err != nil && err != gorm.ErrRecordNotFound {
+		return utils.HandlerError(c, utils.NewInternalError("failed to delete tenant account"))
+	}
+
+	if err := tc.sessionRepo.RevokeSessionsByUserID(parsedTenantID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to revoke tenant sessions"))
+	}
+
 	return c.JSON(http.StatusOK, map[string]string{"message": "success to delete tenant"})
 }
+
+func (tc *TenantController) CreateTenantAccount(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var accountBody models.TenantAccountBody
+
+	parsedTenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid tenant id"))
+	}
+
+	if err := c.Bind(&accountBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if accountBody.Email == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("email is required"))
+	}
+
+	if accountBody.Password == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("password is required"))
+	}
+
+	tenant, apiErr := tc.findAccessibleTenant(userPayload, parsedTenantID)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if !tenant.IsTenant {
+		return utils.HandlerError(c, utils.NewBadRequestError("portal accounts are only available for the main tenant"))
+	}
+
+	if _, err := tc.tenantAccountRepo.FindTenantAccountByTenantID(tenant.ID); err == nil {
+		return utils.HandlerError(c, utils.NewConflictError("tenant already has an account"))
+	}
+
+	newAccount := models.TenantAccount{
+		TenantID:       tenant.ID,
+		Email:          accountBody.Email,
+		Password:       accountBody.Password,
+		Role:           "tenant",
+		RoomingHouseID: tenant.RoomingHouse.ID,
+	}
+
+	if err := tc.tenantAccountRepo.CreateTenantAccount(c.Request().Context(), &newAccount); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to create tenant account"))
+	}
+
+	return c.JSON(http.StatusCreated, models.TenantAccountResponse{
+		ID:             newAccount.ID,
+		TenantID:       newAccount.TenantID,
+		Email:          newAccount.Email,
+		RoomingHouseID: newAccount.RoomingHouseID,
+	})
+}
+
+func (tc *TenantController) DeleteTenantAccount(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	parsedTenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid tenant id"))
+	}
+
+	if _, apiErr := tc.findAccessibleTenant(userPayload, parsedTenantID); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if err := tc.tenantAccountRepo.DeleteTenantAccountByTenantID(c.Request().Context(), parsedTenantID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return utils.HandlerError(c, utils.NewNotFoundError("tenant account not found"))
+		}
+		return utils.HandlerError(c, utils.NewInternalError("failed to delete tenant account"))
+	}
+
+	if err := tc.sessionRepo.RevokeSessionsByUserID(parsedTenantID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to revoke tenant sessions"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "success to delete tenant account"})
+}
+
+func (tc *TenantController) findAccessibleTenant(userPayload *models.JWTPayload, tenantID uuid.UUID) (*models.TenantDetailResponse, *utils.APIError) {
+	roomingHouseIDs, apiErr := scopedRoomingHouseIDs(tc.roomingHouseRepo, userPayload)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	tenant, err := tc.tenantRepo.FindTenantByID(tenantID, roomingHouseIDs)
+	if err != nil {
+		return nil, utils.NewNotFoundError("tenant not found")
+	}
+
+	return tenant, nil
+}
+
+// screenTenantProfile screens a new or changed phone and ID number against the
+// blacklist and the active tenants of the rooming house owner, leaving out the
+// tenant itself. Matches are only accepted with an override justification from
+// a user allowed to override screening.
+func (tc *TenantController) screenTenantProfile(userPayload *models.JWTPayload, roomingHouse *models.RoomingHouseByIDResponse, tenantID uuid.UUID, phoneNumber string, idNumber string, overrideJustification string) ([]models.TenantScreeningMatch, *utils.APIError) {
+	if phoneNumber == "" && idNumber == "" {
+		return nil, nil
+	}
+
+	screened, err := screenTenant(tc.tenantBlacklistRepo, tc.tenantRepo, tc.roomingHouseRepo, roomingHouse.OwnerID, phoneNumber, idNumber)
+	if err != nil {
+		return nil, utils.NewInternalError("failed to screen tenant")
+	}
+
+	var matches []models.TenantScreeningMatch
+	for _, match := range screened {
+		if match.Source == "tenant" && match.ID == tenantID {
+			continue
+		}
+		matches = append(matches, match)
+	}
+
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	if overrideJustification == "" {
+		return matches, utils.NewConflictError("tenant matches a blacklisted or existing tenant")
+	}
+
+	if !userPayload.Can(roomingHouse.ID, constants.Permission(constants.ResourceBlacklist, constants.ActionOverride)) {
+		return nil, utils.NewForbiddenError("you are not allowed to override tenant screening")
+	}
+
+	return matches, nil
+}
+
+// tenantScreeningError writes an error of screenTenantProfile, listing the
+// matches that need an override.
+func tenantScreeningError(c echo.Context, apiErr *utils.APIError, matches []models.TenantScreeningMatch) error {
+	if len(matches) == 0 {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	return c.JSON(apiErr.Code, map[string]interface{}{
+		"code":    apiErr.Code,
+		"message": apiErr.Message,
+		"detail":  apiErr.Detail,
+		"matches": matches,
+	})
+}
+
+// recordScreeningOverride keeps the screening matches accepted for the tenant
+// with the justification given.
+func (tc *TenantController) recordScreeningOverride(c echo.Context, userPayload *models.JWTPayload, roomingHouse *models.RoomingHouseByIDResponse, tenantID uuid.UUID, matches []models.TenantScreeningMatch, overrideJustification string) *utils.APIError {
+	if len(matches) == 0 {
+		return nil
+	}
+
+	matchesJSON, err := json.Marshal(matches)
+	if err != nil {
+		return utils.NewInternalError("failed to record screening override")
+	}
+
+	if err := tc.tenantBlacklistRepo.CreateScreeningOverride(c.Request().Context(), &models.TenantScreeningOverride{
+		TenantID:       tenantID,
+		OwnerID:        roomingHouse.OwnerID,
+		RoomingHouseID: roomingHouse.ID,
+		Matches:        string(matchesJSON),
+		Justification:  overrideJustification,
+		ApprovedBy:     userPayload.UserID,
+	}); err != nil {
+		return utils.NewInternalError("failed to record screening override")
+	}
+
+	return nil
+}
+
+func validateTenantProfile(idNumber string, dateOfBirth string, vehicleBodies []models.TenantVehicleBody) (*time.Time, []models.TenantVehicle, *utils.APIError) {
+	if idNumber != "" && !utils.IsValidNIK(idNumber) {
+		return nil, nil, utils.NewBadRequestError("id number must be a 16-digit NIK")
+	}
+
+	var parsedDateOfBirth *time.Time
+	if dateOfBirth != "" {
+		parsed, err := time.Parse(constants.DateLayout, dateOfBirth)
+		if err != nil {
+			return nil, nil, utils.NewBadRequestError("date of birth must use YYYY-MM-DD format")
+		}
+
+		if parsed.After(time.Now()) {
+			return nil, nil, utils.NewBadRequestError("date of birth cannot be in the future")
+		}
+
+		parsedDateOfBirth = &parsed
+	}
+
+	vehicles := []models.TenantVehicle{}
+	seenPlates := make(map[string]bool)
+	for _, vehicleBody := range vehicleBodies {
+		plateNumber, ok := utils.NormalizePlateNumber(vehicleBody.PlateNumber)
+		if !ok {
+			return nil, nil, utils.NewBadRequestError("invalid plate number " + vehicleBody.PlateNumber)
+		}
+
+		if seenPlates[plateNumber] {
+			return nil, nil, utils.NewBadRequestError("duplicate plate number " + plateNumber)
+		}
+		seenPlates[plateNumber] = true
+
+		vehicles = append(vehicles, models.TenantVehicle{
+			PlateNumber: plateNumber,
+			VehicleType: vehicleBody.VehicleType,
+		})
+	}
+
+	return parsedDateOfBirth, vehicles, nil
+}
@@ -0,0 +1,200 @@
+package controllers
+
+import (
+	"net/http"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/utils"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type TenantBlacklistController struct {
+	tenantBlacklistRepo repositories.TenantBlacklistRepository
+	tenantRepo          repositories.TenantRepository
+	roomingHouseRepo    repositories.RoomingHouseRepository
+}
+
+func NewTenantBlacklistController(tenantBlacklistRepo repositories.TenantBlacklistRepository, tenantRepo repositories.TenantRepository, roomingHouseRepo repositories.RoomingHouseRepository) *TenantBlacklistController {
+	return &TenantBlacklistController{tenantBlacklistRepo: tenantBlacklistRepo, tenantRepo: tenantRepo, roomingHouseRepo: roomingHouseRepo}
+}
+
+func (tbc *TenantBlacklistController) CreateTenantBlacklist(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var blacklistBody models.AddTenantBlacklistBody
+
+	if err := c.Bind(&blacklistBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if blacklistBody.RoomingHouseID == uuid.Nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house id is required"))
+	}
+
+	if blacklistBody.Reason == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("reason is required"))
+	}
+
+	if !canAccessRoomingHouse(tbc.roomingHouseRepo, userPayload, blacklistBody.RoomingHouseID) {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	roomingHouse, err := tbc.roomingHouseRepo.FindRoomingHouseByID(blacklistBody.RoomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	if blacklistBody.TenantID != nil {
+		tenant, err := tbc.tenantRepo.FindTenantByID(*blacklistBody.TenantID, []uuid.UUID{roomingHouse.ID})
+		if err != nil {
+			return utils.HandlerError(c, utils.NewBadRequestError("tenant not found"))
+		}
+
+		if blacklistBody.Name == "" {
+			blacklistBody.Name = tenant.Name
+		}
+
+		if blacklistBody.PhoneNumber == "" {
+			blacklistBody.PhoneNumber = tenant.PhoneNumber
+		}
+
+		if blacklistBody.IDNumber == "" {
+			blacklistBody.IDNumber = tenant.IDNumber
+		}
+	}
+
+	blacklistBody.PhoneNumber = strings.TrimSpace(blacklistBody.PhoneNumber)
+	blacklistBody.IDNumber = strings.TrimSpace(blacklistBody.IDNumber)
+
+	if blacklistBody.Name == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("name is required"))
+	}
+
+	if blacklistBody.PhoneNumber == "" && blacklistBody.IDNumber == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("phone number or id number is required"))
+	}
+
+	if blacklistBody.IDNumber != "" && !utils.IsValidNIK(blacklistBody.IDNumber) {
+		return utils.HandlerError(c, utils.NewBadRequestError("id number must be a 16-digit NIK"))
+	}
+
+	newBlacklist := models.TenantBlacklist{
+		OwnerID:        roomingHouse.OwnerID,
+		RoomingHouseID: roomingHouse.ID,
+		TenantID:       blacklistBody.TenantID,
+		Name:           blacklistBody.Name,
+		PhoneNumber:    blacklistBody.PhoneNumber,
+		IDNumber:       blacklistBody.IDNumber,
+		Reason:         blacklistBody.Reason,
+		Evidence:       blacklistBody.Evidence,
+		CreatedBy:      userPayload.UserID,
+	}
+
+	if err := tbc.tenantBlacklistRepo.CreateTenantBlacklist(c.Request().Context(), &newBlacklist); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to create blacklist entry"))
+	}
+
+	return c.JSON(http.StatusCreated, newBlacklist)
+}
+
+func (tbc *TenantBlacklistController) FindAllTenantBlacklists(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	ownerID, err := requestOwnerID(tbc.roomingHouseRepo, c, userPayload)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	tenantBlacklists, err := tbc.tenantBlacklistRepo.FindAllTenantBlacklists(ownerID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get blacklist"))
+	}
+
+	return c.JSON(http.StatusOK, tenantBlacklists)
+}
+
+func (tbc *TenantBlacklistController) FindTenantBlacklistByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	blacklistID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid blacklist id"))
+	}
+
+	ownerID, err := requestOwnerID(tbc.roomingHouseRepo, c, userPayload)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	tenantBlacklist, err := tbc.tenantBlacklistRepo.FindTenantBlacklistByID(blacklistID, ownerID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewNotFoundError("blacklist entry not found"))
+	}
+
+	return c.JSON(http.StatusOK, tenantBlacklist)
+}
+
+func (tbc *TenantBlacklistController) DeleteTenantBlacklistByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	blacklistID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid blacklist id"))
+	}
+
+	ownerID, err := requestOwnerID(tbc.roomingHouseRepo, c, userPayload)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	if err := tbc.tenantBlacklistRepo.DeleteTenantBlacklistByID(c.Request().Context(), blacklistID, ownerID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return utils.HandlerError(c, utils.NewNotFoundError("blacklist entry not found"))
+		}
+		return utils.HandlerError(c, utils.NewInternalError("failed to delete blacklist entry"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "blacklist entry deleted"})
+}
+
+func (tbc *TenantBlacklistController) CheckTenant(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	phoneNumber := strings.TrimSpace(c.QueryParam("phoneNumber"))
+	idNumber := strings.TrimSpace(c.QueryParam("id_number"))
+
+	if phoneNumber == "" && idNumber == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("phone number or id number is required"))
+	}
+
+	ownerID, err := requestOwnerID(tbc.roomingHouseRepo, c, userPayload)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	matches, err := screenTenant(tbc.tenantBlacklistRepo, tbc.tenantRepo, tbc.roomingHouseRepo, ownerID, phoneNumber, idNumber)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to screen tenant"))
+	}
+
+	return c.JSON(http.StatusOK, matches)
+}
+
+func (tbc *TenantBlacklistController) FindAllScreeningOverrides(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	ownerID, err := requestOwnerID(tbc.roomingHouseRepo, c, userPayload)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	overrides, err := tbc.tenantBlacklistRepo.FindAllScreeningOverrides(ownerID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get screening overrides"))
+	}
+
+	return c.JSON(http.StatusOK, overrides)
+}
@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"net/http"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// TenantPortalController serves the read-only self-service endpoints. Every
+// handler scopes its queries to the tenant ID carried in the tenant's JWT.
+type TenantPortalController struct {
+	tenantRepo        repositories.TenantRepository
+	roomRepo          repositories.RoomRepository
+	transactionRepo   repositories.TransactionRepository
+	periodRepo        repositories.PeriodRepository
+	periodPackageRepo repositories.PeriodPackageRepository
+}
+
+func NewTenantPortalController(tenantRepo repositories.TenantRepository, roomRepo repositories.RoomRepository, transactionRepo repositories.TransactionRepository, periodRepo repositories.PeriodRepository, periodPackageRepo repositories.PeriodPackageRepository) *TenantPortalController {
+	return &TenantPortalController{tenantRepo: tenantRepo, roomRepo: roomRepo, transactionRepo: transactionRepo, periodRepo: periodRepo, periodPackageRepo: periodPackageRepo}
+}
+
+func (tpc *TenantPortalController) GetProfile(c echo.Context) error {
+	tenant, apiErr := tpc.findCurrentTenant(c)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	return c.JSON(http.StatusOK, tenant)
+}
+
+func (tpc *TenantPortalController) GetRoom(c echo.Context) error {
+	tenant, apiErr := tpc.findCurrentTenant(c)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	room, err := tpc.roomRepo.FindTenantPortalRoom(tenant.BookedRoomID, tenant.RoomingHouse.ID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewNotFoundError("room not found"))
+	}
+
+	return c.JSON(http.StatusOK, room)
+}
+
+func (tpc *TenantPortalController) GetCharges(c echo.Context) error {
+	tenant, apiErr := tpc.findCurrentTenant(c)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	room, err := tpc.roomRepo.FindTenantPortalRoom(tenant.BookedRoomID, tenant.RoomingHouse.ID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewNotFoundError("room not found"))
+	}
+
+	period, err := tpc.periodRepo.FindPeriodByID(tenant.Period.ID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewNotFoundError("period not found"))
+	}
+
+	periodPackage, err := tpc.periodPackageRepo.FindPeriodPackageByPeriodIDPackageID(period.ID, room.PricingPackage.ID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewNotFoundError("period package not found"))
+	}
+
+	var additionalFee float64
+	for _, additionalPrice := range tenant.AdditionalPrices {
+		additionalFee += additionalPrice.Price
+	}
+
+	rentAmount := periodPackage.Price * float64(tenant.RegularPaymentDuration)
+
+	coverStart := time.Now().Truncate(24 * time.Hour)
+	if tenant.EndDate != nil {
+		coverStart = *tenant.EndDate
+	}
+
+	upcomingCharges := []models.TenantUpcomingCharge{}
+	for i := 0; i < constants.TenantUpcomingChargeCount; i++ {
+		coverEnd := utils.AddPeriod(coverStart, period.Unit, period.Count*tenant.RegularPaymentDuration)
+
+		upcomingCharges = append(upcomingCharges, models.TenantUpcomingCharge{
+			DueDate:        coverStart,
+			CoverStartDate: coverStart,
+			CoverEndDate:   coverEnd,
+			RentAmount:     rentAmount,
+			AdditionalFee:  additionalFee,
+			TotalAmount:    rentAmount + additionalFee,
+		})
+
+		coverStart = coverEnd
+	}
+
+	return c.JSON(http.StatusOK, models.TenantPortalChargeResponse{
+		Period: models.PeriodResponse{
+			ID:    period.ID,
+			Name:  period.Name,
+			Unit:  period.Unit,
+			Count: period.Count,
+		},
+		RegularPaymentDuration: tenant.RegularPaymentDuration,
+		CurrentStartDate:       tenant.StartDate,
+		CurrentEndDate:         tenant.EndDate,
+		UpcomingCharges:        upcomingCharges,
+		AdditionalPrices:       tenant.AdditionalPrices,
+	})
+}
+
+func (tpc *TenantPortalController) GetTransactions(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	transactions, err := tpc.transactionRepo.FindTransactionsByTenantID(userPayload.UserID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get transactions"))
+	}
+
+	return c.JSON(http.StatusOK, transactions)
+}
+
+func (tpc *TenantPortalController) GetReceipt(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid transaction id"))
+	}
+
+	receipt, err := tpc.transactionRepo.FindTenantReceipt(transactionID, userPayload.UserID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewNotFoundError("receipt not found"))
+	}
+
+	return c.JSON(http.StatusOK, receipt)
+}
+
+func (tpc *TenantPortalController) findCurrentTenant(c echo.Context) (*models.TenantDetailResponse, *utils.APIError) {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	tenant, err := tpc.tenantRepo.FindTenantByID(userPayload.UserID, []uuid.UUID{userPayload.RoomingHouseID})
+	if err != nil {
+		return nil, utils.NewNotFoundError("tenant not found")
+	}
+
+	if !tenant.IsTenant {
+		return nil, utils.NewForbiddenError("only the main tenant can access the portal")
+	}
+
+	return tenant, nil
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// fakeTenantRepository keeps tenants in memory; only active tenants are
+// screened, as in FindActiveTenantMatches.
+type fakeTenantRepository struct {
+	repositories.TenantRepository
+	tenants []models.TenantDetailResponse
+	active  map[uuid.UUID]bool
+	updated *models.Tenant
+}
+
+func (r *fakeTenantRepository) FindTenantByID(tenantID uuid.UUID, roomingHouseIDs []uuid.UUID) (*models.TenantDetailResponse, error) {
+	for i := range r.tenants {
+		if r.tenants[i].ID != tenantID {
+			continue
+		}
+		for _, roomingHouseID := range roomingHouseIDs {
+			if r.tenants[i].RoomingHouse.ID == roomingHouseID {
+				return &r.tenants[i], nil
+			}
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeTenantRepository) FindActiveTenantMatches(roomingHouseIDs []uuid.UUID, phoneNumber string, idNumber string) ([]models.TenantScreeningMatch, error) {
+	var matches []models.TenantScreeningMatch
+	for _, tenant := range r.tenants {
+		if !r.active[tenant.ID] {
+			continue
+		}
+		if (phoneNumber != "" && tenant.PhoneNumber == phoneNumber) || (idNumber != "" && tenant.IDNumber == idNumber) {
+			matches = append(matches, models.TenantScreeningMatch{Source: "tenant", ID: tenant.ID, PhoneNumber: tenant.PhoneNumber, IDNumber: tenant.IDNumber})
+		}
+	}
+	return matches, nil
+}
+
+func (r *fakeTenantRepository) UpdateTenantProfileByID(_ context.Context, tenant *models.Tenant, _ uuid.UUID) error {
+	r.updated = tenant
+	return nil
+}
+
+type fakeTenantVehicleRepository struct {
+	repositories.TenantVehicleRepository
+}
+
+func (fakeTenantVehicleRepository) UpdateTenantVehiclesByTenantID(context.Context, *[]models.TenantVehicle, uuid.UUID) error {
+	return nil
+}
+
+func TestUpdateTenantScreening(t *testing.T) {
+	const (
+		tenantPhone     = "081200000001"
+		tenantIDNumber  = "3171000000000001"
+		activeIDNumber  = "3171000000000002"
+		formerIDNumber  = "3171000000000003"
+		blacklistedNIK  = "3171000000000004"
+		unusedIDNumber  = "3171000000000005"
+		justification   = `,"override_justification":"same person, moved rooms"`
+		blacklistedName = "Andi"
+	)
+
+	tests := []struct {
+		name          string
+		phoneNumber   string
+		idNumber      string
+		extra         string
+		wantStatus    int
+		wantOverrides int
+	}{
+		{name: "unchanged profile matching the tenant itself", phoneNumber: tenantPhone, idNumber: tenantIDNumber, wantStatus: http.StatusOK},
+		{name: "id number of a tenant who has left", phoneNumber: tenantPhone, idNumber: formerIDNumber, wantStatus: http.StatusOK},
+		{name: "new id number", phoneNumber: tenantPhone, idNumber: unusedIDNumber, wantStatus: http.StatusOK},
+		{name: "id number of an active tenant", phoneNumber: tenantPhone, idNumber: activeIDNumber, wantStatus: http.StatusConflict},
+		{name: "blacklisted id number", phoneNumber: tenantPhone, idNumber: blacklistedNIK, wantStatus: http.StatusConflict},
+		{name: "active tenant match with an override", phoneNumber: tenantPhone, idNumber: activeIDNumber, extra: justification, wantStatus: http.StatusOK, wantOverrides: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			co := newCoOwnership()
+			roomingHouse := models.TenantRoomingHouseResponse{ID: co.sharedHouseID}
+
+			tenant := models.TenantDetailResponse{ID: uuid.New(), Name: "Budi", PhoneNumber: tenantPhone, IDNumber: tenantIDNumber, RoomingHouse: roomingHouse}
+			activeTenant := models.TenantDetailResponse{ID: uuid.New(), Name: "Citra", PhoneNumber: "081200000002", IDNumber: activeIDNumber, RoomingHouse: roomingHouse}
+			formerTenant := models.TenantDetailResponse{ID: uuid.New(), Name: "Dewi", PhoneNumber: "081200000003", IDNumber: formerIDNumber, RoomingHouse: roomingHouse}
+
+			tenantRepo := &fakeTenantRepository{
+				tenants: []models.TenantDetailResponse{tenant, activeTenant, formerTenant},
+				active:  map[uuid.UUID]bool{tenant.ID: true, activeTenant.ID: true},
+			}
+			blacklist := &fakeTenantBlacklistRepository{entries: []models.TenantBlacklist{{OwnerID: co.primaryOwnerID, Name: blacklistedName, IDNumber: blacklistedNIK}}}
+			tc := NewTenantController(tenantRepo, nil, co.roomingHouses, nil, fakeTenantVehicleRepository{}, blacklist, nil, nil)
+
+			// A co-owner edits, so screening must use the primary owner's blacklist
+			coOwner := &models.JWTPayload{UserID: co.coOwnerID, Role: "owner"}
+			body := `{"name":"Budi","gender":"male","phoneNumber":"` + tt.phoneNumber + `","id_number":"` + tt.idNumber + `"` + tt.extra + `}`
+			rec := serveAs(coOwner, tc.UpdateTenantByID, http.MethodPut, "/tenants/"+tenant.ID.String(), body, tenant.ID.String())
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus == http.StatusConflict && !strings.Contains(rec.Body.String(), `"matches"`) {
+				t.Errorf("conflict does not list the matches: %s", rec.Body.String())
+			}
+			if updated := tenantRepo.updated != nil; updated != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("tenant updated = %v, want %v", updated, tt.wantStatus == http.StatusOK)
+			}
+			if len(blacklist.overrides) != tt.wantOverrides {
+				t.Errorf("got %d screening overrides, want %d", len(blacklist.overrides), tt.wantOverrides)
+			}
+		})
+	}
+}
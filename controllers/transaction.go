@@ -6,6 +6,7 @@ import (
 	"rooming-house-cms-be/models"
 	"rooming-house-cms-be/repositories"
 	"rooming-house-cms-be/utils"
+	"slices"
 	"strconv"
 	"time"
 
@@ -21,10 +22,11 @@ type TransactionController struct {
 	periodPackageRepo       repositories.PeriodPackageRepository
 	periodRepo              repositories.PeriodRepository
 	roomingHouseRepo        repositories.RoomingHouseRepository
+	roomingHouseOwnerRepo   repositories.RoomingHouseOwnerRepository
 }
 
-func NewTransactionController(transactionRepo repositories.TransactionRepository, transactionCategoryRepo repositories.TransactionCategoryRepository, tenantRepo repositories.TenantRepository, periodPackageRepo repositories.PeriodPackageRepository, periodRepo repositories.PeriodRepository, roomRepo repositories.RoomRepository, roomingHouseRepo repositories.RoomingHouseRepository) *TransactionController {
-	return &TransactionController{transactionRepo: transactionRepo, transactionCategoryRepo: transactionCategoryRepo, tenantRepo: tenantRepo, periodPackageRepo: periodPackageRepo, periodRepo: periodRepo, roomRepo: roomRepo, roomingHouseRepo: roomingHouseRepo}
+func NewTransactionController(transactionRepo repositories.TransactionRepository, transactionCategoryRepo repositories.TransactionCategoryRepository, tenantRepo repositories.TenantRepository, periodPackageRepo repositories.PeriodPackageRepository, periodRepo repositories.PeriodRepository, roomRepo repositories.RoomRepository, roomingHouseRepo repositories.RoomingHouseRepository, roomingHouseOwnerRepo repositories.RoomingHouseOwnerRepository) *TransactionController {
+	return &TransactionController{transactionRepo: transactionRepo, transactionCategoryRepo: transactionCategoryRepo, tenantRepo: tenantRepo, periodPackageRepo: periodPackageRepo, periodRepo: periodRepo, roomRepo: roomRepo, roomingHouseRepo: roomingHouseRepo, roomingHouseOwnerRepo: roomingHouseOwnerRepo}
 }
 
 func (tc *TransactionController) CreateTransaction(c echo.Context) error {
@@ -51,22 +53,25 @@ func (tc *TransactionController) CreateTransaction(c echo.Context) error {
 		return utils.HandlerError(c, utils.NewBadRequestError("transaction category id is required"))
 	}
 
-	if userPayload.Role == "owner" {
-		if transactionBody.RoomingHouseID == uuid.Nil {
-			return utils.HandlerError(c, utils.NewBadRequestError("rooming house id is required"))
-		}
-	} else {
-		transactionBody.RoomingHouseID = userPayload.RoomingHouseID
+	roomingHouseID, apiErr := targetRoomingHouseID(userPayload, transactionBody.RoomingHouseID)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
+	transactionBody.RoomingHouseID = roomingHouseID
 
 	var amount float64
 
-	transactionCategory, err := tc.transactionCategoryRepo.FindTransactionCategoryByID(transactionBody.TransactionCategoryID)
+	roomingHouse, err := tc.roomingHouseRepo.FindRoomingHouseByID(transactionBody.RoomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
 	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	transactionCategory, err := tc.transactionCategoryRepo.FindTransactionCategoryByID(transactionBody.TransactionCategoryID)
+	if err != nil || !transactionCategoryAvailableToRoomingHouse(transactionCategory, roomingHouse) {
 		return utils.HandlerError(c, utils.NewBadRequestError("transaction category not found"))
 	}
 
-	if transactionCategory.Name == "Rent" {
+	if transactionCategory.Code == constants.TransactionCategoryCodeRent {
 		if transactionBody.TenantID == nil {
 			return utils.HandlerError(c, utils.NewBadRequestError("tenant id is required"))
 		}
@@ -80,7 +85,7 @@ func (tc *TransactionController) CreateTransaction(c echo.Context) error {
 			return utils.HandlerError(c, utils.NewBadRequestError("tenant not found"))
 		}
 
-		room, err := tc.roomRepo.FindRoomByID(tenant.BookedRoomID, tenant.RoomingHouse.ID, userPayload.UserID, userPayload.Role)
+		room, err := tc.roomRepo.FindRoomByID(tenant.BookedRoomID, []uuid.UUID{tenant.RoomingHouse.ID}, userPayload.UserID, userPayload.Role)
 		if err != nil {
 			return utils.HandlerError(c, utils.NewBadRequestError("room not found"))
 		}
@@ -98,7 +103,7 @@ func (tc *TransactionController) CreateTransaction(c echo.Context) error {
 
 		amount += periodPackage.Price * float64(tenant.RegularPaymentDuration)
 
-		if err := tc.transactionRepo.CreateTransaction(&models.Transaction{
+		if err := tc.transactionRepo.CreateTransaction(c.Request().Context(), &models.Transaction{
 			Day:                   transactionBody.Day,
 			Month:                 transactionBody.Month,
 			Year:                  transactionBody.Year,
@@ -112,32 +117,21 @@ func (tc *TransactionController) CreateTransaction(c echo.Context) error {
 			return utils.HandlerError(c, utils.NewBadRequestError("failed to create transaction"))
 		}
 
-		var endDate time.Time
-
 		period, err := tc.periodRepo.FindPeriodByID(tenant.Period.ID)
 		if err != nil {
 			return utils.HandlerError(c, utils.NewBadRequestError("period not found"))
 		}
 
-		if period.Name == "Monthly" {
-			endDate = time.Date(transactionBody.Year, time.Month(transactionBody.Month), transactionBody.Day, 0, 0, 0, 0, time.UTC).AddDate(0, tenant.RegularPaymentDuration, 0)
-		} else if period.Name == "Annually" {
-			endDate = time.Date(transactionBody.Year, time.Month(transactionBody.Month), transactionBody.Day, 0, 0, 0, 0, time.UTC).AddDate(tenant.RegularPaymentDuration, 0, 0)
-		} else if period.Name == "Daily" {
-			endDate = time.Date(transactionBody.Year, time.Month(transactionBody.Month), transactionBody.Day, 0, 0, 0, 0, time.UTC).AddDate(0, 0, tenant.RegularPaymentDuration)
-		} else if period.Name == "Weekly" {
-			endDate = time.Date(transactionBody.Year, time.Month(transactionBody.Month), transactionBody.Day, 0, 0, 0, 0, time.UTC).AddDate(0, 0, tenant.RegularPaymentDuration*7)
-		}
-
 		startDate := time.Date(transactionBody.Year, time.Month(transactionBody.Month), transactionBody.Day, 0, 0, 0, 0, time.UTC)
+		endDate := utils.AddPeriod(startDate, period.Unit, period.Count*tenant.RegularPaymentDuration)
 
-		if err := tc.tenantRepo.UpdateTenantByID(&models.Tenant{
+		if err := tc.tenantRepo.UpdateTenantByID(c.Request().Context(), &models.Tenant{
 			StartDate: &startDate,
 			EndDate:   &endDate,
 		}, tenant.ID); err != nil {
 			return utils.HandlerError(c, utils.NewBadRequestError("failed to update tenant"))
 		}
-	} else if transactionCategory.Name == "Deposit" {
+	} else if transactionCategory.Code == constants.TransactionCategoryCodeDeposit {
 		if transactionBody.TenantID == nil {
 			return utils.HandlerError(c, utils.NewBadRequestError("tenant id is required"))
 		}
@@ -159,7 +153,7 @@ func (tc *TransactionController) CreateTransaction(c echo.Context) error {
 			return utils.HandlerError(c, utils.NewBadRequestError("deposit already paid"))
 		}
 
-		if err := tc.transactionRepo.CreateTransaction(&models.Transaction{
+		if err := tc.transactionRepo.CreateTransaction(c.Request().Context(), &models.Transaction{
 			Day:                   transactionBody.Day,
 			Month:                 transactionBody.Month,
 			Year:                  transactionBody.Year,
@@ -173,12 +167,12 @@ func (tc *TransactionController) CreateTransaction(c echo.Context) error {
 			return utils.HandlerError(c, utils.NewBadRequestError("failed to create transaction"))
 		}
 
-		if err := tc.tenantRepo.UpdateTenantByID(&models.Tenant{
+		if err := tc.tenantRepo.UpdateTenantByID(c.Request().Context(), &models.Tenant{
 			IsDepositPaid: true,
 		}, tenant.ID); err != nil {
 			return utils.HandlerError(c, utils.NewBadRequestError("failed to update tenant"))
 		}
-	} else if transactionCategory.Name == "Deposit Payback" {
+	} else if transactionCategory.Code == constants.TransactionCategoryCodeDepositPayback {
 		if transactionBody.TenantID == nil {
 			return utils.HandlerError(c, utils.NewBadRequestError("tenant id is required"))
 		}
@@ -200,7 +194,7 @@ func (tc *TransactionController) CreateTransaction(c echo.Context) error {
 			return utils.HandlerError(c, utils.NewBadRequestError("deposit not paid"))
 		}
 
-		if err := tc.transactionRepo.CreateTransaction(&models.Transaction{
+		if err := tc.transactionRepo.CreateTransaction(c.Request().Context(), &models.Transaction{
 			Day:                   transactionBody.Day,
 			Month:                 transactionBody.Month,
 			Year:                  transactionBody.Year,
@@ -213,7 +207,7 @@ func (tc *TransactionController) CreateTransaction(c echo.Context) error {
 			return utils.HandlerError(c, utils.NewBadRequestError("failed to create transaction"))
 		}
 
-		if err := tc.tenantRepo.UpdateTenantByID(&models.Tenant{IsDepositPaid: false, IsDepositBack: true}, tenant.ID); err != nil {
+		if err := tc.tenantRepo.UpdateTenantByID(c.Request().Context(), &models.Tenant{IsDepositPaid: false, IsDepositBack: true}, tenant.ID); err != nil {
 			return utils.HandlerError(c, utils.NewBadRequestError("failed to update tenant"))
 		}
 	} else {
@@ -231,7 +225,7 @@ func (tc *TransactionController) CreateTransaction(c echo.Context) error {
 			return utils.HandlerError(c, utils.NewBadRequestError("amount is required"))
 		}
 
-		if err := tc.transactionRepo.CreateTransaction(&models.Transaction{
+		if err := tc.transactionRepo.CreateTransaction(c.Request().Context(), &models.Transaction{
 			Day:                   transactionBody.Day,
 			Month:                 transactionBody.Month,
 			Year:                  transactionBody.Year,
@@ -254,19 +248,9 @@ func (tc *TransactionController) FindAllTransactions(c echo.Context) error {
 	userPayload := c.Get("userPayload").(*models.JWTPayload)
 
 	// roomingHouseID := c.QueryParam("roomingHouseID")
-	var roomingHouseIDs []uuid.UUID
-
-	if userPayload.Role == "admin" {
-		roomingHouseIDs = append(roomingHouseIDs, userPayload.RoomingHouseID)
-	} else {
-		roomingHouses, err := tc.roomingHouseRepo.FindAllRoomingHouse(userPayload.RoomingHouseID, userPayload.UserID, userPayload.Role)
-		if err != nil {
-			return utils.HandlerError(c, utils.NewBadRequestError("failed to find rooming houses"))
-		}
-
-		for _, roomingHouse := range roomingHouses {
-			roomingHouseIDs = append(roomingHouseIDs, roomingHouse.ID)
-		}
+	roomingHouseIDs, apiErr := scopedRoomingHouseIDs(tc.roomingHouseRepo, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
 	}
 
 	transactions, err := tc.transactionRepo.FindAllTransactions(roomingHouseIDs, 0)
@@ -294,7 +278,7 @@ func (tc *TransactionController) Dashboard(c echo.Context) error {
 		}
 		roomingHouseIDs = append(roomingHouseIDs, parsedRoomingHouseID)
 	} else {
-		roomingHouses, err := tc.roomingHouseRepo.FindAllRoomingHouse(userPayload.RoomingHouseID, userPayload.UserID, userPayload.Role)
+		roomingHouses, err := tc.roomingHouseRepo.FindAllRoomingHouse(userPayload.UserID, userPayload.Role)
 		if err != nil {
 			return utils.HandlerError(c, utils.NewBadRequestError("failed to find rooming houses"))
 		}
@@ -326,48 +310,165 @@ func (tc *TransactionController) Dashboard(c echo.Context) error {
 	}
 
 	// Group by Rooming House
-	groupedData := make(map[string]*models.DashboardData)
+	groupedData := monthlyTransactionTotals(*transactions, func(txn models.TransactionResponse) string {
+		return txn.RoomingHouse.Name
+	})
+
+	// Convert grouped data into final response slice
+	finalResponse := []models.DashboardData{}
+	for rhName, transactionData := range groupedData {
+		finalResponse = append(finalResponse, models.DashboardData{
+			RoomingHouseName: rhName,
+			TransactionData:  transactionData,
+		})
+	}
+
+	return c.JSON(http.StatusOK, finalResponse)
+}
+
+// monthlyTransactionTotals sums the income and expense of every month of the
+// transactions, grouped by the key returned by groupBy.
+func monthlyTransactionTotals(transactions []models.TransactionResponse, groupBy func(models.TransactionResponse) string) map[string][]models.TransactionDashboardResponse {
+	totals := make(map[string][]models.TransactionDashboardResponse)
 
-	// Iterate through transactions to group data and calculate monthly income/expense
-	for _, txn := range *transactions {
-		rhName := txn.RoomingHouse.Name
+	for _, txn := range transactions {
+		key := groupBy(txn)
 
-		// Initialize DashboardData if not exists
-		if _, exists := groupedData[rhName]; !exists {
+		// Initialize the months if not exists
+		if _, exists := totals[key]; !exists {
 			transactionData := make([]models.TransactionDashboardResponse, 12)
 			for i := 0; i < 12; i++ {
 				transactionData[i] = models.TransactionDashboardResponse{
-					Month:   constants.Months[i],
-					Year:    txn.Year, // Default year from first transaction
-					Index:   i + 1,
-					Income:  0,
-					Expense: 0,
+					Month: constants.Months[i],
+					Year:  txn.Year, // Default year from first transaction
+					Index: i + 1,
 				}
 			}
-			groupedData[rhName] = &models.DashboardData{
-				RoomingHouseName: rhName,
-				TransactionData:  transactionData,
-			}
+			totals[key] = transactionData
 		}
 
-		// Find and update the month in TransactionData
-		for i := range groupedData[rhName].TransactionData {
-			if groupedData[rhName].TransactionData[i].Index == txn.Month {
-				if txn.Category.IsExpense {
-					groupedData[rhName].TransactionData[i].Expense += txn.Amount
-				} else {
-					groupedData[rhName].TransactionData[i].Income += txn.Amount
-				}
+		if txn.Month < 1 || txn.Month > 12 {
+			continue
+		}
+
+		if txn.Category.IsExpense {
+			totals[key][txn.Month-1].Expense += txn.Amount
+		} else {
+			totals[key][txn.Month-1].Income += txn.Amount
+		}
+	}
+
+	return totals
+}
+
+// ProfitLoss reports the income, expense and net of each rooming house for a
+// year, or a single month with the month query param. For owners each house is
+// also split by the ownership shares of its co-owners.
+func (tc *TransactionController) ProfitLoss(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	year := time.Now().Year()
+	if yearParam := c.QueryParam("year"); yearParam != "" {
+		parsedYear, err := strconv.Atoi(yearParam)
+		if err != nil {
+			return utils.HandlerError(c, utils.NewBadRequestError("invalid year"))
+		}
+		year = parsedYear
+	}
+
+	month := 0
+	if monthParam := c.QueryParam("month"); monthParam != "" {
+		parsedMonth, err := strconv.Atoi(monthParam)
+		if err != nil || parsedMonth < 1 || parsedMonth > 12 {
+			return utils.HandlerError(c, utils.NewBadRequestError("month must be between 1 and 12"))
+		}
+		month = parsedMonth
+	}
+
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(tc.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	reports := []models.ProfitLossResponse{}
+	if len(roomingHouseIDs) == 0 {
+		return c.JSON(http.StatusOK, reports)
+	}
+
+	roomingHouses, err := tc.roomingHouseRepo.FindAllRoomingHouse(userPayload.UserID, userPayload.Role)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to find rooming houses"))
+	}
+
+	reportIndex := map[uuid.UUID]int{}
+	for _, roomingHouse := range roomingHouses {
+		for _, roomingHouseID := range roomingHouseIDs {
+			if roomingHouse.ID == roomingHouseID {
+				reportIndex[roomingHouse.ID] = len(reports)
+				reports = append(reports, models.ProfitLossResponse{
+					RoomingHouseID:   roomingHouse.ID,
+					RoomingHouseName: roomingHouse.Name,
+					Year:             year,
+					Month:            month,
+				})
 				break
 			}
 		}
 	}
 
-	// Convert grouped data into final response slice
-	finalResponse := []models.DashboardData{}
-	for _, data := range groupedData {
-		finalResponse = append(finalResponse, *data)
+	transactions, err := tc.transactionRepo.FindAllTransactions(roomingHouseIDs, year)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to find transactions"))
 	}
 
-	return c.JSON(http.StatusOK, finalResponse)
+	sumProfitLoss(reports, reportIndex, *transactions, month)
+
+	// Ownership shares are the owners' business only
+	if isOwner(userPayload) {
+		owners, err := tc.roomingHouseOwnerRepo.FindRoomingHouseOwners(roomingHouseIDs)
+		if err != nil {
+			return utils.HandlerError(c, utils.NewInternalError("failed to get rooming house owners"))
+		}
+
+		for _, owner := range *owners {
+			index, ok := reportIndex[owner.RoomingHouseID]
+			if !ok {
+				continue
+			}
+
+			ratio := owner.Percentage / constants.OwnershipTotalPercentage
+			reports[index].Shares = append(reports[index].Shares, models.ProfitLossShareResponse{
+				OwnerID:    owner.OwnerID,
+				FullName:   owner.FullName,
+				Percentage: owner.Percentage,
+				Income:     reports[index].Income * ratio,
+				Expense:    reports[index].Expense * ratio,
+				Net:        reports[index].Net * ratio,
+			})
+		}
+	}
+
+	return c.JSON(http.StatusOK, reports)
+}
+
+// sumProfitLoss adds the transactions of the month, or the whole year when
+// month is 0, to the report of their rooming house. Deposits are held for the
+// tenant and are left out, as in SumTransactionsByCategory.
+func sumProfitLoss(reports []models.ProfitLossResponse, reportIndex map[uuid.UUID]int, transactions []models.TransactionResponse, month int) {
+	for _, transaction := range transactions {
+		index, ok := reportIndex[transaction.RoomingHouse.ID]
+		if !ok || (month != 0 && transaction.Month != month) || slices.Contains(constants.DepositTransactionCategoryCodes, transaction.CategoryCode) {
+			continue
+		}
+
+		if transaction.Category.IsExpense {
+			reports[index].Expense += transaction.Amount
+		} else {
+			reports[index].Income += transaction.Amount
+		}
+	}
+
+	for i := range reports {
+		reports[i].Net = reports[i].Income - reports[i].Expense
+	}
 }
@@ -7,18 +7,21 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 type TransactionCategoryController struct {
 	transactionCategoryRepo repositories.TransactionCategoryRepository
+	roomingHouseRepo        repositories.RoomingHouseRepository
 }
 
-func NewTransactionCategoryController(transactionCategoryRepo repositories.TransactionCategoryRepository) *TransactionCategoryController {
-	return &TransactionCategoryController{transactionCategoryRepo: transactionCategoryRepo}
+func NewTransactionCategoryController(transactionCategoryRepo repositories.TransactionCategoryRepository, roomingHouseRepo repositories.RoomingHouseRepository) *TransactionCategoryController {
+	return &TransactionCategoryController{transactionCategoryRepo: transactionCategoryRepo, roomingHouseRepo: roomingHouseRepo}
 }
 
 func (tcc *TransactionCategoryController) CreateTransactionCategory(c echo.Context) error {
-	var transactionCategoryBody models.TransactionCategoryBody
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var transactionCategoryBody models.AddTransactionCategoryBody
 
 	if err := c.Bind(&transactionCategoryBody); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
@@ -28,12 +31,39 @@ func (tcc *TransactionCategoryController) CreateTransactionCategory(c echo.Conte
 		return utils.HandlerError(c, utils.NewBadRequestError("name is required"))
 	}
 
+	// Only owners keep categories shared by all of their rooming houses
+	if transactionCategoryBody.RoomingHouseID != nil || !isOwner(userPayload) {
+		requestedRoomingHouseID := uuid.Nil
+		if transactionCategoryBody.RoomingHouseID != nil {
+			requestedRoomingHouseID = *transactionCategoryBody.RoomingHouseID
+		}
+
+		roomingHouseID, apiErr := targetRoomingHouseID(userPayload, requestedRoomingHouseID)
+		if apiErr != nil {
+			return utils.HandlerError(c, apiErr)
+		}
+		transactionCategoryBody.RoomingHouseID = &roomingHouseID
+	}
+
+	var ownerID uuid.UUID
+	var err error
+	if transactionCategoryBody.RoomingHouseID != nil {
+		ownerID, err = resolveOwnerID(tcc.roomingHouseRepo, userPayload, *transactionCategoryBody.RoomingHouseID)
+	} else {
+		ownerID, err = requestOwnerID(tcc.roomingHouseRepo, c, userPayload)
+	}
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
 	newTransactionCategory := models.TransactionCategory{
-		Name:      transactionCategoryBody.Name,
-		IsExpense: transactionCategoryBody.IsExpense,
+		Name:           transactionCategoryBody.Name,
+		IsExpense:      transactionCategoryBody.IsExpense,
+		OwnerID:        &ownerID,
+		RoomingHouseID: transactionCategoryBody.RoomingHouseID,
 	}
 
-	if err := tcc.transactionCategoryRepo.CreateTransactionCategory(&newTransactionCategory); err != nil {
+	if err := tcc.transactionCategoryRepo.CreateTransactionCategory(c.Request().Context(), &newTransactionCategory); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("failed to create transaction category"))
 	}
 
@@ -41,6 +71,7 @@ func (tcc *TransactionCategoryController) CreateTransactionCategory(c echo.Conte
 }
 
 func (tcc *TransactionCategoryController) FindTransactionCategoryByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
 	id := c.Param("id")
 
 	parsedID, err := uuid.Parse(id)
@@ -53,11 +84,34 @@ func (tcc *TransactionCategoryController) FindTransactionCategoryByID(c echo.Con
 		return utils.HandlerError(c, utils.NewNotFoundError("transaction category not found"))
 	}
 
+	if transactionCategory.OwnerID != nil {
+		ownerID, err := tcc.transactionCategoryOwnerID(c, userPayload, transactionCategory)
+		if err != nil || *transactionCategory.OwnerID != ownerID {
+			return utils.HandlerError(c, utils.NewNotFoundError("transaction category not found"))
+		}
+	}
+
+	if transactionCategory.RoomingHouseID != nil && !canAccessRoomingHouse(tcc.roomingHouseRepo, userPayload, *transactionCategory.RoomingHouseID) {
+		return utils.HandlerError(c, utils.NewNotFoundError("transaction category not found"))
+	}
+
 	return c.JSON(200, transactionCategory)
 }
 
 func (tcc *TransactionCategoryController) FindAllTransactionCategories(c echo.Context) error {
-	transactionCategories, err := tcc.transactionCategoryRepo.FindAllTransactionCategories()
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	ownerID, err := requestOwnerID(tcc.roomingHouseRepo, c, userPayload)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(tcc.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	transactionCategories, err := tcc.transactionCategoryRepo.FindAllTransactionCategories(ownerID, roomingHouseIDs)
 	if err != nil {
 		return utils.HandlerError(c, utils.NewNotFoundError("transaction categories not found"))
 	}
@@ -66,8 +120,8 @@ func (tcc *TransactionCategoryController) FindAllTransactionCategories(c echo.Co
 }
 
 func (tcc *TransactionCategoryController) UpdateTransactionCategoryByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
 	var transactionCategoryBody models.TransactionCategoryBody
-	id := c.Param("id")
 
 	if err := c.Bind(&transactionCategoryBody); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
@@ -77,9 +131,21 @@ func (tcc *TransactionCategoryController) UpdateTransactionCategoryByID(c echo.C
 		return utils.HandlerError(c, utils.NewBadRequestError("name is required"))
 	}
 
-	parsedID, err := uuid.Parse(id)
-	if err != nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("invalid id"))
+	transactionCategory, apiErr := tcc.findEditableTransactionCategory(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	// Flipping the type would move recorded transactions between income and expense
+	if transactionCategory.IsExpense != transactionCategoryBody.IsExpense {
+		transactions, err := tcc.transactionCategoryRepo.CountTransactionsByCategoryID(transactionCategory.ID)
+		if err != nil {
+			return utils.HandlerError(c, utils.NewInternalError("failed to check transaction category usage"))
+		}
+
+		if transactions > 0 {
+			return utils.HandlerError(c, utils.NewConflictError("is expense cannot be changed while the category has transactions"))
+		}
 	}
 
 	newTransactionCategory := models.TransactionCategory{
@@ -87,7 +153,7 @@ func (tcc *TransactionCategoryController) UpdateTransactionCategoryByID(c echo.C
 		IsExpense: transactionCategoryBody.IsExpense,
 	}
 
-	if err := tcc.transactionCategoryRepo.UpdateTransactionCategoryByID(&newTransactionCategory, parsedID); err != nil {
+	if err := tcc.transactionCategoryRepo.UpdateTransactionCategoryByID(c.Request().Context(), &newTransactionCategory, transactionCategory.ID); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("failed to update transaction category"))
 	}
 
@@ -95,16 +161,87 @@ func (tcc *TransactionCategoryController) UpdateTransactionCategoryByID(c echo.C
 }
 
 func (tcc *TransactionCategoryController) DeleteTransactionCategoryByID(c echo.Context) error {
-	id := c.Param("id")
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
 
-	parsedID, err := uuid.Parse(id)
+	transactionCategory, apiErr := tcc.findEditableTransactionCategory(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	transactions, err := tcc.transactionCategoryRepo.CountTransactionsByCategoryID(transactionCategory.ID)
 	if err != nil {
-		return utils.HandlerError(c, utils.NewBadRequestError("invalid id"))
+		return utils.HandlerError(c, utils.NewInternalError("failed to check transaction category usage"))
+	}
+
+	if transactions > 0 {
+		return utils.HandlerError(c, utils.NewConflictError("transaction category is still used by transactions"))
 	}
 
-	if err := tcc.transactionCategoryRepo.DeleteTransactionCategoryByID(parsedID); err != nil {
+	if err := tcc.transactionCategoryRepo.DeleteTransactionCategoryByID(c.Request().Context(), transactionCategory.ID); err != nil {
 		return utils.HandlerError(c, utils.NewBadRequestError("failed to delete transaction category"))
 	}
 
 	return c.NoContent(204)
 }
+
+// findEditableTransactionCategory loads a custom category the user may change.
+// System and global categories are read-only, and admins may only change the
+// categories of their own rooming house.
+func (tcc *TransactionCategoryController) findEditableTransactionCategory(c echo.Context, userPayload *models.JWTPayload) (*models.TransactionCategory, *utils.APIError) {
+	transactionCategoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, utils.NewBadRequestError("invalid id")
+	}
+
+	transactionCategory, err := tcc.transactionCategoryRepo.FindTransactionCategoryByID(transactionCategoryID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.NewNotFoundError("transaction category not found")
+		}
+		return nil, utils.NewInternalError("failed to get transaction category")
+	}
+
+	if transactionCategory.Code != "" {
+		return nil, utils.NewForbiddenError("system transaction categories cannot be changed")
+	}
+
+	if transactionCategory.OwnerID == nil {
+		return nil, utils.NewForbiddenError("global transaction categories cannot be changed")
+	}
+
+	ownerID, err := tcc.transactionCategoryOwnerID(c, userPayload, transactionCategory)
+	if err != nil || *transactionCategory.OwnerID != ownerID {
+		return nil, utils.NewNotFoundError("transaction category not found")
+	}
+
+	if transactionCategory.RoomingHouseID == nil && !isOwner(userPayload) || transactionCategory.RoomingHouseID != nil && !canAccessRoomingHouse(tcc.roomingHouseRepo, userPayload, *transactionCategory.RoomingHouseID) {
+		return nil, utils.NewForbiddenError("only the owner can change this transaction category")
+	}
+
+	return transactionCategory, nil
+}
+
+// transactionCategoryOwnerID resolves the owner the category must belong to:
+// the owner of its rooming house, or of the requested rooming house for
+// owner-wide categories.
+func (tcc *TransactionCategoryController) transactionCategoryOwnerID(c echo.Context, userPayload *models.JWTPayload, transactionCategory *models.TransactionCategory) (uuid.UUID, error) {
+	if transactionCategory.RoomingHouseID != nil {
+		return resolveOwnerID(tcc.roomingHouseRepo, userPayload, *transactionCategory.RoomingHouseID)
+	}
+
+	return requestOwnerID(tcc.roomingHouseRepo, c, userPayload)
+}
+
+// transactionCategoryAvailableToRoomingHouse reports whether transactions of
+// the rooming house may be booked under the category.
+func transactionCategoryAvailableToRoomingHouse(transactionCategory *models.TransactionCategory, roomingHouse *models.RoomingHouseByIDResponse) bool {
+	if transactionCategory.OwnerID == nil {
+		return true
+	}
+
+	if *transactionCategory.OwnerID != roomingHouse.OwnerID {
+		return false
+	}
+
+	return transactionCategory.RoomingHouseID == nil || *transactionCategory.RoomingHouseID == roomingHouse.ID
+}
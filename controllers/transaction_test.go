@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSumProfitLoss(t *testing.T) {
+	roomingHouse := models.TenantRoomingHouseResponse{ID: uuid.New(), Name: "Kos Melati"}
+	otherRoomingHouse := models.TenantRoomingHouseResponse{ID: uuid.New(), Name: "Kos Mawar"}
+
+	transaction := func(month int, amount float64, isExpense bool, code string) models.TransactionResponse {
+		return models.TransactionResponse{
+			Month:        month,
+			Year:         2026,
+			Amount:       amount,
+			RoomingHouse: roomingHouse,
+			Category:     models.TransactionCategoryBody{IsExpense: isExpense},
+			CategoryCode: code,
+		}
+	}
+
+	transactions := []models.TransactionResponse{
+		transaction(1, 3000000, false, constants.TransactionCategoryCodeRent),
+		transaction(1, 1500000, false, constants.TransactionCategoryCodeDeposit),
+		transaction(1, 400000, true, ""),
+		transaction(2, 3000000, false, constants.TransactionCategoryCodeRent),
+		transaction(2, 1500000, true, constants.TransactionCategoryCodeDepositPayback),
+		{Month: 1, Amount: 999, RoomingHouse: otherRoomingHouse},
+	}
+
+	tests := []struct {
+		name        string
+		month       int
+		wantIncome  float64
+		wantExpense float64
+		wantNet     float64
+	}{
+		{name: "deposit is not income", month: 1, wantIncome: 3000000, wantExpense: 400000, wantNet: 2600000},
+		{name: "deposit payback is not expense", month: 2, wantIncome: 3000000, wantNet: 3000000},
+		{name: "whole year", month: 0, wantIncome: 6000000, wantExpense: 400000, wantNet: 5600000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reports := []models.ProfitLossResponse{{RoomingHouseID: roomingHouse.ID}}
+			sumProfitLoss(reports, map[uuid.UUID]int{roomingHouse.ID: 0}, transactions, tt.month)
+
+			report := reports[0]
+			if report.Income != tt.wantIncome || report.Expense != tt.wantExpense || report.Net != tt.wantNet {
+				t.Errorf("income, expense, net = %v, %v, %v; want %v, %v, %v", report.Income, report.Expense, report.Net, tt.wantIncome, tt.wantExpense, tt.wantNet)
+			}
+		})
+	}
+}
@@ -0,0 +1,330 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (uc *UserController) GetTwoFactorStatus(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	if apiErr := requireTwoFactorRole(userPayload); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	status := models.TwoFactorStatusResponse{}
+
+	twoFactor, err := uc.twoFactorRepo.FindTwoFactorByUser(userPayload.UserID, userPayload.Role)
+	if err == nil && twoFactor.EnabledAt != nil {
+		remaining, err := uc.twoFactorRepo.CountUnusedRecoveryCodes(twoFactor.ID)
+		if err != nil {
+			return utils.HandlerError(c, utils.NewInternalError("failed to count recovery codes"))
+		}
+
+		status.Enabled = true
+		status.EnabledAt = twoFactor.EnabledAt
+		status.RecoveryCodesRemaining = remaining
+	}
+
+	return c.JSON(http.StatusOK, status)
+}
+
+// SetupTwoFactor starts an enrollment and returns the secret and the
+// provisioning URI to render as a QR code. Two-factor stays off until the
+// first code is confirmed with EnableTwoFactor.
+func (uc *UserController) SetupTwoFactor(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	if apiErr := requireTwoFactorRole(userPayload); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if twoFactor, err := uc.twoFactorRepo.FindTwoFactorByUser(userPayload.UserID, userPayload.Role); err == nil && twoFactor.EnabledAt != nil {
+		return utils.HandlerError(c, utils.NewConflictError("two-factor authentication is already enabled"))
+	}
+
+	userCredential, err := uc.findCredentialByID(userPayload.Role, userPayload.UserID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewNotFoundError("user not found"))
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("could not generate secret"))
+	}
+
+	twoFactor := models.TwoFactor{
+		UserID: userPayload.UserID,
+		Role:   userPayload.Role,
+		Secret: secret,
+	}
+
+	if err := uc.twoFactorRepo.CreateTwoFactor(c.Request().Context(), &twoFactor); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to start two-factor setup"))
+	}
+
+	return c.JSON(http.StatusOK, models.TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(userCredential.Email, secret),
+	})
+}
+
+// EnableTwoFactor confirms the pending enrollment with a code from the
+// authenticator app and returns the recovery codes. They are only shown once.
+func (uc *UserController) EnableTwoFactor(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var codeBody models.TwoFactorCodeBody
+
+	if err := c.Bind(&codeBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if apiErr := requireTwoFactorRole(userPayload); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if codeBody.Code == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("code is required"))
+	}
+
+	twoFactor, err := uc.twoFactorRepo.FindTwoFactorByUser(userPayload.UserID, userPayload.Role)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("start two-factor setup first"))
+	}
+
+	if twoFactor.EnabledAt != nil {
+		return utils.HandlerError(c, utils.NewConflictError("two-factor authentication is already enabled"))
+	}
+
+	step, ok := utils.ValidateTOTP(twoFactor.Secret, codeBody.Code, time.Now())
+	if !ok {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid code"))
+	}
+
+	recoveryCodes, codeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("could not generate recovery codes"))
+	}
+
+	if err := uc.twoFactorRepo.EnableTwoFactor(c.Request().Context(), twoFactor.ID, step, codeHashes); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to enable two-factor authentication"))
+	}
+
+	return c.JSON(http.StatusOK, models.RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
+
+// RegenerateRecoveryCodes replaces every remaining recovery code with a new set.
+func (uc *UserController) RegenerateRecoveryCodes(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var codeBody models.TwoFactorCodeBody
+
+	if err := c.Bind(&codeBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if apiErr := requireTwoFactorRole(userPayload); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	twoFactor, err := uc.twoFactorRepo.FindTwoFactorByUser(userPayload.UserID, userPayload.Role)
+	if err != nil || twoFactor.EnabledAt == nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("two-factor authentication is not enabled"))
+	}
+
+	if !uc.verifySecondFactor(c.Request().Context(), twoFactor, codeBody.Code, "") {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid code"))
+	}
+
+	recoveryCodes, codeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("could not generate recovery codes"))
+	}
+
+	if err := uc.twoFactorRepo.ReplaceRecoveryCodes(c.Request().Context(), twoFactor.ID, codeHashes); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to regenerate recovery codes"))
+	}
+
+	return c.JSON(http.StatusOK, models.RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
+
+// DisableTwoFactor turns two-factor off. It asks for the password and a
+// second factor so a stolen access token alone cannot remove it.
+func (uc *UserController) DisableTwoFactor(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var disableBody models.DisableTwoFactorBody
+
+	if err := c.Bind(&disableBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if apiErr := requireTwoFactorRole(userPayload); apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if disableBody.Password == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("password is required"))
+	}
+
+	twoFactor, err := uc.twoFactorRepo.FindTwoFactorByUser(userPayload.UserID, userPayload.Role)
+	if err != nil || twoFactor.EnabledAt == nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("two-factor authentication is not enabled"))
+	}
+
+	userCredential, err := uc.findCredentialByID(userPayload.Role, userPayload.UserID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewNotFoundError("user not found"))
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(userCredential.PasswordHash), []byte(disableBody.Password)); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("password is incorrect"))
+	}
+
+	if !uc.verifySecondFactor(c.Request().Context(), twoFactor, disableBody.Code, disableBody.RecoveryCode) {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid code"))
+	}
+
+	if err := uc.twoFactorRepo.DeleteTwoFactor(c.Request().Context(), twoFactor.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to disable two-factor authentication"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "two-factor authentication disabled"})
+}
+
+// VerifyLogin completes a login started by Login for an account with
+// two-factor enabled, exchanging the challenge token and a TOTP or recovery
+// code for the session tokens.
+func (uc *UserController) VerifyLogin(c echo.Context) error {
+	var verifyBody models.VerifyLoginBody
+
+	if err := c.Bind(&verifyBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if verifyBody.ChallengeToken == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("challenge token is required"))
+	}
+
+	if verifyBody.Code == "" && verifyBody.RecoveryCode == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("code or recovery code is required"))
+	}
+
+	challenge, err := uc.challengeRepo.FindLoginChallengeByTokenHash(utils.HashToken(verifyBody.ChallengeToken))
+	if err != nil || challenge.UsedAt != nil || time.Now().After(challenge.ExpiresAt) || challenge.Attempts >= constants.LoginChallengeMaxAttempts {
+		return utils.HandlerError(c, utils.NewUnauthorizedError("login has expired, please login again"))
+	}
+
+	twoFactor, err := uc.twoFactorRepo.FindTwoFactorByUser(challenge.UserID, challenge.Role)
+	if err != nil || twoFactor.EnabledAt == nil {
+		return utils.HandlerError(c, utils.NewUnauthorizedError("login has expired, please login again"))
+	}
+
+	attempt := models.LoginAttempt{
+		Email:     challenge.Email,
+		Role:      challenge.Role,
+		UserID:    &challenge.UserID,
+		IPAddress: c.RealIP(),
+		UserAgent: c.Request().UserAgent(),
+	}
+
+	if !uc.verifySecondFactor(c.Request().Context(), twoFactor, verifyBody.Code, verifyBody.RecoveryCode) {
+		if err := uc.challengeRepo.IncrementLoginChallengeAttempts(challenge.ID); err != nil {
+			return utils.HandlerError(c, utils.NewInternalError("failed to verify code"))
+		}
+
+		attempt.FailureReason = constants.LoginFailureInvalidCode
+		uc.loginAttemptRepo.CreateLoginAttempt(&attempt)
+
+		return utils.HandlerError(c, utils.NewUnauthorizedError("invalid code"))
+	}
+
+	// Claim the challenge before issuing tokens so it cannot be completed twice
+	if err := uc.challengeRepo.UseLoginChallenge(challenge.ID); err != nil {
+		return utils.HandlerError(c, utils.NewUnauthorizedError("login has expired, please login again"))
+	}
+
+	tokens, err := uc.createSession(challenge.UserID, challenge.Role, challenge.RoomingHouseID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("could not create session"))
+	}
+
+	attempt.Success = true
+	uc.loginAttemptRepo.CreateLoginAttempt(&attempt)
+
+	return c.JSON(http.StatusOK, tokens)
+}
+
+// createLoginChallenge stores the second login step of a user whose password
+// was accepted and returns its token.
+func (uc *UserController) createLoginChallenge(email string, userID uuid.UUID, role string, roomingHouseID uuid.UUID) (*models.LoginChallengeResponse, error) {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := models.LoginChallenge{
+		Email:          email,
+		UserID:         userID,
+		Role:           role,
+		RoomingHouseID: roomingHouseID,
+		TokenHash:      utils.HashToken(token),
+		ExpiresAt:      time.Now().Add(constants.LoginChallengeTTL),
+	}
+
+	if err := uc.challengeRepo.CreateLoginChallenge(&challenge); err != nil {
+		return nil, err
+	}
+
+	return &models.LoginChallengeResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    token,
+		ExpiresIn:         int64(constants.LoginChallengeTTL.Seconds()),
+	}, nil
+}
+
+// verifySecondFactor accepts either a TOTP code that was not used before or
+// an unused recovery code, consuming it.
+func (uc *UserController) verifySecondFactor(ctx context.Context, twoFactor *models.TwoFactor, code string, recoveryCode string) bool {
+	if code != "" {
+		step, ok := utils.ValidateTOTP(twoFactor.Secret, code, time.Now())
+		return ok && uc.twoFactorRepo.UseTwoFactorStep(ctx, twoFactor.ID, step) == nil
+	}
+
+	if recoveryCode != "" {
+		codeHash := utils.HashToken(utils.NormalizeRecoveryCode(recoveryCode))
+		return uc.twoFactorRepo.UseRecoveryCode(ctx, twoFactor.ID, codeHash) == nil
+	}
+
+	return false
+}
+
+// generateRecoveryCodes returns a new set of recovery codes and their hashes.
+func generateRecoveryCodes() ([]string, []string, error) {
+	recoveryCodes := make([]string, 0, constants.RecoveryCodeCount)
+	codeHashes := make([]string, 0, constants.RecoveryCodeCount)
+
+	for i := 0; i < constants.RecoveryCodeCount; i++ {
+		recoveryCode, err := utils.GenerateRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+		recoveryCodes = append(recoveryCodes, recoveryCode)
+		codeHashes = append(codeHashes, utils.HashToken(utils.NormalizeRecoveryCode(recoveryCode)))
+	}
+
+	return recoveryCodes, codeHashes, nil
+}
+
+func requireTwoFactorRole(userPayload *models.JWTPayload) *utils.APIError {
+	if userPayload.Role != "owner" && userPayload.Role != "admin" {
+		return utils.NewForbiddenError("two-factor authentication is only available for owners and admins")
+	}
+	return nil
+}
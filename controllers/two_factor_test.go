@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// fakeTwoFactorRepository keeps the last used step and the unused recovery
+// codes in memory, with the same conditions as the database queries.
+type fakeTwoFactorRepository struct {
+	repositories.TwoFactorRepository
+	lastUsedStep  int64
+	recoveryCodes map[string]bool
+}
+
+func (r *fakeTwoFactorRepository) UseTwoFactorStep(_ context.Context, _ uuid.UUID, step int64) error {
+	if r.lastUsedStep >= step {
+		return gorm.ErrRecordNotFound
+	}
+	r.lastUsedStep = step
+	return nil
+}
+
+func (r *fakeTwoFactorRepository) UseRecoveryCode(_ context.Context, _ uuid.UUID, codeHash string) error {
+	if !r.recoveryCodes[codeHash] {
+		return gorm.ErrRecordNotFound
+	}
+	delete(r.recoveryCodes, codeHash)
+	return nil
+}
+
+// testTOTPCode computes the RFC 6238 code of a time step.
+func testTOTPCode(t *testing.T, secret string, step int64) string {
+	t.Helper()
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatalf("decode secret: %v", err)
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000)
+}
+
+func TestVerifySecondFactorRejectsReplayedSteps(t *testing.T) {
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret: %v", err)
+	}
+
+	current := time.Now().Unix() / int64(constants.TOTPPeriod.Seconds())
+
+	type attempt struct {
+		step int64
+		want bool
+	}
+
+	tests := []struct {
+		name     string
+		attempts []attempt
+	}{
+		{
+			name:     "same code twice",
+			attempts: []attempt{{step: current, want: true}, {step: current}},
+		},
+		{
+			name:     "earlier step after a later one",
+			attempts: []attempt{{step: current, want: true}, {step: current - 1}},
+		},
+		{
+			name:     "later step after an earlier one",
+			attempts: []attempt{{step: current - 1, want: true}, {step: current, want: true}, {step: current + 1, want: true}},
+		},
+		{
+			name:     "step outside the skew",
+			attempts: []attempt{{step: current - constants.TOTPSkew - 2}, {step: current, want: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeTwoFactorRepository{}
+			uc := &UserController{twoFactorRepo: repo}
+			twoFactor := &models.TwoFactor{Secret: secret}
+
+			for i, a := range tt.attempts {
+				code := testTOTPCode(t, secret, a.step)
+				if got := uc.verifySecondFactor(context.Background(), twoFactor, code, ""); got != a.want {
+					t.Fatalf("attempt %d for step %d = %v, want %v", i, a.step-current, got, a.want)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifySecondFactorRecoveryCodesAreSingleUse(t *testing.T) {
+	recoveryCode := "abcde-12345"
+	repo := &fakeTwoFactorRepository{recoveryCodes: map[string]bool{
+		utils.HashToken(utils.NormalizeRecoveryCode(recoveryCode)): true,
+	}}
+	uc := &UserController{twoFactorRepo: repo}
+	twoFactor := &models.TwoFactor{}
+
+	attempts := []struct {
+		code string
+		want bool
+	}{
+		{code: " ABCDE 12345 ", want: true},
+		{code: recoveryCode},
+		{code: "zzzzz-99999"},
+	}
+
+	for i, a := range attempts {
+		if got := uc.verifySecondFactor(context.Background(), twoFactor, "", a.code); got != a.want {
+			t.Fatalf("attempt %d = %v, want %v", i, got, a.want)
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package controllers
+
+import (
+	"net/http"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type WorkOrderController struct {
+	workOrderRepo            repositories.WorkOrderRepository
+	roomingHouseRepo         repositories.RoomingHouseRepository
+	roomRepo                 repositories.RoomRepository
+	roomingHouseFacilityRepo repositories.RoomingHouseFacilityRepository
+	transactionCategoryRepo  repositories.TransactionCategoryRepository
+	tenantRepo               repositories.TenantRepository
+	maintenanceScheduleRepo  repositories.MaintenanceScheduleRepository
+}
+
+func NewWorkOrderController(workOrderRepo repositories.WorkOrderRepository, roomingHouseRepo repositories.RoomingHouseRepository, roomRepo repositories.RoomRepository, roomingHouseFacilityRepo repositories.RoomingHouseFacilityRepository, transactionCategoryRepo repositories.TransactionCategoryRepository, tenantRepo repositories.TenantRepository, maintenanceScheduleRepo repositories.MaintenanceScheduleRepository) *WorkOrderController {
+	return &WorkOrderController{workOrderRepo: workOrderRepo, roomingHouseRepo: roomingHouseRepo, roomRepo: roomRepo, roomingHouseFacilityRepo: roomingHouseFacilityRepo, transactionCategoryRepo: transactionCategoryRepo, tenantRepo: tenantRepo, maintenanceScheduleRepo: maintenanceScheduleRepo}
+}
+
+func (woc *WorkOrderController) CreateWorkOrder(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var workOrderBody models.AddWorkOrderBody
+
+	if err := c.Bind(&workOrderBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if workOrderBody.Title == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("title is required"))
+	}
+
+	if workOrderBody.Priority == "" {
+		workOrderBody.Priority = constants.WorkOrderPriorityMedium
+	}
+
+	if !constants.WorkOrderPriorities[workOrderBody.Priority] {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid priority"))
+	}
+
+	if (workOrderBody.RoomID == nil) == (workOrderBody.RoomingHouseFacilityID == nil) {
+		return utils.HandlerError(c, utils.NewBadRequestError("either room id or rooming house facility id is required"))
+	}
+
+	roomingHouseID, apiErr := targetRoomingHouseID(userPayload, workOrderBody.RoomingHouseID)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if _, err := woc.roomingHouseRepo.FindRoomingHouseByID(roomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs()); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+	}
+
+	if workOrderBody.RoomID != nil {
+		room, err := woc.roomRepo.FindRoomByID(*workOrderBody.RoomID, []uuid.UUID{roomingHouseID}, userPayload.UserID, userPayload.Role)
+		if err != nil || room.RoomingHouseID != roomingHouseID {
+			return utils.HandlerError(c, utils.NewBadRequestError("room not found"))
+		}
+	}
+
+	if workOrderBody.RoomingHouseFacilityID != nil {
+		roomingHouseFacility, err := woc.roomingHouseFacilityRepo.FindRoomingHouseFacilityByID(*workOrderBody.RoomingHouseFacilityID)
+		if err != nil || roomingHouseFacility.RoomingHouseID != roomingHouseID {
+			return utils.HandlerError(c, utils.NewBadRequestError("rooming house facility not found"))
+		}
+	}
+
+	newWorkOrder := models.WorkOrder{
+		Title:                  workOrderBody.Title,
+		Description:            workOrderBody.Description,
+		Status:                 constants.WorkOrderStatusOpen,
+		Priority:               workOrderBody.Priority,
+		RoomingHouseID:         roomingHouseID,
+		RoomID:                 workOrderBody.RoomID,
+		RoomingHouseFacilityID: workOrderBody.RoomingHouseFacilityID,
+		ReportedBy:             userPayload.UserID,
+		ReporterRole:           userPayload.Role,
+	}
+
+	if err := woc.workOrderRepo.CreateWorkOrder(c.Request().Context(), &newWorkOrder); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to create work order"))
+	}
+
+	return c.JSON(http.StatusCreated, newWorkOrder)
+}
+
+func (woc *WorkOrderController) ReportWorkOrder(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var workOrderBody models.AddWorkOrderBody
+
+	if err := c.Bind(&workOrderBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if workOrderBody.Title == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("title is required"))
+	}
+
+	tenant, err := woc.tenantRepo.FindTenantByID(userPayload.UserID, []uuid.UUID{userPayload.RoomingHouseID})
+	if err != nil || !tenant.IsTenant {
+		return utils.HandlerError(c, utils.NewNotFoundError("tenant not found"))
+	}
+
+	newWorkOrder := models.WorkOrder{
+		Title:          workOrderBody.Title,
+		Description:    workOrderBody.Description,
+		Status:         constants.WorkOrderStatusOpen,
+		Priority:       constants.WorkOrderPriorityMedium,
+		RoomingHouseID: userPayload.RoomingHouseID,
+		ReportedBy:     userPayload.UserID,
+		ReporterRole:   userPayload.Role,
+	}
+
+	// Tenants may report shared facility issues; otherwise the issue is in their own room
+	if workOrderBody.RoomingHouseFacilityID != nil {
+		roomingHouseFacility, err := woc.roomingHouseFacilityRepo.FindRoomingHouseFacilityByID(*workOrderBody.RoomingHouseFacilityID)
+		if err != nil || roomingHouseFacility.RoomingHouseID != userPayload.RoomingHouseID {
+			return utils.HandlerError(c, utils.NewBadRequestError("rooming house facility not found"))
+		}
+		newWorkOrder.RoomingHouseFacilityID = workOrderBody.RoomingHouseFacilityID
+	} else {
+		newWorkOrder.RoomID = &tenant.BookedRoomID
+	}
+
+	if err := woc.workOrderRepo.CreateWorkOrder(c.Request().Context(), &newWorkOrder); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to create work order"))
+	}
+
+	return c.JSON(http.StatusCreated, newWorkOrder)
+}
+
+func (woc *WorkOrderController) FindAllWorkOrders(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	status := c.QueryParam("status")
+	roomingHouseIDs, apiErr := accessibleRoomingHouseIDs(woc.roomingHouseRepo, c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if len(roomingHouseIDs) == 0 {
+		return c.JSON(http.StatusOK, []models.WorkOrderResponse{})
+	}
+
+	workOrders, err := woc.workOrderRepo.FindAllWorkOrders(roomingHouseIDs, status, uuid.Nil)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get work orders"))
+	}
+
+	return c.JSON(http.StatusOK, workOrders)
+}
+
+func (woc *WorkOrderController) FindTenantWorkOrders(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	workOrders, err := woc.workOrderRepo.FindAllWorkOrders([]uuid.UUID{userPayload.RoomingHouseID}, c.QueryParam("status"), userPayload.UserID)
+	if err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to get work orders"))
+	}
+
+	return c.JSON(http.StatusOK, workOrders)
+}
+
+func (woc *WorkOrderController) FindWorkOrderByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	workOrder, apiErr := woc.findAccessibleWorkOrder(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	return c.JSON(http.StatusOK, workOrder)
+}
+
+func (woc *WorkOrderController) UpdateWorkOrderByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var workOrderBody models.UpdateWorkOrderBody
+
+	if err := c.Bind(&workOrderBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	if workOrderBody.Title == "" {
+		return utils.HandlerError(c, utils.NewBadRequestError("title is required"))
+	}
+
+	if !constants.WorkOrderPriorities[workOrderBody.Priority] {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid priority"))
+	}
+
+	workOrder, apiErr := woc.findAccessibleWorkOrder(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if workOrder.Status == constants.WorkOrderStatusDone {
+		return utils.HandlerError(c, utils.NewBadRequestError("work order is already done"))
+	}
+
+	updatedWorkOrder := models.WorkOrder{
+		Title:       workOrderBody.Title,
+		Description: workOrderBody.Description,
+		Priority:    workOrderBody.Priority,
+	}
+
+	if err := woc.workOrderRepo.UpdateWorkOrderByID(c.Request().Context(), &updatedWorkOrder, workOrder.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to update work order"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "work order updated"})
+}
+
+func (woc *WorkOrderController) UpdateWorkOrderStatus(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+	var statusBody models.UpdateWorkOrderStatusBody
+
+	if err := c.Bind(&statusBody); err != nil {
+		return utils.HandlerError(c, utils.NewBadRequestError("invalid input"))
+	}
+
+	workOrder, apiErr := woc.findAccessibleWorkOrder(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	allowed := false
+	for _, next := range constants.WorkOrderTransitions[workOrder.Status] {
+		if next == statusBody.Status {
+			allowed = true
+			break
+		}
+	}
+
+	if !allowed {
+		return utils.HandlerError(c, utils.NewBadRequestError("cannot change status from "+workOrder.Status+" to "+statusBody.Status))
+	}
+
+	now := time.Now()
+	updatedWorkOrder := models.WorkOrder{
+		BaseModel:  models.BaseModel{ID: workOrder.ID},
+		Status:     statusBody.Status,
+		Assignee:   workOrder.Assignee,
+		AssignedAt: workOrder.AssignedAt,
+		StartedAt:  workOrder.StartedAt,
+	}
+
+	switch statusBody.Status {
+	case constants.WorkOrderStatusOpen:
+		// Reopening hands the work order back, so nobody is assigned anymore
+		updatedWorkOrder.Assignee = ""
+		updatedWorkOrder.AssignedAt = nil
+		updatedWorkOrder.StartedAt = nil
+	case constants.WorkOrderStatusAssigned:
+		if statusBody.Assignee == "" {
+			return utils.HandlerError(c, utils.NewBadRequestError("assignee is required"))
+		}
+		updatedWorkOrder.Assignee = statusBody.Assignee
+		updatedWorkOrder.AssignedAt = &now
+		updatedWorkOrder.StartedAt = nil
+	case constants.WorkOrderStatusInProgress:
+		if statusBody.Assignee != "" {
+			updatedWorkOrder.Assignee = statusBody.Assignee
+		}
+		updatedWorkOrder.StartedAt = &now
+	case constants.WorkOrderStatusDone:
+		updatedWorkOrder.ResolutionNote = statusBody.ResolutionNote
+		updatedWorkOrder.Cost = statusBody.Cost
+		updatedWorkOrder.CompletedAt = &now
+
+		var expense *models.Transaction
+		if statusBody.CreateExpense {
+			if statusBody.Cost <= 0 {
+				return utils.HandlerError(c, utils.NewBadRequestError("cost is required to create an expense"))
+			}
+
+			if statusBody.TransactionCategoryID == nil {
+				return utils.HandlerError(c, utils.NewBadRequestError("transaction category id is required"))
+			}
+
+			if !userPayload.Can(workOrder.RoomingHouseID, constants.Permission(constants.ResourceTransactions, constants.ActionWrite)) {
+				return utils.HandlerError(c, utils.NewForbiddenError("you are not allowed to record transactions"))
+			}
+
+			roomingHouse, err := woc.roomingHouseRepo.FindRoomingHouseByID(workOrder.RoomingHouseID, userPayload.UserID, userPayload.Role, userPayload.ScopedRoomingHouseIDs())
+			if err != nil {
+				return utils.HandlerError(c, utils.NewBadRequestError("rooming house not found"))
+			}
+
+			transactionCategory, err := woc.transactionCategoryRepo.FindTransactionCategoryByID(*statusBody.TransactionCategoryID)
+			if err != nil || !transactionCategoryAvailableToRoomingHouse(transactionCategory, roomingHouse) {
+				return utils.HandlerError(c, utils.NewBadRequestError("transaction category not found"))
+			}
+
+			if !transactionCategory.IsExpense {
+				return utils.HandlerError(c, utils.NewBadRequestError("transaction category is not an expense"))
+			}
+
+			expense = &models.Transaction{
+				Day:                   now.Day(),
+				Month:                 int(now.Month()),
+				Year:                  now.Year(),
+				Amount:                statusBody.Cost,
+				Description:           "Work order: " + workOrder.Title,
+				IsRoom:                workOrder.RoomID != nil,
+				TransactionCategoryID: transactionCategory.ID,
+				RoomID:                workOrder.RoomID,
+				RoomingHouseID:        workOrder.RoomingHouseID,
+			}
+		}
+
+		if err := woc.workOrderRepo.CompleteWorkOrder(c.Request().Context(), &updatedWorkOrder, expense); err != nil {
+			return utils.HandlerError(c, utils.NewInternalError("failed to complete work order"))
+		}
+
+		// Work orders opened by the maintenance job move their schedule on to the next cycle
+		if workOrder.MaintenanceScheduleID != nil {
+			maintenanceSchedule, err := woc.maintenanceScheduleRepo.FindMaintenanceScheduleByID(*workOrder.MaintenanceScheduleID)
+			if err == nil && maintenanceSchedule.OpenWorkOrderID != nil && *maintenanceSchedule.OpenWorkOrderID == workOrder.ID {
+				if err := completeMaintenanceSchedule(c.Request().Context(), woc.maintenanceScheduleRepo, maintenanceSchedule, userPayload, statusBody.ResolutionNote, statusBody.Cost, &workOrder.ID); err != nil {
+					return utils.HandlerError(c, utils.NewInternalError("failed to complete maintenance schedule"))
+				}
+			}
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"message":        "work order completed",
+			"transaction_id": updatedWorkOrder.TransactionID,
+		})
+	}
+
+	if err := woc.workOrderRepo.UpdateWorkOrderStatus(c.Request().Context(), &updatedWorkOrder); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to update work order status"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "work order status updated"})
+}
+
+func (woc *WorkOrderController) DeleteWorkOrderByID(c echo.Context) error {
+	userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+	workOrder, apiErr := woc.findAccessibleWorkOrder(c, userPayload)
+	if apiErr != nil {
+		return utils.HandlerError(c, apiErr)
+	}
+
+	if err := woc.workOrderRepo.DeleteWorkOrderByID(c.Request().Context(), workOrder.ID); err != nil {
+		return utils.HandlerError(c, utils.NewInternalError("failed to delete work order"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "work order deleted"})
+}
+
+func (woc *WorkOrderController) findAccessibleWorkOrder(c echo.Context, userPayload *models.JWTPayload) (*models.WorkOrder, *utils.APIError) {
+	workOrderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, utils.NewBadRequestError("invalid work order id")
+	}
+
+	workOrder, err := woc.workOrderRepo.FindWorkOrderByID(workOrderID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.NewNotFoundError("work order not found")
+		}
+		return nil, utils.NewInternalError("failed to get work order")
+	}
+
+	if !canAccessRoomingHouse(woc.roomingHouseRepo, userPayload, workOrder.RoomingHouseID) {
+		return nil, utils.NewNotFoundError("work order not found")
+	}
+
+	return workOrder, nil
+}
@@ -0,0 +1,68 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StartMaintenanceScheduler runs in the background and opens a work order for
+// every maintenance schedule that has come due.
+func StartMaintenanceScheduler(db *gorm.DB) {
+	maintenanceScheduleRepo := repositories.NewMaintenanceScheduleRepository(db)
+	roomingHouseFacilityRepo := repositories.NewRoomingHouseFacilityRepository(db)
+
+	go func() {
+		ticker := time.NewTicker(constants.MaintenanceJobInterval)
+		defer ticker.Stop()
+
+		for {
+			generateMaintenanceWorkOrders(maintenanceScheduleRepo, roomingHouseFacilityRepo)
+			<-ticker.C
+		}
+	}()
+}
+
+func generateMaintenanceWorkOrders(maintenanceScheduleRepo repositories.MaintenanceScheduleRepository, roomingHouseFacilityRepo repositories.RoomingHouseFacilityRepository) {
+	maintenanceSchedules, err := maintenanceScheduleRepo.FindDueMaintenanceSchedules(time.Now())
+	if err != nil {
+		log.Printf("maintenance scheduler: failed to get due schedules: %v", err)
+		return
+	}
+
+	for i := range *maintenanceSchedules {
+		maintenanceSchedule := &(*maintenanceSchedules)[i]
+		scheduleID := maintenanceSchedule.ID
+
+		workOrder := models.WorkOrder{
+			Title:                 "Preventive maintenance: " + maintenanceSchedule.Title,
+			Description:           maintenanceSchedule.Description,
+			Status:                constants.WorkOrderStatusOpen,
+			Priority:              constants.WorkOrderPriorityMedium,
+			RoomingHouseID:        maintenanceSchedule.RoomingHouseID,
+			RoomID:                maintenanceSchedule.RoomID,
+			ReportedBy:            uuid.Nil,
+			ReporterRole:          constants.MaintenanceReporterRole,
+			MaintenanceScheduleID: &scheduleID,
+		}
+
+		if maintenanceSchedule.RoomID == nil {
+			roomingHouseFacility, err := roomingHouseFacilityRepo.FindRoomingHouseFacilityByFacilityID(maintenanceSchedule.RoomingHouseID, maintenanceSchedule.FacilityID)
+			if err != nil {
+				log.Printf("maintenance scheduler: facility of schedule %s not found: %v", maintenanceSchedule.ID, err)
+				continue
+			}
+			workOrder.RoomingHouseFacilityID = &roomingHouseFacility.ID
+		}
+
+		if err := maintenanceScheduleRepo.CreateScheduledWorkOrder(context.Background(), maintenanceSchedule, &workOrder); err != nil {
+			log.Printf("maintenance scheduler: failed to open work order for schedule %s: %v", maintenanceSchedule.ID, err)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"rooming-house-cms-be/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StartRecurringExpenseScheduler runs in the background, creates a draft for
+// every recurring expense that has come due and flags the drafts left
+// pending after their month ended.
+func StartRecurringExpenseScheduler(db *gorm.DB) {
+	recurringExpenseRepo := repositories.NewRecurringExpenseRepository(db)
+
+	go func() {
+		ticker := time.NewTicker(constants.RecurringExpenseJobInterval)
+		defer ticker.Stop()
+
+		for {
+			generateRecurringExpenseDrafts(recurringExpenseRepo, time.Now())
+			flagSkippedRecurringExpenseDrafts(recurringExpenseRepo)
+			<-ticker.C
+		}
+	}()
+}
+
+func generateRecurringExpenseDrafts(recurringExpenseRepo repositories.RecurringExpenseRepository, now time.Time) {
+	recurringExpenses, err := recurringExpenseRepo.FindDueRecurringExpenses(now)
+	if err != nil {
+		log.Printf("recurring expense scheduler: failed to get due recurring expenses: %v", err)
+		return
+	}
+
+	for i := range *recurringExpenses {
+		recurringExpense := &(*recurringExpenses)[i]
+
+		// Catch up on every month missed while the server was down
+		for !recurringExpense.NextDueDate.After(now) && (recurringExpense.EndDate == nil || !recurringExpense.NextDueDate.After(*recurringExpense.EndDate)) {
+			dueDate := recurringExpense.NextDueDate
+			draft := models.RecurringExpenseDraft{
+				RecurringExpenseID:    recurringExpense.ID,
+				RoomingHouseID:        recurringExpense.RoomingHouseID,
+				TransactionCategoryID: recurringExpense.TransactionCategoryID,
+				Description:           fmt.Sprintf("%s %s %d", recurringExpense.Name, dueDate.Month(), dueDate.Year()),
+				Amount:                recurringExpense.Amount,
+				DueDate:               dueDate,
+				Status:                constants.RecurringExpenseDraftPending,
+			}
+
+			nextDueDate := utils.MonthDay(dueDate.Year(), dueDate.Month()+1, recurringExpense.DayOfMonth)
+			if err := recurringExpenseRepo.CreateRecurringExpenseDraft(context.Background(), recurringExpense, &draft, nextDueDate); err != nil {
+				log.Printf("recurring expense scheduler: failed to create draft for recurring expense %s: %v", recurringExpense.ID, err)
+				break
+			}
+		}
+	}
+}
+
+func flagSkippedRecurringExpenseDrafts(recurringExpenseRepo repositories.RecurringExpenseRepository) {
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+
+	flagged, err := recurringExpenseRepo.FlagSkippedRecurringExpenseDrafts(monthStart, now)
+	if err != nil {
+		log.Printf("recurring expense scheduler: failed to flag skipped drafts: %v", err)
+		return
+	}
+
+	if flagged > 0 {
+		log.Printf("recurring expense scheduler: flagged %d skipped drafts", flagged)
+	}
+}
@@ -0,0 +1,139 @@
+package jobs
+
+import (
+	"context"
+	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeRecurringExpenseRepository returns the given recurring expenses as due
+// and records the drafts created for them.
+type fakeRecurringExpenseRepository struct {
+	repositories.RecurringExpenseRepository
+	recurringExpenses []models.RecurringExpense
+	drafts            []models.RecurringExpenseDraft
+}
+
+func (r *fakeRecurringExpenseRepository) FindDueRecurringExpenses(now time.Time) (*[]models.RecurringExpense, error) {
+	return &r.recurringExpenses, nil
+}
+
+func (r *fakeRecurringExpenseRepository) CreateRecurringExpenseDraft(_ context.Context, recurringExpense *models.RecurringExpense, draft *models.RecurringExpenseDraft, nextDueDate time.Time) error {
+	r.drafts = append(r.drafts, *draft)
+	recurringExpense.NextDueDate = nextDueDate
+	return nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+func TestGenerateRecurringExpenseDrafts(t *testing.T) {
+	endDate := date(2026, time.March, 15)
+
+	tests := []struct {
+		name         string
+		dayOfMonth   int
+		nextDueDate  time.Time
+		endDate      *time.Time
+		now          time.Time
+		wantDueDates []time.Time
+		wantNextDue  time.Time
+	}{
+		{
+			name:         "not due yet",
+			dayOfMonth:   10,
+			nextDueDate:  date(2026, time.May, 10),
+			now:          date(2026, time.May, 9),
+			wantNextDue:  date(2026, time.May, 10),
+			wantDueDates: nil,
+		},
+		{
+			name:         "due today",
+			dayOfMonth:   10,
+			nextDueDate:  date(2026, time.May, 10),
+			now:          date(2026, time.May, 10),
+			wantDueDates: []time.Time{date(2026, time.May, 10)},
+			wantNextDue:  date(2026, time.June, 10),
+		},
+		{
+			name:         "catches up on missed months",
+			dayOfMonth:   5,
+			nextDueDate:  date(2026, time.February, 5),
+			now:          date(2026, time.May, 1),
+			wantDueDates: []time.Time{date(2026, time.February, 5), date(2026, time.March, 5), date(2026, time.April, 5)},
+			wantNextDue:  date(2026, time.May, 5),
+		},
+		{
+			name:         "day 31 falls on the last day of short months and returns after",
+			dayOfMonth:   31,
+			nextDueDate:  date(2026, time.January, 31),
+			now:          date(2026, time.May, 31),
+			wantDueDates: []time.Time{date(2026, time.January, 31), date(2026, time.February, 28), date(2026, time.March, 31), date(2026, time.April, 30), date(2026, time.May, 31)},
+			wantNextDue:  date(2026, time.June, 30),
+		},
+		{
+			name:         "day 31 in a leap year",
+			dayOfMonth:   31,
+			nextDueDate:  date(2028, time.January, 31),
+			now:          date(2028, time.February, 29),
+			wantDueDates: []time.Time{date(2028, time.January, 31), date(2028, time.February, 29)},
+			wantNextDue:  date(2028, time.March, 31),
+		},
+		{
+			name:         "catches up across the year end",
+			dayOfMonth:   30,
+			nextDueDate:  date(2025, time.November, 30),
+			now:          date(2026, time.February, 1),
+			wantDueDates: []time.Time{date(2025, time.November, 30), date(2025, time.December, 30), date(2026, time.January, 30)},
+			wantNextDue:  date(2026, time.February, 28),
+		},
+		{
+			name:         "stops at the end date",
+			dayOfMonth:   15,
+			nextDueDate:  date(2026, time.January, 15),
+			endDate:      &endDate,
+			now:          date(2026, time.June, 1),
+			wantDueDates: []time.Time{date(2026, time.January, 15), date(2026, time.February, 15), date(2026, time.March, 15)},
+			wantNextDue:  date(2026, time.April, 15),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurringExpense := models.RecurringExpense{
+				Name:        "Internet",
+				Amount:      350000,
+				DayOfMonth:  tt.dayOfMonth,
+				EndDate:     tt.endDate,
+				NextDueDate: tt.nextDueDate,
+				IsActive:    true,
+			}
+			recurringExpense.ID = uuid.New()
+
+			repo := &fakeRecurringExpenseRepository{recurringExpenses: []models.RecurringExpense{recurringExpense}}
+			generateRecurringExpenseDrafts(repo, tt.now)
+
+			if len(repo.drafts) != len(tt.wantDueDates) {
+				t.Fatalf("got %d drafts, want %d", len(repo.drafts), len(tt.wantDueDates))
+			}
+
+			for i, draft := range repo.drafts {
+				if !draft.DueDate.Equal(tt.wantDueDates[i]) {
+					t.Errorf("draft %d due %s, want %s", i, draft.DueDate.Format(time.DateOnly), tt.wantDueDates[i].Format(time.DateOnly))
+				}
+				if draft.Amount != recurringExpense.Amount || draft.RecurringExpenseID != recurringExpense.ID {
+					t.Errorf("draft %d does not copy the recurring expense", i)
+				}
+			}
+
+			if nextDue := repo.recurringExpenses[0].NextDueDate; !nextDue.Equal(tt.wantNextDue) {
+				t.Errorf("next due date = %s, want %s", nextDue.Format(time.DateOnly), tt.wantNextDue.Format(time.DateOnly))
+			}
+		})
+	}
+}
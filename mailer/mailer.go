@@ -0,0 +1,46 @@
+package mailer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Mailer abstracts how outgoing emails are delivered so the development
+// outbox can be swapped for an SMTP or API provider without touching controllers.
+type Mailer interface {
+	Send(to string, subject string, body string) error
+}
+
+type logMailer struct {
+	outboxPath string
+}
+
+// NewLogMailer writes every email to a file in outboxPath and logs it instead
+// of delivering it. Intended for local development.
+func NewLogMailer(outboxPath string) Mailer {
+	if outboxPath == "" {
+		outboxPath = "outbox"
+	}
+	return &logMailer{outboxPath: outboxPath}
+}
+
+func (m *logMailer) Send(to string, subject string, body string) error {
+	if err := os.MkdirAll(m.outboxPath, 0o750); err != nil {
+		return err
+	}
+
+	recipient := strings.NewReplacer("/", "_", "\\", "_", "..", "_").Replace(to)
+	fileName := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102T150405.000000000"), recipient)
+
+	message := fmt.Sprintf("To: %s\nSubject: %s\nDate: %s\n\n%s\n", to, subject, time.Now().Format(time.RFC1123Z), body)
+	if err := os.WriteFile(filepath.Join(m.outboxPath, fileName), []byte(message), 0o640); err != nil {
+		return err
+	}
+
+	log.Printf("mail to %s: %s (saved to %s)", to, subject, fileName)
+	return nil
+}
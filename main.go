@@ -5,7 +5,9 @@ import (
 	"os"
 	"rooming-house-cms-be/cli"
 	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/jobs"
 	"rooming-house-cms-be/middlewares"
+	"rooming-house-cms-be/seeders"
 
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
@@ -19,9 +21,13 @@ func main() {
 
 	config.InitDB()
 
-	// seeders.SeedPeriod(config.DB)
+	seeders.SeedPeriod(config.DB)
 	// seeders.SeedFacility(config.DB)
 	// seeders.SeedTransactionCategory(config.DB)
+	seeders.SeedTransactionCategoryCodes(config.DB)
+	seeders.SeedAdminRoomingHouses(config.DB)
+	seeders.SeedRoles(config.DB)
+	seeders.SeedRoomingHouseOwners(config.DB)
 
 	port := os.Getenv("PORT")
 
@@ -38,10 +44,27 @@ func main() {
 	cli.RoomRoutes(e)
 	cli.AdditionalPriceRoutes(e)
 	cli.TransactionRoutes(e)
+	cli.SettlementRoutes(e)
+	cli.StaffRoutes(e)
+	cli.PayrollRoutes(e)
+	cli.RecurringExpenseRoutes(e)
+	cli.BudgetRoutes(e)
 	cli.TenantRoutes(e)
 	cli.AdminRoutes(e)
+	cli.RoleRoutes(e)
+	cli.AuditLogRoutes(e)
+	cli.InvitationRoutes(e)
 	cli.PeriodRoute(e)
 	cli.FacilityRoutes(e)
+	cli.AttachmentRoutes(e)
+	cli.TenantBlacklistRoutes(e)
+	cli.TenantPortalRoutes(e)
+	cli.WorkOrderRoutes(e)
+	cli.MaintenanceScheduleRoutes(e)
+	cli.FacilityAssetRoutes(e)
+
+	jobs.StartMaintenanceScheduler(config.DB)
+	jobs.StartRecurringExpenseScheduler(config.DB)
 
 	e.Logger.Fatal(e.Start(":" + port))
 }
@@ -2,10 +2,16 @@ package middlewares
 
 import (
 	"fmt"
+	"net/http"
 	"os"
+	"rooming-house-cms-be/audit"
+	"rooming-house-cms-be/config"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/models"
+	"rooming-house-cms-be/repositories"
 	"rooming-house-cms-be/utils"
 	"strings"
+	"time"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/google/uuid"
@@ -14,20 +20,21 @@ import (
 
 var jwtSecret = []byte(os.Getenv("JWT_SECRET_KEY"))
 
-func GenerateJWT(userID uuid.UUID, role string, rooming_house_id uuid.UUID) (string, error) {
-	var claims jwt.Claims
+// GenerateJWT issues a short-lived access token bound to the given session.
+func GenerateJWT(userID uuid.UUID, role string, rooming_house_id uuid.UUID, sessionID uuid.UUID) (string, error) {
 	if role == "owner" {
-		claims = jwt.MapClaims{
-			"user_id":          userID,
-			"role":             role,
-			"rooming_house_id": uuid.Nil,
-		}
-	} else {
-		claims = jwt.MapClaims{
-			"user_id":          userID,
-			"role":             role,
-			"rooming_house_id": rooming_house_id,
-		}
+		rooming_house_id = uuid.Nil
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"user_id":          userID,
+		"role":             role,
+		"rooming_house_id": rooming_house_id,
+		"sid":              sessionID,
+		"jti":              uuid.New(),
+		"iat":              now.Unix(),
+		"exp":              now.Add(constants.AccessTokenTTL).Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -37,61 +44,212 @@ func GenerateJWT(userID uuid.UUID, role string, rooming_house_id uuid.UUID) (str
 func JWTAuth(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		fmt.Println("masuk jwt auth")
-		authHeader := c.Request().Header.Get("Authorization")
-		if authHeader == "" {
-			return utils.HandlerError(c, utils.NewUnauthorizedError("please login first"))
+		userPayload, apiErr := parseToken(c)
+		if apiErr != nil {
+			return utils.HandlerError(c, apiErr)
 		}
 
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		if tokenString == authHeader {
-			return utils.HandlerError(c, utils.NewUnauthorizedError("invalid authorization header format"))
+		// Tenant tokens are only valid on the portal routes guarded by TenantJWTAuth
+		if userPayload.Role != "owner" && userPayload.Role != "admin" {
+			return utils.HandlerError(c, utils.NewForbiddenError("you are not allowed to access this resource"))
 		}
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		if userPayload.Role == "admin" {
+			if apiErr := selectAdminRoomingHouse(c, userPayload); apiErr != nil {
+				return utils.HandlerError(c, apiErr)
 			}
-			return jwtSecret, nil
-		})
-
-		if err != nil {
-			return utils.HandlerError(c, utils.NewUnauthorizedError("invalid token"))
 		}
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			userIDStr, ok := claims["user_id"].(string)
-			if !ok {
-				return utils.HandlerError(c, utils.NewUnauthorizedError("invalid token: user_id not found"))
-			}
+		setUserPayload(c, userPayload)
 
-			roomingHouseIDStr, ok := claims["rooming_house_id"].(string)
-			if !ok {
-				return utils.HandlerError(c, utils.NewUnauthorizedError("invalid token: rooming_house_id not found"))
-			}
+		return next(c)
+	}
+}
 
-			// Convert the string IDs to uuid.UUID
-			userID, err := uuid.Parse(userIDStr)
-			if err != nil {
-				return utils.HandlerError(c, utils.NewUnauthorizedError("invalid token: invalid user_id"))
-			}
+// AnyJWTAuth accepts a valid token of any role, for endpoints such as logout.
+func AnyJWTAuth(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userPayload, apiErr := parseToken(c)
+		if apiErr != nil {
+			return utils.HandlerError(c, apiErr)
+		}
 
-			roomingHouseID, err := uuid.Parse(roomingHouseIDStr)
-			if err != nil {
-				return utils.HandlerError(c, utils.NewUnauthorizedError("invalid token: invalid rooming_house_id"))
-			}
+		setUserPayload(c, userPayload)
 
-			// Role is still a string, so it's fine
-			role := claims["role"].(string)
+		return next(c)
+	}
+}
+
+func TenantJWTAuth(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userPayload, apiErr := parseToken(c)
+		if apiErr != nil {
+			return utils.HandlerError(c, apiErr)
+		}
 
-			c.Set("userPayload", &models.JWTPayload{
-				UserID:         userID,
-				Role:           role,
-				RoomingHouseID: roomingHouseID,
-			})
-		} else {
-			return utils.HandlerError(c, utils.NewUnauthorizedError("invalid token"))
+		if userPayload.Role != "tenant" {
+			return utils.HandlerError(c, utils.NewForbiddenError("only tenant can access this resource"))
 		}
 
+		userPayload.RoomingHouseIDs = []uuid.UUID{userPayload.RoomingHouseID}
+
+		setUserPayload(c, userPayload)
+
 		return next(c)
 	}
 }
+
+// setUserPayload stores the authenticated user for the handlers and attributes
+// the request's database writes to it in the audit log.
+func setUserPayload(c echo.Context, userPayload *models.JWTPayload) {
+	c.Set("userPayload", userPayload)
+
+	ctx := audit.WithActor(c.Request().Context(), audit.Actor{
+		UserID:         userPayload.UserID,
+		Role:           userPayload.Role,
+		RoomingHouseID: userPayload.RoomingHouseID,
+	})
+	c.SetRequest(c.Request().WithContext(ctx))
+}
+
+// selectAdminRoomingHouse loads the admin's current assignments and role
+// permissions and picks the house the request works on from the rooming house
+// header. An admin with a single house works on it implicitly; with several
+// houses, reads span all of them and writes must select one.
+func selectAdminRoomingHouse(c echo.Context, userPayload *models.JWTPayload) *utils.APIError {
+	roomingHouseIDs, err := repositories.NewAdminRepository(config.DB).FindAdminRoomingHouseIDs(userPayload.UserID)
+	if err != nil {
+		return utils.NewInternalError("failed to get assigned rooming houses")
+	}
+
+	if len(roomingHouseIDs) == 0 {
+		return utils.NewForbiddenError("you are not assigned to any rooming house")
+	}
+
+	permissions, err := repositories.NewRoleRepository(config.DB).FindAdminPermissions(userPayload.UserID)
+	if err != nil {
+		return utils.NewInternalError("failed to get permissions")
+	}
+
+	userPayload.RoomingHouseIDs = roomingHouseIDs
+	userPayload.RoomingHouseID = uuid.Nil
+	userPayload.Permissions = permissions
+
+	selected := c.Request().Header.Get(constants.RoomingHouseHeader)
+	if selected == "" {
+		if len(roomingHouseIDs) == 1 {
+			userPayload.RoomingHouseID = roomingHouseIDs[0]
+			return nil
+		}
+
+		method := c.Request().Method
+		if method != http.MethodGet && method != http.MethodHead {
+			return utils.NewBadRequestError("select a rooming house with the " + constants.RoomingHouseHeader + " header")
+		}
+		return nil
+	}
+
+	selectedID, err := uuid.Parse(selected)
+	if err != nil {
+		return utils.NewBadRequestError("invalid " + constants.RoomingHouseHeader + " header")
+	}
+
+	for _, roomingHouseID := range roomingHouseIDs {
+		if roomingHouseID == selectedID {
+			userPayload.RoomingHouseID = selectedID
+			return nil
+		}
+	}
+
+	return utils.NewForbiddenError("you are not assigned to this rooming house")
+}
+
+func parseToken(c echo.Context) (*models.JWTPayload, *utils.APIError) {
+	authHeader := c.Request().Header.Get("Authorization")
+	if authHeader == "" {
+		return nil, utils.NewUnauthorizedError("please login first")
+	}
+
+	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+	if tokenString == authHeader {
+		return nil, utils.NewUnauthorizedError("invalid authorization header format")
+	}
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return jwtSecret, nil
+	})
+
+	if err != nil {
+		return nil, utils.NewUnauthorizedError("invalid token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, utils.NewUnauthorizedError("invalid token")
+	}
+
+	userIDStr, ok := claims["user_id"].(string)
+	if !ok {
+		return nil, utils.NewUnauthorizedError("invalid token: user_id not found")
+	}
+
+	roomingHouseIDStr, ok := claims["rooming_house_id"].(string)
+	if !ok {
+		return nil, utils.NewUnauthorizedError("invalid token: rooming_house_id not found")
+	}
+
+	// Convert the string IDs to uuid.UUID
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, utils.NewUnauthorizedError("invalid token: invalid user_id")
+	}
+
+	roomingHouseID, err := uuid.Parse(roomingHouseIDStr)
+	if err != nil {
+		return nil, utils.NewUnauthorizedError("invalid token: invalid rooming_house_id")
+	}
+
+	role, ok := claims["role"].(string)
+	if !ok {
+		return nil, utils.NewUnauthorizedError("invalid token: role not found")
+	}
+
+	// Tokens issued before sessions existed carry no expiry and are rejected
+	if _, ok := claims["exp"]; !ok {
+		return nil, utils.NewUnauthorizedError("invalid token: expiry not found")
+	}
+
+	sessionIDStr, ok := claims["sid"].(string)
+	if !ok {
+		return nil, utils.NewUnauthorizedError("invalid token: session not found")
+	}
+
+	sessionID, err := uuid.Parse(sessionIDStr)
+	if err != nil {
+		return nil, utils.NewUnauthorizedError("invalid token: invalid session")
+	}
+
+	// Logout, password changes and removed accounts revoke the session
+	session, err := repositories.NewSessionRepository(config.DB).FindSessionByID(sessionID)
+	if err != nil || session.RevokedAt != nil || session.UserID != userID || session.Role != role {
+		return nil, utils.NewUnauthorizedError("session has been revoked, please login again")
+	}
+
+	// Disabled admins are locked out even if revoking their sessions failed
+	if role == "admin" {
+		admin, err := repositories.NewAdminRepository(config.DB).FindAdminByID(userID)
+		if err != nil || admin.DisabledAt != nil {
+			return nil, utils.NewUnauthorizedError("your account has been disabled or removed")
+		}
+	}
+
+	return &models.JWTPayload{
+		UserID:         userID,
+		Role:           role,
+		RoomingHouseID: roomingHouseID,
+		SessionID:      sessionID,
+	}, nil
+}
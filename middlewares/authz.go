@@ -1,21 +1,54 @@
 package middlewares
 
 import (
-	"fmt"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/models"
 	"rooming-house-cms-be/utils"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
-func Authz(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		fmt.Println("Authz middleware")
-		userPayload := c.Get("userPayload").(*models.JWTPayload)
+// RequirePermission allows the request when the user holds the permission.
+// Owners hold every permission. Admins need it from their role in the
+// selected rooming house; without a selection, the request is narrowed to the
+// assigned houses that grant it. Must run after JWTAuth.
+func RequirePermission(resource string, action string) echo.MiddlewareFunc {
+	permission := constants.Permission(resource, action)
 
-		if userPayload.Role != "owner" {
-			return utils.HandlerError(c, utils.NewForbiddenError("only owner can access this resource"))
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userPayload := c.Get("userPayload").(*models.JWTPayload)
+
+			if userPayload.Role == "owner" {
+				return next(c)
+			}
+
+			if constants.OwnerOnlyPermissions[permission] {
+				return utils.HandlerError(c, utils.NewForbiddenError("only owner can access this resource"))
+			}
+
+			if userPayload.RoomingHouseID != uuid.Nil {
+				if !userPayload.Can(userPayload.RoomingHouseID, permission) {
+					return utils.HandlerError(c, utils.NewForbiddenError("you do not have the "+permission+" permission in this rooming house"))
+				}
+				return next(c)
+			}
+
+			var permitted []uuid.UUID
+			for _, roomingHouseID := range userPayload.RoomingHouseIDs {
+				if userPayload.Can(roomingHouseID, permission) {
+					permitted = append(permitted, roomingHouseID)
+				}
+			}
+
+			if len(permitted) == 0 {
+				return utils.HandlerError(c, utils.NewForbiddenError("you do not have the "+permission+" permission"))
+			}
+
+			userPayload.RoomingHouseIDs = permitted
+
+			return next(c)
 		}
-		return next(c)
 	}
 }
@@ -0,0 +1,128 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+func TestRequirePermission(t *testing.T) {
+	houseA, houseB := uuid.New(), uuid.New()
+	roomsRead := constants.Permission(constants.ResourceRooms, constants.ActionRead)
+	roomsWrite := constants.Permission(constants.ResourceRooms, constants.ActionWrite)
+
+	newAdmin := func(selected uuid.UUID) *models.JWTPayload {
+		return &models.JWTPayload{
+			Role:            "admin",
+			RoomingHouseID:  selected,
+			RoomingHouseIDs: []uuid.UUID{houseA, houseB},
+			Permissions: map[uuid.UUID]map[string]bool{
+				houseA: {roomsRead: true, roomsWrite: true},
+				houseB: {roomsRead: true},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		payload    *models.JWTPayload
+		resource   string
+		action     string
+		wantStatus int
+		wantScope  []uuid.UUID
+	}{
+		{
+			name:       "owner passes",
+			payload:    &models.JWTPayload{Role: "owner"},
+			resource:   constants.ResourceAdmins,
+			action:     constants.ActionWrite,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "owner only permission is forbidden for admins",
+			payload:    newAdmin(uuid.Nil),
+			resource:   constants.ResourceRoomingHouses,
+			action:     constants.ActionWrite,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "selected house grants the permission",
+			payload:    newAdmin(houseA),
+			resource:   constants.ResourceRooms,
+			action:     constants.ActionWrite,
+			wantStatus: http.StatusOK,
+			wantScope:  []uuid.UUID{houseA},
+		},
+		{
+			name:       "selected house lacks the permission",
+			payload:    newAdmin(houseB),
+			resource:   constants.ResourceRooms,
+			action:     constants.ActionWrite,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "without selection the scope is narrowed to granting houses",
+			payload:    newAdmin(uuid.Nil),
+			resource:   constants.ResourceRooms,
+			action:     constants.ActionWrite,
+			wantStatus: http.StatusOK,
+			wantScope:  []uuid.UUID{houseA},
+		},
+		{
+			name:       "without selection every granting house stays",
+			payload:    newAdmin(uuid.Nil),
+			resource:   constants.ResourceRooms,
+			action:     constants.ActionRead,
+			wantStatus: http.StatusOK,
+			wantScope:  []uuid.UUID{houseA, houseB},
+		},
+		{
+			name:       "no house grants the permission",
+			payload:    newAdmin(uuid.Nil),
+			resource:   constants.ResourceRooms,
+			action:     constants.ActionDelete,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+			c.Set("userPayload", tt.payload)
+
+			var scope []uuid.UUID
+			handler := RequirePermission(tt.resource, tt.action)(func(c echo.Context) error {
+				scope = c.Get("userPayload").(*models.JWTPayload).ScopedRoomingHouseIDs()
+				return c.NoContent(http.StatusOK)
+			})
+
+			if err := handler(c); err != nil {
+				t.Fatalf("handler returned error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+
+			if tt.wantStatus != http.StatusOK || tt.payload.Role == "owner" {
+				return
+			}
+
+			if len(scope) != len(tt.wantScope) {
+				t.Fatalf("scope = %v, want %v", scope, tt.wantScope)
+			}
+			for i := range scope {
+				if scope[i] != tt.wantScope[i] {
+					t.Fatalf("scope = %v, want %v", scope, tt.wantScope)
+				}
+			}
+		})
+	}
+}
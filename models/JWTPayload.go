@@ -2,8 +2,44 @@ package models
 
 import "github.com/google/uuid"
 
+// JWTPayload is the authenticated user of a request. RoomingHouseIDs are the
+// houses an admin is assigned to (or the tenant's house); RoomingHouseID is
+// the house selected for this request, or uuid.Nil when an admin with several
+// houses did not select one. Both are empty for owners. Permissions holds the
+// "resource:action" grants of an admin's role in each assigned house.
 type JWTPayload struct {
-	UserID         uuid.UUID `json:"user_id"`
-	Role           string    `json:"role"`
-	RoomingHouseID uuid.UUID `json:"rooming_house_id"`
+	UserID          uuid.UUID                     `json:"user_id"`
+	Role            string                        `json:"role"`
+	RoomingHouseID  uuid.UUID                     `json:"rooming_house_id"`
+	RoomingHouseIDs []uuid.UUID                   `json:"rooming_house_ids"`
+	SessionID       uuid.UUID                     `json:"sid"`
+	Permissions     map[uuid.UUID]map[string]bool `json:"-"`
+}
+
+// ScopedRoomingHouseIDs returns the houses a non-owner request works on: the
+// selected house, or every permitted house when none is selected.
+func (p *JWTPayload) ScopedRoomingHouseIDs() []uuid.UUID {
+	if p.RoomingHouseID != uuid.Nil {
+		return []uuid.UUID{p.RoomingHouseID}
+	}
+	return p.RoomingHouseIDs
+}
+
+// HasRoomingHouse reports whether the rooming house is within the request scope.
+func (p *JWTPayload) HasRoomingHouse(roomingHouseID uuid.UUID) bool {
+	for _, id := range p.ScopedRoomingHouseIDs() {
+		if id == roomingHouseID {
+			return true
+		}
+	}
+	return false
+}
+
+// Can reports whether the user holds the permission in the rooming house.
+// Owners hold every permission on their own houses.
+func (p *JWTPayload) Can(roomingHouseID uuid.UUID, permission string) bool {
+	if p.Role == "owner" {
+		return true
+	}
+	return p.Permissions[roomingHouseID][permission]
 }
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestJWTPayloadCan(t *testing.T) {
+	houseA, houseB, otherHouse := uuid.New(), uuid.New(), uuid.New()
+
+	admin := &JWTPayload{
+		Role:            "admin",
+		RoomingHouseIDs: []uuid.UUID{houseA, houseB},
+		Permissions: map[uuid.UUID]map[string]bool{
+			houseA: {"rooms:read": true, "rooms:write": true},
+			houseB: {"rooms:read": true},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		payload        *JWTPayload
+		roomingHouseID uuid.UUID
+		permission     string
+		want           bool
+	}{
+		{name: "owner holds every permission", payload: &JWTPayload{Role: "owner"}, roomingHouseID: otherHouse, permission: "rooms:delete", want: true},
+		{name: "admin with the grant", payload: admin, roomingHouseID: houseA, permission: "rooms:write", want: true},
+		{name: "grant is per rooming house", payload: admin, roomingHouseID: houseB, permission: "rooms:write"},
+		{name: "unassigned rooming house", payload: admin, roomingHouseID: otherHouse, permission: "rooms:read"},
+		{name: "admin without permissions", payload: &JWTPayload{Role: "admin", RoomingHouseIDs: []uuid.UUID{houseA}}, roomingHouseID: houseA, permission: "rooms:read"},
+		{name: "tenant holds nothing", payload: &JWTPayload{Role: "tenant", RoomingHouseID: houseA}, roomingHouseID: houseA, permission: "rooms:read"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.payload.Can(tt.roomingHouseID, tt.permission); got != tt.want {
+				t.Errorf("Can() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJWTPayloadHasRoomingHouse(t *testing.T) {
+	houseA, houseB := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name           string
+		payload        *JWTPayload
+		roomingHouseID uuid.UUID
+		want           bool
+	}{
+		{name: "assigned house without selection", payload: &JWTPayload{RoomingHouseIDs: []uuid.UUID{houseA, houseB}}, roomingHouseID: houseB, want: true},
+		{name: "selection narrows the scope", payload: &JWTPayload{RoomingHouseID: houseA, RoomingHouseIDs: []uuid.UUID{houseA, houseB}}, roomingHouseID: houseB},
+		{name: "selected house", payload: &JWTPayload{RoomingHouseID: houseA, RoomingHouseIDs: []uuid.UUID{houseA, houseB}}, roomingHouseID: houseA, want: true},
+		{name: "empty scope", payload: &JWTPayload{}, roomingHouseID: houseA},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.payload.HasRoomingHouse(tt.roomingHouseID); got != tt.want {
+				t.Errorf("HasRoomingHouse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -16,20 +16,14 @@ type AdditionalPrice struct {
 }
 
 type AddAdditionalPriceBody struct {
-	Name           string    `json:"name"`
-	DailyPrice     float64   `json:"daily_price"`
-	WeeklyPrice    float64   `json:"weekly_price"`
-	MonthlyPrice   float64   `json:"monthly_price"`
-	AnnualPrice    float64   `json:"annual_price"`
-	RoomingHouseID uuid.UUID `json:"rooming_house_id"`
+	Name           string                `json:"name"`
+	Prices         map[uuid.UUID]float64 `json:"prices"`
+	RoomingHouseID uuid.UUID             `json:"rooming_house_id"`
 }
 
 type UpdateAdditionalPriceBody struct {
-	Name         string  `json:"name"`
-	DailyPrice   float64 `json:"daily_price"`
-	WeeklyPrice  float64 `json:"weekly_price"`
-	MonthlyPrice float64 `json:"monthly_price"`
-	AnnualPrice  float64 `json:"annual_price"`
+	Name   string                `json:"name"`
+	Prices map[uuid.UUID]float64 `json:"prices"`
 }
 
 type AdditionalPriceResponse struct {
@@ -10,35 +10,47 @@ import (
 
 type Admin struct {
 	BaseModel
-	FullName       string    `json:"full_name" gorm:"not null"`
-	Username       string    `json:"username" gorm:"not null"`
-	Email          string    `json:"email" gorm:"not null;uniqueIndex;size:191"`
-	Password       string    `json:"password" gorm:"not null"`
-	Role           string    `json:"role" gorm:"not null"`
-	RoomingHouseID uuid.UUID `json:"rooming_house_id" gorm:"not null;size:191"`
+	FullName   string     `json:"full_name" gorm:"not null"`
+	Username   string     `json:"username" gorm:"not null"`
+	Email      string     `json:"email" gorm:"not null;uniqueIndex;size:191"`
+	Password   string     `json:"password" gorm:"not null"`
+	Role       string     `json:"role" gorm:"not null"`
+	DisabledAt *time.Time `json:"disabled_at"`
 }
 
-type AdminRegisterBody struct {
-	FullName       string    `json:"full_name" gorm:"not null"`
-	Username       string    `json:"username" gorm:"not null"`
-	Email          string    `json:"email" gorm:"not null"`
-	Password       string    `json:"password" gorm:"not null"`
-	RoomingHouseID uuid.UUID `json:"rooming_house_id"`
+type UpdateAdminBody struct {
+	FullName string `json:"full_name"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+type MoveAdminBody struct {
+	FromRoomingHouseID uuid.UUID  `json:"from_rooming_house_id"`
+	ToRoomingHouseID   uuid.UUID  `json:"to_rooming_house_id"`
+	RoleID             *uuid.UUID `json:"role_id"`
 }
 
 type AdminResponse struct {
-	ID             uuid.UUID `json:"id"`
-	Username       string    `json:"username"`
-	Role           string    `json:"role"`
-	RoomingHouseID uuid.UUID `json:"rooming_house_id"`
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+	Role     string    `json:"role"`
 }
 
 type GetAllAdminResponse struct {
-	ID           uuid.UUID                  `json:"id"`
-	FullName     string                     `json:"full_name"`
-	Username     string                     `json:"username"`
-	Role         string                     `json:"role"`
-	RoomingHouse TenantRoomingHouseResponse `json:"rooming_house"`
+	ID            uuid.UUID                   `json:"id"`
+	FullName      string                      `json:"full_name"`
+	Username      string                      `json:"username"`
+	Email         string                      `json:"email"`
+	Role          string                      `json:"role"`
+	DisabledAt    *time.Time                  `json:"disabled_at"`
+	RoomingHouses []AdminRoomingHouseResponse `json:"rooming_houses"`
+}
+
+type AdminRoomingHouseResponse struct {
+	ID       uuid.UUID  `json:"id"`
+	Name     string     `json:"name"`
+	RoleID   *uuid.UUID `json:"role_id"`
+	RoleName string     `json:"role_name"`
 }
 
 func (a *Admin) BeforeCreate(tx *gorm.DB) (err error) {
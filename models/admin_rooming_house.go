@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AdminRoomingHouse assigns an admin to a rooming house. An admin can staff
+// several houses of the same owner and a house can have several admins.
+type AdminRoomingHouse struct {
+	BaseModel
+	AdminID        uuid.UUID  `json:"admin_id" gorm:"not null;size:191;uniqueIndex:idx_admin_rooming_house"`
+	RoomingHouseID uuid.UUID  `json:"rooming_house_id" gorm:"not null;size:191;uniqueIndex:idx_admin_rooming_house;index"`
+	RoleID         *uuid.UUID `json:"role_id" gorm:"size:191;index"`
+}
+
+type AssignAdminRoomingHouseBody struct {
+	RoomingHouseID uuid.UUID  `json:"rooming_house_id"`
+	RoleID         *uuid.UUID `json:"role_id"`
+}
+
+type UpdateAdminRoleBody struct {
+	RoleID uuid.UUID `json:"role_id"`
+}
+
+func (arh *AdminRoomingHouse) BeforeCreate(tx *gorm.DB) (err error) {
+	arh.ID = uuid.New()
+	arh.CreatedAt = time.Now()
+
+	return
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Attachment struct {
+	BaseModel
+	EntityType     string    `json:"entity_type" gorm:"not null;size:32;index:idx_attachment_entity"`
+	EntityID       uuid.UUID `json:"entity_id" gorm:"not null;size:191;index:idx_attachment_entity"`
+	RoomingHouseID uuid.UUID `json:"rooming_house_id" gorm:"not null;size:191"`
+	FileName       string    `json:"file_name" gorm:"not null"`
+	MimeType       string    `json:"mime_type" gorm:"not null"`
+	Size           int64     `json:"size" gorm:"not null"`
+	Description    string    `json:"description"`
+	StorageKey     string    `json:"-" gorm:"not null"`
+	UploadedBy     uuid.UUID `json:"uploaded_by" gorm:"not null;size:191"`
+}
+
+type AttachmentResponse struct {
+	ID             uuid.UUID `json:"id"`
+	EntityType     string    `json:"entity_type"`
+	EntityID       uuid.UUID `json:"entity_id"`
+	RoomingHouseID uuid.UUID `json:"rooming_house_id"`
+	FileName       string    `json:"file_name"`
+	MimeType       string    `json:"mime_type"`
+	Size           int64     `json:"size"`
+	Description    string    `json:"description"`
+	UploadedBy     uuid.UUID `json:"uploaded_by"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (a *Attachment) BeforeCreate(tx *gorm.DB) (err error) {
+	a.ID = uuid.New()
+	a.CreatedAt = time.Now()
+
+	return
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditLog records one created, updated or deleted row. Before and After hold
+// the changed columns of an update, the full row of a create or a delete.
+type AuditLog struct {
+	BaseModel
+	ActorID        *uuid.UUID      `json:"actor_id" gorm:"size:191;index"`
+	ActorRole      string          `json:"actor_role" gorm:"not null;size:16"`
+	OwnerID        *uuid.UUID      `json:"owner_id" gorm:"size:191;index"`
+	RoomingHouseID *uuid.UUID      `json:"rooming_house_id" gorm:"size:191;index"`
+	EntityType     string          `json:"entity_type" gorm:"not null;size:64;index:idx_audit_entity"`
+	EntityID       string          `json:"entity_id" gorm:"size:191;index:idx_audit_entity"`
+	Action         string          `json:"action" gorm:"not null;size:16"`
+	Before         json.RawMessage `json:"before" gorm:"type:json"`
+	After          json.RawMessage `json:"after" gorm:"type:json"`
+}
+
+type AuditLogFilter struct {
+	EntityType     string
+	EntityID       string
+	ActorID        *uuid.UUID
+	RoomingHouseID *uuid.UUID
+	Action         string
+	From           *time.Time
+	To             *time.Time
+	Limit          int
+}
+
+func (al *AuditLog) BeforeCreate(tx *gorm.DB) (err error) {
+	al.ID = uuid.New()
+	al.CreatedAt = time.Now()
+
+	return
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Budget is the planned amount of a transaction category in a rooming house
+// for one month. AlertThreshold is the percentage of the amount at which
+// spending is flagged.
+type Budget struct {
+	BaseModel
+	RoomingHouseID        uuid.UUID `json:"rooming_house_id" gorm:"not null;size:191;uniqueIndex:idx_budget_period"`
+	TransactionCategoryID uuid.UUID `json:"transaction_category_id" gorm:"not null;size:191;uniqueIndex:idx_budget_period"`
+	Year                  int       `json:"year" gorm:"not null;uniqueIndex:idx_budget_period"`
+	Month                 int       `json:"month" gorm:"not null;uniqueIndex:idx_budget_period"`
+	Amount                float64   `json:"amount" gorm:"not null"`
+	AlertThreshold        float64   `json:"alert_threshold" gorm:"not null"`
+}
+
+type BudgetMonthBody struct {
+	Month  int     `json:"month"`
+	Amount float64 `json:"amount"`
+}
+
+// BudgetBody sets the budget of a category for a year, month by month or as
+// an annual amount spread evenly over the months. It replaces the budget the
+// category had for that year; sending neither clears it.
+type BudgetBody struct {
+	RoomingHouseID        uuid.UUID         `json:"rooming_house_id"`
+	TransactionCategoryID uuid.UUID         `json:"transaction_category_id"`
+	Year                  int               `json:"year"`
+	AnnualAmount          float64           `json:"annual_amount"`
+	Months                []BudgetMonthBody `json:"months"`
+	AlertThreshold        float64           `json:"alert_threshold"`
+}
+
+type BudgetResponse struct {
+	ID                    uuid.UUID                  `json:"id"`
+	TransactionCategoryID uuid.UUID                  `json:"transaction_category_id"`
+	CategoryName          string                     `json:"category_name"`
+	IsExpense             bool                       `json:"is_expense"`
+	Year                  int                        `json:"year"`
+	Month                 int                        `json:"month"`
+	Amount                float64                    `json:"amount"`
+	AlertThreshold        float64                    `json:"alert_threshold"`
+	RoomingHouse          TenantRoomingHouseResponse `json:"rooming_house" gorm:"embedded"`
+}
+
+type BudgetVarianceMonth struct {
+	Month          string  `json:"month"`
+	Index          int     `json:"index"`
+	Budget         float64 `json:"budget"`
+	Actual         float64 `json:"actual"`
+	Variance       float64 `json:"variance"`
+	UsedPercentage float64 `json:"used_percentage"`
+	AlertThreshold float64 `json:"alert_threshold"`
+	Alert          bool    `json:"alert"`
+}
+
+// BudgetVarianceResponse compares the budget of a category with what was
+// actually spent or earned. Variance is positive when the result is better
+// than planned: spent less or earned more. Each month alerts when spending
+// reaches the AlertThreshold percent of its own budget, and the period alerts
+// when any of its months does. AlertThreshold is only set for a single month.
+type BudgetVarianceResponse struct {
+	RoomingHouse          TenantRoomingHouseResponse `json:"rooming_house"`
+	TransactionCategoryID uuid.UUID                  `json:"transaction_category_id"`
+	CategoryName          string                     `json:"category_name"`
+	IsExpense             bool                       `json:"is_expense"`
+	Year                  int                        `json:"year"`
+	Month                 int                        `json:"month,omitempty"`
+	Budget                float64                    `json:"budget"`
+	Actual                float64                    `json:"actual"`
+	Variance              float64                    `json:"variance"`
+	UsedPercentage        float64                    `json:"used_percentage"`
+	AlertThreshold        float64                    `json:"alert_threshold,omitempty"`
+	Alert                 bool                       `json:"alert"`
+	Months                []BudgetVarianceMonth      `json:"months,omitempty"`
+}
+
+func (b *Budget) BeforeCreate(tx *gorm.DB) (err error) {
+	b.ID = uuid.New()
+	b.CreatedAt = time.Now()
+
+	return
+}
@@ -13,6 +13,7 @@ type Facility struct {
 	Description          string                 `json:"description"`
 	IsPublic             bool                   `json:"is_public" gorm:"not null"`
 	IsRoom               bool                   `json:"is_room" gorm:"not null"`
+	OwnerID              *uuid.UUID             `json:"owner_id" gorm:"size:191;index"`
 	RoomFacility         []RoomFacility         `json:"room_facility" gorm:"foreignKey:FacilityID"`
 	RoomingHouseFacility []RoomingHouseFacility `json:"rooming_house_facility" gorm:"foreignKey:FacilityID"`
 }
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FacilityAsset is one physical unit of a facility, such as a specific AC. It
+// sits in a room when RoomID is set, otherwise it is shared by the rooming house.
+type FacilityAsset struct {
+	BaseModel
+	FacilityID       uuid.UUID  `json:"facility_id" gorm:"not null;size:191"`
+	RoomingHouseID   uuid.UUID  `json:"rooming_house_id" gorm:"not null;size:191;index"`
+	RoomID           *uuid.UUID `json:"room_id" gorm:"size:191;index"`
+	SerialNumber     string     `json:"serial_number" gorm:"size:100"`
+	Brand            string     `json:"brand"`
+	PurchaseDate     *time.Time `json:"purchase_date"`
+	PurchaseCost     float64    `json:"purchase_cost"`
+	SalvageValue     float64    `json:"salvage_value"`
+	UsefulLifeMonths int        `json:"useful_life_months"`
+	WarrantyExpiry   *time.Time `json:"warranty_expiry"`
+	Condition        string     `json:"condition" gorm:"not null;size:32;default:good"`
+}
+
+// FacilityAssetMovement records an asset being moved between rooms or shared areas.
+type FacilityAssetMovement struct {
+	BaseModel
+	FacilityAssetID uuid.UUID  `json:"facility_asset_id" gorm:"not null;size:191;index"`
+	FromRoomID      *uuid.UUID `json:"from_room_id" gorm:"size:191"`
+	ToRoomID        *uuid.UUID `json:"to_room_id" gorm:"size:191"`
+	Reason          string     `json:"reason"`
+	MovedBy         uuid.UUID  `json:"moved_by" gorm:"not null;size:191"`
+	MoverRole       string     `json:"mover_role" gorm:"not null"`
+}
+
+type AddFacilityAssetBody struct {
+	FacilityID       uuid.UUID  `json:"facility_id"`
+	RoomingHouseID   uuid.UUID  `json:"rooming_house_id"`
+	RoomID           *uuid.UUID `json:"room_id"`
+	SerialNumber     string     `json:"serial_number"`
+	Brand            string     `json:"brand"`
+	PurchaseDate     string     `json:"purchase_date"`
+	PurchaseCost     float64    `json:"purchase_cost"`
+	SalvageValue     float64    `json:"salvage_value"`
+	UsefulLifeMonths int        `json:"useful_life_months"`
+	WarrantyExpiry   string     `json:"warranty_expiry"`
+	Condition        string     `json:"condition"`
+}
+
+type UpdateFacilityAssetBody struct {
+	SerialNumber     string  `json:"serial_number"`
+	Brand            string  `json:"brand"`
+	PurchaseDate     string  `json:"purchase_date"`
+	PurchaseCost     float64 `json:"purchase_cost"`
+	SalvageValue     float64 `json:"salvage_value"`
+	UsefulLifeMonths int     `json:"useful_life_months"`
+	WarrantyExpiry   string  `json:"warranty_expiry"`
+	Condition        string  `json:"condition"`
+}
+
+type MoveFacilityAssetBody struct {
+	RoomID *uuid.UUID `json:"room_id"`
+	Reason string     `json:"reason"`
+}
+
+type FacilityAssetResponse struct {
+	ID               uuid.UUID                  `json:"id"`
+	FacilityID       uuid.UUID                  `json:"facility_id"`
+	FacilityName     string                     `json:"facility_name"`
+	RoomID           *uuid.UUID                 `json:"room_id"`
+	RoomName         *string                    `json:"room_name"`
+	SerialNumber     string                     `json:"serial_number"`
+	Brand            string                     `json:"brand"`
+	PurchaseDate     *time.Time                 `json:"purchase_date"`
+	PurchaseCost     float64                    `json:"purchase_cost"`
+	SalvageValue     float64                    `json:"salvage_value"`
+	UsefulLifeMonths int                        `json:"useful_life_months"`
+	WarrantyExpiry   *time.Time                 `json:"warranty_expiry"`
+	Condition        string                     `json:"condition"`
+	RoomingHouse     TenantRoomingHouseResponse `json:"rooming_house" gorm:"embedded"`
+}
+
+type FacilityAssetDepreciation struct {
+	FacilityAssetResponse
+	AccumulatedDepreciation float64 `json:"accumulated_depreciation"`
+	BookValue               float64 `json:"book_value"`
+}
+
+type DepreciationReportResponse struct {
+	RoomingHouse                 TenantRoomingHouseResponse  `json:"rooming_house"`
+	AsOf                         time.Time                   `json:"as_of"`
+	TotalCost                    float64                     `json:"total_cost"`
+	TotalAccumulatedDepreciation float64                     `json:"total_accumulated_depreciation"`
+	TotalBookValue               float64                     `json:"total_book_value"`
+	Assets                       []FacilityAssetDepreciation `json:"assets"`
+}
+
+func (fa *FacilityAsset) BeforeCreate(tx *gorm.DB) (err error) {
+	fa.ID = uuid.New()
+	fa.CreatedAt = time.Now()
+
+	return
+}
+
+func (fam *FacilityAssetMovement) BeforeCreate(tx *gorm.DB) (err error) {
+	fam.ID = uuid.New()
+	fam.CreatedAt = time.Now()
+
+	return
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Invitation invites a new admin by email to one of the owner's rooming
+// houses. Only the hash of the single-use token is stored; the token itself
+// is only sent to the invitee. AdminID is set once it is accepted.
+type Invitation struct {
+	BaseModel
+	OwnerID        uuid.UUID  `json:"owner_id" gorm:"not null;size:191;index"`
+	RoomingHouseID uuid.UUID  `json:"rooming_house_id" gorm:"not null;size:191;index"`
+	RoleID         uuid.UUID  `json:"role_id" gorm:"not null;size:191"`
+	Email          string     `json:"email" gorm:"not null;size:191;index"`
+	FullName       string     `json:"full_name"`
+	TokenHash      string     `json:"-" gorm:"not null;uniqueIndex;size:64"`
+	ExpiresAt      time.Time  `json:"expires_at" gorm:"not null"`
+	AcceptedAt     *time.Time `json:"accepted_at"`
+	AdminID        *uuid.UUID `json:"admin_id" gorm:"size:191"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+}
+
+type InvitationBody struct {
+	Email          string     `json:"email"`
+	FullName       string     `json:"full_name"`
+	RoomingHouseID uuid.UUID  `json:"rooming_house_id"`
+	RoleID         *uuid.UUID `json:"role_id"`
+}
+
+type AcceptInvitationBody struct {
+	Token    string `json:"token"`
+	FullName string `json:"full_name"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type InvitationResponse struct {
+	ID               uuid.UUID `json:"id"`
+	Email            string    `json:"email"`
+	FullName         string    `json:"full_name"`
+	RoomingHouseID   uuid.UUID `json:"rooming_house_id"`
+	RoomingHouseName string    `json:"rooming_house_name"`
+	RoleID           uuid.UUID `json:"role_id"`
+	RoleName         string    `json:"role_name"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+func (i *Invitation) BeforeCreate(tx *gorm.DB) (err error) {
+	i.ID = uuid.New()
+	i.CreatedAt = time.Now()
+
+	return
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// LoginAttempt is one login of the login history. Failed attempts also drive
+// the throttling of the account and the IP address. UserID is only set when
+// the email belongs to an account.
+type LoginAttempt struct {
+	BaseModel
+	Email         string     `json:"email" gorm:"not null;size:191;index:idx_login_attempts_account"`
+	Role          string     `json:"role" gorm:"not null;size:20;index:idx_login_attempts_account"`
+	UserID        *uuid.UUID `json:"user_id" gorm:"size:191;index"`
+	IPAddress     string     `json:"ip_address" gorm:"not null;size:64;index"`
+	UserAgent     string     `json:"user_agent"`
+	Success       bool       `json:"success" gorm:"not null"`
+	FailureReason string     `json:"failure_reason"`
+}
+
+type LoginAttemptFilter struct {
+	UserID  *uuid.UUID
+	Success *bool
+	From    *time.Time
+	To      *time.Time
+	Limit   int
+}
+
+// LoginFailureSummary counts the recent failed logins of an account or IP address.
+type LoginFailureSummary struct {
+	Failures     int64
+	LastFailedAt *time.Time
+}
+
+func (la *LoginAttempt) BeforeCreate(tx *gorm.DB) (err error) {
+	la.ID = uuid.New()
+	la.CreatedAt = time.Now()
+
+	return
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaintenanceSchedule is a recurring service plan for a facility asset. The
+// asset is a facility inside a room when RoomID is set, otherwise it is a
+// shared facility of the rooming house.
+type MaintenanceSchedule struct {
+	BaseModel
+	Title           string     `json:"title" gorm:"not null"`
+	Description     string     `json:"description"`
+	RoomingHouseID  uuid.UUID  `json:"rooming_house_id" gorm:"not null;size:191;index"`
+	RoomID          *uuid.UUID `json:"room_id" gorm:"size:191"`
+	FacilityID      uuid.UUID  `json:"facility_id" gorm:"not null;size:191"`
+	IntervalUnit    string     `json:"interval_unit" gorm:"not null;size:16"`
+	IntervalCount   int        `json:"interval_count" gorm:"not null"`
+	LeadDays        int        `json:"lead_days"`
+	NextDueDate     time.Time  `json:"next_due_date" gorm:"not null;index"`
+	LastCompletedAt *time.Time `json:"last_completed_at"`
+	OpenWorkOrderID *uuid.UUID `json:"open_work_order_id" gorm:"size:191"`
+	IsActive        bool       `json:"is_active" gorm:"not null;default:true"`
+}
+
+// MaintenanceLog is one completed service of a maintenance schedule's asset.
+type MaintenanceLog struct {
+	BaseModel
+	MaintenanceScheduleID uuid.UUID  `json:"maintenance_schedule_id" gorm:"not null;size:191;index"`
+	RoomingHouseID        uuid.UUID  `json:"rooming_house_id" gorm:"not null;size:191"`
+	RoomID                *uuid.UUID `json:"room_id" gorm:"size:191"`
+	FacilityID            uuid.UUID  `json:"facility_id" gorm:"not null;size:191"`
+	WorkOrderID           *uuid.UUID `json:"work_order_id" gorm:"size:191"`
+	DueDate               time.Time  `json:"due_date" gorm:"not null"`
+	CompletedAt           time.Time  `json:"completed_at" gorm:"not null"`
+	CompletedBy           uuid.UUID  `json:"completed_by" gorm:"not null;size:191"`
+	CompleterRole         string     `json:"completer_role" gorm:"not null"`
+	Note                  string     `json:"note"`
+	Cost                  float64    `json:"cost"`
+}
+
+type AddMaintenanceScheduleBody struct {
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	RoomingHouseID uuid.UUID  `json:"rooming_house_id"`
+	RoomID         *uuid.UUID `json:"room_id"`
+	FacilityID     uuid.UUID  `json:"facility_id"`
+	IntervalUnit   string     `json:"interval_unit"`
+	IntervalCount  int        `json:"interval_count"`
+	LeadDays       int        `json:"lead_days"`
+	NextDueDate    string     `json:"next_due_date"`
+}
+
+type UpdateMaintenanceScheduleBody struct {
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	IntervalUnit  string `json:"interval_unit"`
+	IntervalCount int    `json:"interval_count"`
+	LeadDays      int    `json:"lead_days"`
+	NextDueDate   string `json:"next_due_date"`
+	IsActive      bool   `json:"is_active"`
+}
+
+type CompleteMaintenanceBody struct {
+	Note string  `json:"note"`
+	Cost float64 `json:"cost"`
+}
+
+type MaintenanceScheduleResponse struct {
+	ID              uuid.UUID                  `json:"id"`
+	Title           string                     `json:"title"`
+	Description     string                     `json:"description"`
+	RoomID          *uuid.UUID                 `json:"room_id"`
+	RoomName        *string                    `json:"room_name"`
+	FacilityID      uuid.UUID                  `json:"facility_id"`
+	FacilityName    string                     `json:"facility_name"`
+	IntervalUnit    string                     `json:"interval_unit"`
+	IntervalCount   int                        `json:"interval_count"`
+	LeadDays        int                        `json:"lead_days"`
+	NextDueDate     time.Time                  `json:"next_due_date"`
+	LastCompletedAt *time.Time                 `json:"last_completed_at"`
+	OpenWorkOrderID *uuid.UUID                 `json:"open_work_order_id"`
+	IsActive        bool                       `json:"is_active"`
+	IsOverdue       bool                       `json:"is_overdue" gorm:"-"`
+	RoomingHouse    TenantRoomingHouseResponse `json:"rooming_house" gorm:"embedded"`
+}
+
+type MaintenanceLogResponse struct {
+	ID                    uuid.UUID  `json:"id"`
+	MaintenanceScheduleID uuid.UUID  `json:"maintenance_schedule_id"`
+	ScheduleTitle         string     `json:"schedule_title"`
+	RoomID                *uuid.UUID `json:"room_id"`
+	RoomName              *string    `json:"room_name"`
+	FacilityID            uuid.UUID  `json:"facility_id"`
+	FacilityName          string     `json:"facility_name"`
+	WorkOrderID           *uuid.UUID `json:"work_order_id"`
+	DueDate               time.Time  `json:"due_date"`
+	CompletedAt           time.Time  `json:"completed_at"`
+	CompletedBy           uuid.UUID  `json:"completed_by"`
+	CompleterRole         string     `json:"completer_role"`
+	Note                  string     `json:"note"`
+	Cost                  float64    `json:"cost"`
+}
+
+func (ms *MaintenanceSchedule) BeforeCreate(tx *gorm.DB) (err error) {
+	ms.ID = uuid.New()
+	ms.CreatedAt = time.Now()
+
+	return
+}
+
+func (ml *MaintenanceLog) BeforeCreate(tx *gorm.DB) (err error) {
+	ml.ID = uuid.New()
+	ml.CreatedAt = time.Now()
+
+	return
+}
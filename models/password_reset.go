@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PasswordReset is a single-use reset token. Only the hash of the token is
+// stored; the token itself is only ever sent to the user's email.
+type PasswordReset struct {
+	BaseModel
+	UserID    uuid.UUID  `json:"user_id" gorm:"not null;size:191;index"`
+	Role      string     `json:"role" gorm:"not null"`
+	TokenHash string     `json:"-" gorm:"not null;uniqueIndex;size:64"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at"`
+}
+
+// PasswordResetRequest records a forgot password request, whether or not the
+// account exists, to throttle requests per email and per IP address.
+type PasswordResetRequest struct {
+	BaseModel
+	Email     string `json:"email" gorm:"not null;size:191;index"`
+	Role      string `json:"role" gorm:"not null"`
+	IPAddress string `json:"ip_address" gorm:"size:64;index"`
+}
+
+// PasswordResetRequestSummary counts the recent reset requests of an account
+// or IP address.
+type PasswordResetRequestSummary struct {
+	Requests         int64
+	FirstRequestedAt *time.Time
+}
+
+type ChangePasswordBody struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type ForgotPasswordBody struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+type ResetPasswordBody struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+func (pr *PasswordReset) BeforeCreate(tx *gorm.DB) (err error) {
+	pr.ID = uuid.New()
+	pr.CreatedAt = time.Now()
+
+	return
+}
+
+func (prr *PasswordResetRequest) BeforeCreate(tx *gorm.DB) (err error) {
+	prr.ID = uuid.New()
+	prr.CreatedAt = time.Now()
+
+	return
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PayrollRun is the payroll of a rooming house for one month. Approving it
+// posts a Salary expense transaction for every payslip.
+type PayrollRun struct {
+	BaseModel
+	RoomingHouseID  uuid.UUID  `json:"rooming_house_id" gorm:"not null;size:191;uniqueIndex:idx_payroll_period"`
+	Year            int        `json:"year" gorm:"not null;uniqueIndex:idx_payroll_period"`
+	Month           int        `json:"month" gorm:"not null;uniqueIndex:idx_payroll_period"`
+	Status          string     `json:"status" gorm:"not null;size:32"`
+	TotalGross      float64    `json:"total_gross" gorm:"not null"`
+	TotalDeductions float64    `json:"total_deductions" gorm:"not null"`
+	TotalNet        float64    `json:"total_net" gorm:"not null"`
+	CreatedByID     uuid.UUID  `json:"created_by_id" gorm:"not null;size:191"`
+	CreatedByRole   string     `json:"created_by_role" gorm:"not null"`
+	ApprovedAt      *time.Time `json:"approved_at"`
+	ApprovedByID    *uuid.UUID `json:"approved_by_id" gorm:"size:191"`
+	ApprovedByRole  string     `json:"approved_by_role"`
+	Payslips        []Payslip  `json:"payslips,omitempty" gorm:"foreignKey:PayrollRunID"`
+}
+
+// Payslip is the pay of one staff member in a payroll run. The staff details
+// and pay components are copied so later changes do not alter it.
+type Payslip struct {
+	BaseModel
+	PayrollRunID   uuid.UUID     `json:"payroll_run_id" gorm:"not null;size:191;index"`
+	StaffID        uuid.UUID     `json:"staff_id" gorm:"not null;size:191;index"`
+	RoomingHouseID uuid.UUID     `json:"rooming_house_id" gorm:"not null;size:191"`
+	Year           int           `json:"year" gorm:"not null"`
+	Month          int           `json:"month" gorm:"not null"`
+	StaffName      string        `json:"staff_name" gorm:"not null"`
+	Position       string        `json:"position" gorm:"not null"`
+	BaseSalary     float64       `json:"base_salary" gorm:"not null"`
+	DaysWorked     int           `json:"days_worked" gorm:"not null"`
+	DaysInMonth    int           `json:"days_in_month" gorm:"not null"`
+	Allowances     float64       `json:"allowances" gorm:"not null"`
+	Deductions     float64       `json:"deductions" gorm:"not null"`
+	NetPay         float64       `json:"net_pay" gorm:"not null"`
+	TransactionID  *uuid.UUID    `json:"transaction_id" gorm:"size:191"`
+	Items          []PayslipItem `json:"items" gorm:"foreignKey:PayslipID"`
+}
+
+type PayslipItem struct {
+	BaseModel
+	PayslipID uuid.UUID `json:"payslip_id" gorm:"not null;size:191;index"`
+	Name      string    `json:"name" gorm:"not null"`
+	Kind      string    `json:"kind" gorm:"not null;size:32"`
+	Amount    float64   `json:"amount" gorm:"not null"`
+}
+
+type PayrollRunBody struct {
+	RoomingHouseID uuid.UUID `json:"rooming_house_id"`
+	Year           int       `json:"year"`
+	Month          int       `json:"month"`
+}
+
+// PayslipResponse is a payslip with the run it belongs to, for printing.
+type PayslipResponse struct {
+	Payslip
+	RoomingHouseName string     `json:"rooming_house_name"`
+	Status           string     `json:"status"`
+	ApprovedAt       *time.Time `json:"approved_at"`
+}
+
+func (pr *PayrollRun) BeforeCreate(tx *gorm.DB) (err error) {
+	pr.ID = uuid.New()
+	pr.CreatedAt = time.Now()
+
+	return
+}
+
+func (p *Payslip) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	p.CreatedAt = time.Now()
+
+	return
+}
+
+func (pi *PayslipItem) BeforeCreate(tx *gorm.DB) (err error) {
+	pi.ID = uuid.New()
+	pi.CreatedAt = time.Now()
+
+	return
+}
@@ -11,15 +11,24 @@ type Period struct {
 	BaseModel
 	Name              string             `json:"name" gorm:"not null"`
 	Unit              string             `json:"unit" gorm:"not null"`
+	Count             int                `json:"count" gorm:"not null;default:1"`
+	OwnerID           *uuid.UUID         `json:"owner_id" gorm:"size:191;index"`
 	PeriodPackages    []PeriodPackage    `json:"period_packages" gorm:"foreignKey:PeriodID"`
 	Tenants           []Tenant           `json:"tenants" gorm:"foreignKey:PeriodID"`
 	AdditionalPeriods []AdditionalPeriod `json:"additional_periods" gorm:"foreignKey:PeriodID"`
 }
 
+type PeriodBody struct {
+	Name  string `json:"name"`
+	Unit  string `json:"unit"`
+	Count int    `json:"count"`
+}
+
 type PeriodResponse struct {
-	ID   uuid.UUID `json:"id" gorm:"column:period_id"`
-	Name string    `json:"name" gorm:"column:period_name"`
-	Unit string    `json:"unit" gorm:"column:period_unit"`
+	ID    uuid.UUID `json:"id" gorm:"column:period_id"`
+	Name  string    `json:"name" gorm:"column:period_name"`
+	Unit  string    `json:"unit" gorm:"column:period_unit"`
+	Count int       `json:"count" gorm:"column:period_count"`
 }
 
 func (p *Period) BeforeCreate(tx *gorm.DB) (err error) {
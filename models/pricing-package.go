@@ -16,20 +16,14 @@ type PricingPackage struct {
 }
 
 type AddPricingPackageBody struct {
-	Name           string    `json:"name"`
-	RoomingHouseID uuid.UUID `json:"rooming_house_id"`
-	DailyPrice     float64   `json:"daily_price"`
-	WeeklyPrice    float64   `json:"weekly_price"`
-	MonthlyPrice   float64   `json:"monthly_price"`
-	AnnualPrice    float64   `json:"annual_price"`
+	Name           string                `json:"name"`
+	RoomingHouseID uuid.UUID             `json:"rooming_house_id"`
+	Prices         map[uuid.UUID]float64 `json:"prices"`
 }
 
 type UpdatePricingPackageBody struct {
-	Name         string  `json:"name"`
-	DailyPrice   float64 `json:"daily_price"`
-	WeeklyPrice  float64 `json:"weekly_price"`
-	MonthlyPrice float64 `json:"monthly_price"`
-	AnnualPrice  float64 `json:"annual_price"`
+	Name   string                `json:"name"`
+	Prices map[uuid.UUID]float64 `json:"prices"`
 }
 
 type PackageResponse struct {
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecurringExpense is a template for an expense a rooming house pays every
+// month, such as the internet bill. Each month it creates a draft on
+// DayOfMonth that is confirmed into a transaction.
+type RecurringExpense struct {
+	BaseModel
+	Name                  string     `json:"name" gorm:"not null"`
+	RoomingHouseID        uuid.UUID  `json:"rooming_house_id" gorm:"not null;size:191;index"`
+	TransactionCategoryID uuid.UUID  `json:"transaction_category_id" gorm:"not null;size:191"`
+	Amount                float64    `json:"amount" gorm:"not null"`
+	DayOfMonth            int        `json:"day_of_month" gorm:"not null"`
+	EndDate               *time.Time `json:"end_date"`
+	NextDueDate           time.Time  `json:"next_due_date" gorm:"not null;index"`
+	IsActive              bool       `json:"is_active" gorm:"not null;default:true"`
+}
+
+// RecurringExpenseDraft is one month of a recurring expense waiting to be
+// confirmed. SkippedAt flags a draft whose month ended while it was pending.
+type RecurringExpenseDraft struct {
+	BaseModel
+	RecurringExpenseID    uuid.UUID  `json:"recurring_expense_id" gorm:"not null;size:191;uniqueIndex:idx_recurring_expense_due"`
+	RoomingHouseID        uuid.UUID  `json:"rooming_house_id" gorm:"not null;size:191;index"`
+	TransactionCategoryID uuid.UUID  `json:"transaction_category_id" gorm:"not null;size:191"`
+	Description           string     `json:"description"`
+	Amount                float64    `json:"amount" gorm:"not null"`
+	DueDate               time.Time  `json:"due_date" gorm:"not null;uniqueIndex:idx_recurring_expense_due"`
+	Status                string     `json:"status" gorm:"not null;size:32;index"`
+	SkippedAt             *time.Time `json:"skipped_at"`
+	TransactionID         *uuid.UUID `json:"transaction_id" gorm:"size:191"`
+	ResolvedAt            *time.Time `json:"resolved_at"`
+	ResolvedBy            *uuid.UUID `json:"resolved_by" gorm:"size:191"`
+	ResolverRole          string     `json:"resolver_role"`
+}
+
+type AddRecurringExpenseBody struct {
+	Name                  string    `json:"name"`
+	RoomingHouseID        uuid.UUID `json:"rooming_house_id"`
+	TransactionCategoryID uuid.UUID `json:"transaction_category_id"`
+	Amount                float64   `json:"amount"`
+	DayOfMonth            int       `json:"day_of_month"`
+	StartDate             string    `json:"start_date"`
+	EndDate               string    `json:"end_date"`
+}
+
+type UpdateRecurringExpenseBody struct {
+	Name                  string    `json:"name"`
+	TransactionCategoryID uuid.UUID `json:"transaction_category_id"`
+	Amount                float64   `json:"amount"`
+	DayOfMonth            int       `json:"day_of_month"`
+	EndDate               string    `json:"end_date"`
+	IsActive              bool      `json:"is_active"`
+}
+
+// ConfirmRecurringExpenseDraftBody adjusts the draft before it is posted.
+// Zero values keep what the draft has.
+type ConfirmRecurringExpenseDraftBody struct {
+	Amount      float64 `json:"amount"`
+	Date        string  `json:"date"`
+	Description string  `json:"description"`
+}
+
+type RecurringExpenseResponse struct {
+	ID                    uuid.UUID                  `json:"id"`
+	Name                  string                     `json:"name"`
+	TransactionCategoryID uuid.UUID                  `json:"transaction_category_id"`
+	CategoryName          string                     `json:"category_name"`
+	Amount                float64                    `json:"amount"`
+	DayOfMonth            int                        `json:"day_of_month"`
+	EndDate               *time.Time                 `json:"end_date"`
+	NextDueDate           time.Time                  `json:"next_due_date"`
+	IsActive              bool                       `json:"is_active"`
+	RoomingHouse          TenantRoomingHouseResponse `json:"rooming_house" gorm:"embedded"`
+}
+
+type RecurringExpenseDraftResponse struct {
+	ID                 uuid.UUID                  `json:"id"`
+	RecurringExpenseID uuid.UUID                  `json:"recurring_expense_id"`
+	Name               string                     `json:"name"`
+	CategoryName       string                     `json:"category_name"`
+	Description        string                     `json:"description"`
+	Amount             float64                    `json:"amount"`
+	DueDate            time.Time                  `json:"due_date"`
+	Status             string                     `json:"status"`
+	SkippedAt          *time.Time                 `json:"skipped_at"`
+	TransactionID      *uuid.UUID                 `json:"transaction_id"`
+	ResolvedAt         *time.Time                 `json:"resolved_at"`
+	RoomingHouse       TenantRoomingHouseResponse `json:"rooming_house" gorm:"embedded"`
+}
+
+func (re *RecurringExpense) BeforeCreate(tx *gorm.DB) (err error) {
+	re.ID = uuid.New()
+	re.CreatedAt = time.Now()
+
+	return
+}
+
+func (red *RecurringExpenseDraft) BeforeCreate(tx *gorm.DB) (err error) {
+	red.ID = uuid.New()
+	red.CreatedAt = time.Now()
+
+	return
+}
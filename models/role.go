@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Role is a named set of permissions assigned to staff per rooming house.
+// System roles have a code and no owner; owners can define their own roles.
+type Role struct {
+	BaseModel
+	OwnerID     *uuid.UUID       `json:"owner_id" gorm:"size:191;index"`
+	Code        string           `json:"code" gorm:"size:32;index"`
+	Name        string           `json:"name" gorm:"not null"`
+	Description string           `json:"description"`
+	Permissions []RolePermission `json:"permissions" gorm:"foreignKey:RoleID"`
+}
+
+type RolePermission struct {
+	BaseModel
+	RoleID   uuid.UUID `json:"role_id" gorm:"not null;size:191;index"`
+	Resource string    `json:"resource" gorm:"not null;size:64"`
+	Action   string    `json:"action" gorm:"not null;size:16"`
+}
+
+// RoleBody lists permissions in "resource:action" form, e.g. "tenants:write".
+type RoleBody struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type PermissionResponse struct {
+	Resource string   `json:"resource"`
+	Actions  []string `json:"actions"`
+}
+
+func (r *Role) BeforeCreate(tx *gorm.DB) (err error) {
+	r.ID = uuid.New()
+	r.CreatedAt = time.Now()
+
+	return
+}
+
+func (rp *RolePermission) BeforeCreate(tx *gorm.DB) (err error) {
+	rp.ID = uuid.New()
+	rp.CreatedAt = time.Now()
+
+	return
+}
@@ -16,6 +16,7 @@ type Room struct {
 	SizeID         uuid.UUID     `json:"size_id" gorm:"not null;size:191"`
 	PackageID      uuid.UUID     `json:"package_id" gorm:"not null;size:191"`
 	RoomingHouseID uuid.UUID     `json:"rooming_house_id" gorm:"not null;size:191"`
+	Status         string        `json:"status" gorm:"not null;default:available"`
 	Facilities     []Facility    `gorm:"many2many:room_facilities;foreignKey:ID;joinForeignKey:RoomID;References:ID;joinReferences:FacilityID"`
 	Tenants        *Tenant       `json:"tenant" gorm:"foreignKey:RoomID"`
 	Transactions   []Transaction `json:"transactions" gorm:"foreignKey:RoomID"`
@@ -46,6 +47,8 @@ type AllRoomResponse struct {
 	Floor          int                  `json:"floor" gorm:"column:floor_number"`
 	MaxCapacity    int                  `json:"max_capacity" gorm:"column:max_capacity"`
 	RoomingHouseID uuid.UUID            `json:"rooming_house_id" gorm:"column:rooming_house_id"`
+	Status         string               `json:"status" gorm:"column:status"`
+	IsAvailable    bool                 `json:"is_available"`
 	Tenants        GetAllTenantResponse `json:"tenants"`
 }
 
@@ -56,6 +59,7 @@ type RoomDetailResponse struct {
 	MaxCapacity    int                       `json:"max_capacity"`
 	Size           Size                      `json:"size"`
 	RoomingHouseID uuid.UUID                 `json:"rooming_house_id"`
+	Status         string                    `json:"status"`
 	PricingPackage PackageResponse           `json:"pricing_package"`
 	Tenants        *TenantRoomDetailResponse `json:"tenants"`
 	Facilities     []Facility                `json:"facilities"`
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RoomStatusHistory struct {
+	BaseModel
+	RoomID      uuid.UUID `json:"room_id" gorm:"not null;size:191"`
+	FromStatus  string    `json:"from_status" gorm:"not null"`
+	ToStatus    string    `json:"to_status" gorm:"not null"`
+	Reason      string    `json:"reason"`
+	ChangedBy   uuid.UUID `json:"changed_by" gorm:"not null;size:191"`
+	ChangerRole string    `json:"changer_role" gorm:"not null"`
+}
+
+type UpdateRoomStatusBody struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+func (rsh *RoomStatusHistory) BeforeCreate(tx *gorm.DB) (err error) {
+	rsh.ID = uuid.New()
+	rsh.CreatedAt = time.Now()
+
+	return
+}
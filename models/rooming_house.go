@@ -17,7 +17,6 @@ type RoomingHouse struct {
 	Transactions []Transaction `json:"transactions" gorm:"foreignKey:RoomingHouseID"`
 	Facilities   []Facility    `gorm:"many2many:rooming_house_facilities;foreignKey:ID;joinForeignKey:RoomingHouseID;References:ID;joinReferences:FacilityID"`
 	Rooms        []Room        `json:"rooms" gorm:"foreignKey:RoomingHouseID"`
-	Admin        Admin         `json:"admin" gorm:"foreignKey:RoomingHouseID"`
 }
 
 type RoomingHouseBody struct {
@@ -39,16 +38,15 @@ type AllRoomingHouseResponse struct {
 }
 
 type RoomingHouseByIDResponse struct {
-	ID           uuid.UUID     `json:"id"`
-	Name         string        `json:"name"`
-	Description  string        `json:"description"`
-	Address      string        `json:"address"`
-	FloorTotal   int           `json:"floor_total"`
-	OwnerID      uuid.UUID     `json:"owner_id"`
-	Admin        AdminResponse `json:"admin"`
-	Transactions []Transaction `json:"transactions"`
-	Facilities   []Facility    `json:"facilities"`
-	Rooms        []Room        `json:"rooms"`
+	ID          uuid.UUID       `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Address     string          `json:"address"`
+	FloorTotal  int             `json:"floor_total"`
+	OwnerID     uuid.UUID       `json:"owner_id"`
+	Admins      []AdminResponse `json:"admins"`
+	Facilities  []Facility      `json:"facilities"`
+	Rooms       []Room          `json:"rooms"`
 }
 
 type TenantRoomingHouseResponse struct {
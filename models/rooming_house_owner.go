@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RoomingHouseOwner is an owner's share of a rooming house. Every owner of a
+// house has one, the primary owner in RoomingHouse.OwnerID included, and the
+// percentages of a house add up to 100. Owner-wide data such as categories and
+// the blacklist used by the house stay those of the primary owner.
+type RoomingHouseOwner struct {
+	BaseModel
+	RoomingHouseID uuid.UUID `json:"rooming_house_id" gorm:"not null;size:191;uniqueIndex:idx_rooming_house_owner"`
+	OwnerID        uuid.UUID `json:"owner_id" gorm:"not null;size:191;uniqueIndex:idx_rooming_house_owner;index"`
+	Percentage     float64   `json:"percentage" gorm:"not null"`
+}
+
+type RoomingHouseOwnerShareBody struct {
+	Email      string  `json:"email"`
+	Percentage float64 `json:"percentage"`
+}
+
+type UpdateRoomingHouseOwnersBody struct {
+	Owners []RoomingHouseOwnerShareBody `json:"owners"`
+}
+
+type RoomingHouseOwnerResponse struct {
+	RoomingHouseID uuid.UUID `json:"rooming_house_id"`
+	OwnerID        uuid.UUID `json:"owner_id"`
+	FullName       string    `json:"full_name"`
+	Email          string    `json:"email"`
+	Percentage     float64   `json:"percentage"`
+	IsPrimary      bool      `json:"is_primary"`
+}
+
+func (rho *RoomingHouseOwner) BeforeCreate(tx *gorm.DB) (err error) {
+	rho.ID = uuid.New()
+	rho.CreatedAt = time.Now()
+
+	return
+}
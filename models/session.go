@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Session is one login of a user. It stores the hash of the current refresh
+// token, which is replaced on every refresh; access tokens reference the
+// session so revoking it locks them out immediately.
+type Session struct {
+	BaseModel
+	UserID           uuid.UUID  `json:"user_id" gorm:"not null;size:191;index"`
+	Role             string     `json:"role" gorm:"not null"`
+	RoomingHouseID   uuid.UUID  `json:"rooming_house_id" gorm:"size:191"`
+	RefreshTokenHash string     `json:"-" gorm:"not null;size:64"`
+	ExpiresAt        time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt        *time.Time `json:"revoked_at"`
+}
+
+type RefreshTokenBody struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type TokenResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+func (s *Session) BeforeCreate(tx *gorm.DB) (err error) {
+	s.ID = uuid.New()
+	s.CreatedAt = time.Now()
+
+	return
+}
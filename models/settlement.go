@@ -0,0 +1,146 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ManagementFee is the commission the manager of a rooming house keeps before
+// the rest of the month's net income is paid out to the owners.
+type ManagementFee struct {
+	BaseModel
+	RoomingHouseID uuid.UUID `json:"rooming_house_id" gorm:"not null;size:191;uniqueIndex"`
+	FeeType        string    `json:"fee_type" gorm:"not null"`
+	Value          float64   `json:"value" gorm:"not null"`
+}
+
+type ManagementFeeBody struct {
+	FeeType string  `json:"fee_type"`
+	Value   float64 `json:"value"`
+}
+
+// Settlement is the closed books of a rooming house for one month: the
+// income and expense at the time it was settled, the management fee taken and
+// what is left for the owners. The fee terms are copied so later changes to
+// the house's fee do not alter it.
+type Settlement struct {
+	BaseModel
+	RoomingHouseID uuid.UUID          `json:"rooming_house_id" gorm:"not null;size:191;uniqueIndex:idx_settlement_period"`
+	Year           int                `json:"year" gorm:"not null;uniqueIndex:idx_settlement_period"`
+	Month          int                `json:"month" gorm:"not null;uniqueIndex:idx_settlement_period"`
+	Income         float64            `json:"income" gorm:"not null"`
+	Expense        float64            `json:"expense" gorm:"not null"`
+	NetIncome      float64            `json:"net_income" gorm:"not null"`
+	FeeType        string             `json:"fee_type"`
+	FeeValue       float64            `json:"fee_value" gorm:"not null"`
+	ManagementFee  float64            `json:"management_fee" gorm:"not null"`
+	OwnerPayout    float64            `json:"owner_payout" gorm:"not null"`
+	CreatedByID    uuid.UUID          `json:"created_by_id" gorm:"not null;size:191"`
+	CreatedByRole  string             `json:"created_by_role" gorm:"not null"`
+	Lines          []SettlementLine   `json:"lines,omitempty" gorm:"foreignKey:SettlementID"`
+	Payouts        []SettlementPayout `json:"payouts,omitempty" gorm:"foreignKey:SettlementID"`
+}
+
+// SettlementLine is the total of one transaction category in a settlement.
+type SettlementLine struct {
+	BaseModel
+	SettlementID          uuid.UUID `json:"settlement_id" gorm:"not null;size:191;index"`
+	TransactionCategoryID uuid.UUID `json:"transaction_category_id" gorm:"not null;size:191"`
+	CategoryName          string    `json:"category_name" gorm:"not null"`
+	IsExpense             bool      `json:"is_expense" gorm:"not null"`
+	Amount                float64   `json:"amount" gorm:"not null"`
+}
+
+// SettlementPayout is the part of a settlement owed to one owner, by their
+// ownership share. PaidAt is set once the transfer is recorded.
+type SettlementPayout struct {
+	BaseModel
+	SettlementID uuid.UUID  `json:"settlement_id" gorm:"not null;size:191;index"`
+	OwnerID      uuid.UUID  `json:"owner_id" gorm:"not null;size:191;index"`
+	Percentage   float64    `json:"percentage" gorm:"not null"`
+	Amount       float64    `json:"amount" gorm:"not null"`
+	PaidAt       *time.Time `json:"paid_at"`
+	Reference    string     `json:"reference"`
+	PaidByID     *uuid.UUID `json:"paid_by_id" gorm:"size:191"`
+}
+
+type SettlementBody struct {
+	RoomingHouseID uuid.UUID `json:"rooming_house_id"`
+	Year           int       `json:"year"`
+	Month          int       `json:"month"`
+}
+
+type RecordPayoutBody struct {
+	PaidAt    string `json:"paid_at"`
+	Reference string `json:"reference"`
+}
+
+// TransactionCategoryTotal is the sum of a rooming house's transactions of one
+// category over a month.
+type TransactionCategoryTotal struct {
+	TransactionCategoryID uuid.UUID `json:"transaction_category_id"`
+	CategoryName          string    `json:"category_name"`
+	IsExpense             bool      `json:"is_expense"`
+	Amount                float64   `json:"amount"`
+}
+
+type SettlementPayoutResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	OwnerID    uuid.UUID  `json:"owner_id"`
+	OwnerName  string     `json:"owner_name"`
+	Percentage float64    `json:"percentage"`
+	Amount     float64    `json:"amount"`
+	PaidAt     *time.Time `json:"paid_at"`
+	Reference  string     `json:"reference"`
+}
+
+// SettlementStatementResponse is the printable statement of a settlement.
+type SettlementStatementResponse struct {
+	ID               uuid.UUID                  `json:"id"`
+	RoomingHouseID   uuid.UUID                  `json:"rooming_house_id"`
+	RoomingHouseName string                     `json:"rooming_house_name"`
+	Year             int                        `json:"year"`
+	Month            int                        `json:"month"`
+	Income           float64                    `json:"income"`
+	Expense          float64                    `json:"expense"`
+	NetIncome        float64                    `json:"net_income"`
+	FeeType          string                     `json:"fee_type"`
+	FeeValue         float64                    `json:"fee_value"`
+	ManagementFee    float64                    `json:"management_fee"`
+	OwnerPayout      float64                    `json:"owner_payout"`
+	PaidTotal        float64                    `json:"paid_total"`
+	Outstanding      float64                    `json:"outstanding"`
+	SettledAt        time.Time                  `json:"settled_at"`
+	Lines            []SettlementLine           `json:"lines"`
+	Payouts          []SettlementPayoutResponse `json:"payouts"`
+}
+
+func (mf *ManagementFee) BeforeCreate(tx *gorm.DB) (err error) {
+	mf.ID = uuid.New()
+	mf.CreatedAt = time.Now()
+
+	return
+}
+
+func (s *Settlement) BeforeCreate(tx *gorm.DB) (err error) {
+	s.ID = uuid.New()
+	s.CreatedAt = time.Now()
+
+	return
+}
+
+func (sl *SettlementLine) BeforeCreate(tx *gorm.DB) (err error) {
+	sl.ID = uuid.New()
+	sl.CreatedAt = time.Now()
+
+	return
+}
+
+func (sp *SettlementPayout) BeforeCreate(tx *gorm.DB) (err error) {
+	sp.ID = uuid.New()
+	sp.CreatedAt = time.Now()
+
+	return
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Staff is someone on the payroll of a rooming house. Cleaners and guards do
+// not log in; staff who do can be linked to their admin account with AdminID
+// to see their own payslips.
+type Staff struct {
+	BaseModel
+	RoomingHouseID uuid.UUID           `json:"rooming_house_id" gorm:"not null;size:191;index"`
+	AdminID        *uuid.UUID          `json:"admin_id" gorm:"size:191;index"`
+	Name           string              `json:"name" gorm:"not null"`
+	Position       string              `json:"position" gorm:"not null"`
+	PhoneNumber    string              `json:"phone_number"`
+	BaseSalary     float64             `json:"base_salary" gorm:"not null"`
+	JoinedAt       *time.Time          `json:"joined_at"`
+	InactiveAt     *time.Time          `json:"inactive_at"`
+	Components     []StaffPayComponent `json:"components" gorm:"foreignKey:StaffID"`
+}
+
+// StaffPayComponent is an allowance or deduction applied to every payslip of
+// the staff member on top of the base salary.
+type StaffPayComponent struct {
+	BaseModel
+	StaffID uuid.UUID `json:"staff_id" gorm:"not null;size:191;index"`
+	Name    string    `json:"name" gorm:"not null"`
+	Kind    string    `json:"kind" gorm:"not null;size:32"`
+	Amount  float64   `json:"amount" gorm:"not null"`
+}
+
+type StaffPayComponentBody struct {
+	Name   string  `json:"name"`
+	Kind   string  `json:"kind"`
+	Amount float64 `json:"amount"`
+}
+
+type AddStaffBody struct {
+	RoomingHouseID uuid.UUID               `json:"rooming_house_id"`
+	AdminID        *uuid.UUID              `json:"admin_id"`
+	Name           string                  `json:"name"`
+	Position       string                  `json:"position"`
+	PhoneNumber    string                  `json:"phone_number"`
+	BaseSalary     float64                 `json:"base_salary"`
+	JoinedAt       string                  `json:"joined_at"`
+	Components     []StaffPayComponentBody `json:"components"`
+}
+
+// UpdateStaffBody replaces the staff member's details and pay components.
+// Active false takes them off the payroll from the next run; leaving it out
+// keeps their status.
+type UpdateStaffBody struct {
+	AdminID     *uuid.UUID              `json:"admin_id"`
+	Name        string                  `json:"name"`
+	Position    string                  `json:"position"`
+	PhoneNumber string                  `json:"phone_number"`
+	BaseSalary  float64                 `json:"base_salary"`
+	JoinedAt    string                  `json:"joined_at"`
+	Active      *bool                   `json:"active"`
+	Components  []StaffPayComponentBody `json:"components"`
+}
+
+func (s *Staff) BeforeCreate(tx *gorm.DB) (err error) {
+	s.ID = uuid.New()
+	s.CreatedAt = time.Now()
+
+	return
+}
+
+func (spc *StaffPayComponent) BeforeCreate(tx *gorm.DB) (err error) {
+	spc.ID = uuid.New()
+	spc.CreatedAt = time.Now()
+
+	return
+}
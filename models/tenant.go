@@ -13,6 +13,11 @@ type Tenant struct {
 	Gender                 string            `json:"gender" gorm:"not null"`
 	PhoneNumber            string            `json:"phoneNumber" gorm:"not null"`
 	EmergencyContact       string            `json:"emergencyContact"`
+	IDNumber               string            `json:"id_number" gorm:"size:32;index"`
+	DateOfBirth            *time.Time        `json:"date_of_birth"`
+	Occupation             string            `json:"occupation"`
+	Institution            string            `json:"institution"`
+	OriginAddress          string            `json:"origin_address"`
 	StartDate              *time.Time        `json:"start_date"`
 	EndDate                *time.Time        `json:"end_date"`
 	RegularPaymentDuration int               `json:"regular_payment_duration"`
@@ -25,20 +30,42 @@ type Tenant struct {
 	RoomID                 *uuid.UUID        `json:"room_id" gorm:"size:191"`
 	Transactions           []Transaction     `json:"transactions" gorm:"foreignKey:TenantID;references:ID"`
 	AdditionalPrices       []AdditionalPrice `json:"additional_prices" gorm:"many2many:tenant_additional_prices;joinForeignKey:TenantID;joinReferences:AdditionalPriceID"`
+	Vehicles               []TenantVehicle   `json:"vehicles" gorm:"foreignKey:TenantID"`
 }
 
 type AddTenantBody struct {
-	Name                   string      `json:"name"`
-	Gender                 string      `json:"gender"`
-	PhoneNumber            string      `json:"phoneNumber"`
-	EmergencyContact       string      `json:"emergencyContact"`
-	IsTenant               bool        `json:"is_tenant"`
-	RegularPaymentDuration int         `json:"regular_payment_duration"`
-	RoomingHouseID         uuid.UUID   `json:"rooming_house_id"`
-	RoomID                 *uuid.UUID  `json:"room_id"`
-	PeriodID               *uuid.UUID  `json:"period_id"`
-	TenantID               uuid.UUID   `json:"tenant_id"`
-	TenantAdditionalIDs    []uuid.UUID `json:"tenant_additional_ids"`
+	Name                   string              `json:"name"`
+	Gender                 string              `json:"gender"`
+	PhoneNumber            string              `json:"phoneNumber"`
+	EmergencyContact       string              `json:"emergencyContact"`
+	IDNumber               string              `json:"id_number"`
+	DateOfBirth            string              `json:"date_of_birth"`
+	Occupation             string              `json:"occupation"`
+	Institution            string              `json:"institution"`
+	OriginAddress          string              `json:"origin_address"`
+	IsTenant               bool                `json:"is_tenant"`
+	RegularPaymentDuration int                 `json:"regular_payment_duration"`
+	RoomingHouseID         uuid.UUID           `json:"rooming_house_id"`
+	RoomID                 *uuid.UUID          `json:"room_id"`
+	PeriodID               *uuid.UUID          `json:"period_id"`
+	TenantID               uuid.UUID           `json:"tenant_id"`
+	TenantAdditionalIDs    []uuid.UUID         `json:"tenant_additional_ids"`
+	Vehicles               []TenantVehicleBody `json:"vehicles"`
+	OverrideJustification  string              `json:"override_justification"`
+}
+
+type UpdateTenantBody struct {
+	Name                  string              `json:"name"`
+	Gender                string              `json:"gender"`
+	PhoneNumber           string              `json:"phoneNumber"`
+	EmergencyContact      string              `json:"emergencyContact"`
+	IDNumber              string              `json:"id_number"`
+	DateOfBirth           string              `json:"date_of_birth"`
+	Occupation            string              `json:"occupation"`
+	Institution           string              `json:"institution"`
+	OriginAddress         string              `json:"origin_address"`
+	Vehicles              []TenantVehicleBody `json:"vehicles"`
+	OverrideJustification string              `json:"override_justification"`
 }
 
 type GetAllTenantResponse struct {
@@ -68,6 +95,11 @@ type TenantDetailResponse struct {
 	Gender                 string                     `json:"gender"`
 	PhoneNumber            string                     `json:"phoneNumber"`
 	EmergencyContact       string                     `json:"emergencyContact"`
+	IDNumber               string                     `json:"id_number"`
+	DateOfBirth            *time.Time                 `json:"date_of_birth"`
+	Occupation             string                     `json:"occupation"`
+	Institution            string                     `json:"institution"`
+	OriginAddress          string                     `json:"origin_address"`
 	BookedRoomID           uuid.UUID                  `json:"booked_room_id"`
 	StartDate              *time.Time                 `json:"start_date"`
 	EndDate                *time.Time                 `json:"end_date"`
@@ -82,6 +114,7 @@ type TenantDetailResponse struct {
 	Room                   TenantRoomResponse         `json:"room" gorm:"embedded"`
 	Transactions           []TransactionResponse      `json:"transactions" gorm:"-"`
 	AdditionalPrices       []AdditionalPriceDetail    `json:"additional_prices" gorm:"-"`
+	Vehicles               []TenantVehicleResponse    `json:"vehicles" gorm:"-"`
 }
 
 type TenantRoomDetailResponse struct {
@@ -90,6 +123,9 @@ type TenantRoomDetailResponse struct {
 	Gender                 string                 `json:"gender"`
 	PhoneNumber            string                 `json:"phoneNumber"`
 	EmergencyContact       string                 `json:"emergencyContact"`
+	IDNumber               string                 `json:"id_number"`
+	Occupation             string                 `json:"occupation"`
+	Institution            string                 `json:"institution"`
 	IsTenant               bool                   `json:"is_tenant"`
 	StartDate              *time.Time             `json:"start_date"`
 	EndDate                *time.Time             `json:"end_date"`
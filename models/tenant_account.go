@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+type TenantAccount struct {
+	BaseModel
+	TenantID       uuid.UUID `json:"tenant_id" gorm:"not null;uniqueIndex;size:191"`
+	Email          string    `json:"email" gorm:"not null;uniqueIndex;size:191"`
+	Password       string    `json:"password" gorm:"not null"`
+	Role           string    `json:"role" gorm:"not null"`
+	RoomingHouseID uuid.UUID `json:"rooming_house_id" gorm:"not null;size:191"`
+}
+
+type TenantAccountBody struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type TenantAccountResponse struct {
+	ID             uuid.UUID `json:"id"`
+	TenantID       uuid.UUID `json:"tenant_id"`
+	Email          string    `json:"email"`
+	RoomingHouseID uuid.UUID `json:"rooming_house_id"`
+}
+
+type TenantPortalRoomResponse struct {
+	ID             uuid.UUID                  `json:"id"`
+	Name           string                     `json:"name"`
+	Floor          int                        `json:"floor"`
+	Size           Size                       `json:"size"`
+	RoomingHouse   TenantRoomingHouseResponse `json:"rooming_house"`
+	PricingPackage PackageResponse            `json:"pricing_package"`
+	Facilities     []Facility                 `json:"facilities"`
+}
+
+type TenantPortalChargeResponse struct {
+	Period                 PeriodResponse          `json:"period"`
+	RegularPaymentDuration int                     `json:"regular_payment_duration"`
+	CurrentStartDate       *time.Time              `json:"current_start_date"`
+	CurrentEndDate         *time.Time              `json:"current_end_date"`
+	UpcomingCharges        []TenantUpcomingCharge  `json:"upcoming_charges"`
+	AdditionalPrices       []AdditionalPriceDetail `json:"additional_prices"`
+}
+
+type TenantUpcomingCharge struct {
+	DueDate        time.Time `json:"due_date"`
+	CoverStartDate time.Time `json:"cover_start_date"`
+	CoverEndDate   time.Time `json:"cover_end_date"`
+	RentAmount     float64   `json:"rent_amount"`
+	AdditionalFee  float64   `json:"additional_fee"`
+	TotalAmount    float64   `json:"total_amount"`
+}
+
+type TenantReceiptResponse struct {
+	ReceiptNumber string                     `json:"receipt_number"`
+	TransactionID uuid.UUID                  `json:"transaction_id"`
+	Day           int                        `json:"day"`
+	Month         int                        `json:"month"`
+	Year          int                        `json:"year"`
+	Amount        float64                    `json:"amount"`
+	Description   string                     `json:"description"`
+	Category      string                     `json:"category"`
+	TenantName    string                     `json:"tenant_name"`
+	RoomName      string                     `json:"room_name"`
+	RoomingHouse  TenantRoomingHouseResponse `json:"rooming_house" gorm:"embedded"`
+	IssuedAt      time.Time                  `json:"issued_at"`
+}
+
+func (ta *TenantAccount) BeforeCreate(tx *gorm.DB) (err error) {
+	ta.ID = uuid.New()
+	ta.CreatedAt = time.Now()
+
+	hasedPassword, _ := bcrypt.GenerateFromPassword([]byte(ta.Password), 14)
+
+	ta.Password = string(hasedPassword)
+	return
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TenantBlacklist struct {
+	BaseModel
+	OwnerID        uuid.UUID  `json:"owner_id" gorm:"not null;size:191;index"`
+	RoomingHouseID uuid.UUID  `json:"rooming_house_id" gorm:"not null;size:191"`
+	TenantID       *uuid.UUID `json:"tenant_id" gorm:"size:191"`
+	Name           string     `json:"name" gorm:"not null"`
+	PhoneNumber    string     `json:"phoneNumber" gorm:"size:32;index"`
+	IDNumber       string     `json:"id_number" gorm:"size:32;index"`
+	Reason         string     `json:"reason" gorm:"not null"`
+	Evidence       string     `json:"evidence"`
+	CreatedBy      uuid.UUID  `json:"created_by" gorm:"not null;size:191"`
+}
+
+type AddTenantBlacklistBody struct {
+	RoomingHouseID uuid.UUID  `json:"rooming_house_id"`
+	TenantID       *uuid.UUID `json:"tenant_id"`
+	Name           string     `json:"name"`
+	PhoneNumber    string     `json:"phoneNumber"`
+	IDNumber       string     `json:"id_number"`
+	Reason         string     `json:"reason"`
+	Evidence       string     `json:"evidence"`
+}
+
+type TenantBlacklistResponse struct {
+	ID           uuid.UUID                  `json:"id"`
+	TenantID     *uuid.UUID                 `json:"tenant_id"`
+	Name         string                     `json:"name"`
+	PhoneNumber  string                     `json:"phoneNumber"`
+	IDNumber     string                     `json:"id_number"`
+	Reason       string                     `json:"reason"`
+	Evidence     string                     `json:"evidence"`
+	CreatedBy    uuid.UUID                  `json:"created_by"`
+	CreatedAt    time.Time                  `json:"created_at"`
+	RoomingHouse TenantRoomingHouseResponse `json:"rooming_house" gorm:"embedded"`
+}
+
+// TenantScreeningMatch is a blacklist entry or an active tenant that shares a
+// phone number or ID number with a tenant being registered.
+type TenantScreeningMatch struct {
+	Source       string                     `json:"source"`
+	ID           uuid.UUID                  `json:"id"`
+	Name         string                     `json:"name"`
+	PhoneNumber  string                     `json:"phoneNumber"`
+	IDNumber     string                     `json:"id_number"`
+	MatchedOn    []string                   `json:"matched_on" gorm:"-"`
+	Reason       string                     `json:"reason,omitempty"`
+	RoomingHouse TenantRoomingHouseResponse `json:"rooming_house" gorm:"embedded"`
+}
+
+func (tb *TenantBlacklist) BeforeCreate(tx *gorm.DB) (err error) {
+	tb.ID = uuid.New()
+	tb.CreatedAt = time.Now()
+
+	return
+}
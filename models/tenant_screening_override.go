@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TenantScreeningOverride records an owner's decision to register a tenant
+// despite screening matches, together with the justification given.
+type TenantScreeningOverride struct {
+	BaseModel
+	TenantID       uuid.UUID `json:"tenant_id" gorm:"not null;size:191"`
+	OwnerID        uuid.UUID `json:"owner_id" gorm:"not null;size:191;index"`
+	RoomingHouseID uuid.UUID `json:"rooming_house_id" gorm:"not null;size:191"`
+	Matches        string    `json:"matches" gorm:"type:text;not null"`
+	Justification  string    `json:"justification" gorm:"type:text;not null"`
+	ApprovedBy     uuid.UUID `json:"approved_by" gorm:"not null;size:191"`
+}
+
+type TenantScreeningOverrideResponse struct {
+	ID            uuid.UUID                  `json:"id"`
+	TenantID      uuid.UUID                  `json:"tenant_id"`
+	TenantName    string                     `json:"tenant_name"`
+	Matches       string                     `json:"matches"`
+	Justification string                     `json:"justification"`
+	ApprovedBy    uuid.UUID                  `json:"approved_by"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	RoomingHouse  TenantRoomingHouseResponse `json:"rooming_house" gorm:"embedded"`
+}
+
+func (tso *TenantScreeningOverride) BeforeCreate(tx *gorm.DB) (err error) {
+	tso.ID = uuid.New()
+	tso.CreatedAt = time.Now()
+
+	return
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TenantVehicle struct {
+	BaseModel
+	TenantID    uuid.UUID `json:"tenant_id" gorm:"not null;size:191"`
+	PlateNumber string    `json:"plate_number" gorm:"not null;size:16"`
+	VehicleType string    `json:"vehicle_type"`
+}
+
+type TenantVehicleBody struct {
+	PlateNumber string `json:"plate_number"`
+	VehicleType string `json:"vehicle_type"`
+}
+
+type TenantVehicleResponse struct {
+	ID          uuid.UUID `json:"id"`
+	PlateNumber string    `json:"plate_number"`
+	VehicleType string    `json:"vehicle_type"`
+}
+
+func (tv *TenantVehicle) BeforeCreate(tx *gorm.DB) (err error) {
+	tv.ID = uuid.New()
+	tv.CreatedAt = time.Now()
+
+	return
+}
@@ -35,13 +35,15 @@ type AddTransactionBody struct {
 }
 
 type TransactionResponse struct {
-	ID           uuid.UUID                  `json:"id"`
-	Day          int                        `json:"day"`
-	Month        int                        `json:"month"`
-	Year         int                        `json:"year"`
-	Amount       float64                    `json:"amount"`
-	RoomingHouse TenantRoomingHouseResponse `json:"rooming_house" gorm:"embedded"`
-	Category     TransactionCategoryBody    `json:"category" gorm:"embedded"`
+	ID                    uuid.UUID                  `json:"id"`
+	Day                   int                        `json:"day"`
+	Month                 int                        `json:"month"`
+	Year                  int                        `json:"year"`
+	Amount                float64                    `json:"amount"`
+	TransactionCategoryID uuid.UUID                  `json:"transaction_category_id"`
+	RoomingHouse          TenantRoomingHouseResponse `json:"rooming_house" gorm:"embedded"`
+	Category              TransactionCategoryBody    `json:"category" gorm:"embedded"`
+	CategoryCode          string                     `json:"category_code" gorm:"column:transaction_category_code"`
 }
 
 type TransactionDashboardResponse struct {
@@ -52,6 +54,28 @@ type TransactionDashboardResponse struct {
 	Expense float64 `json:"expense"`
 }
 
+// ProfitLossResponse is the income and expense of a rooming house over a year,
+// or one month of it. Shares split it by the ownership of the house.
+type ProfitLossResponse struct {
+	RoomingHouseID   uuid.UUID                 `json:"rooming_house_id"`
+	RoomingHouseName string                    `json:"rooming_house_name"`
+	Year             int                       `json:"year"`
+	Month            int                       `json:"month,omitempty"`
+	Income           float64                   `json:"income"`
+	Expense          float64                   `json:"expense"`
+	Net              float64                   `json:"net"`
+	Shares           []ProfitLossShareResponse `json:"shares,omitempty"`
+}
+
+type ProfitLossShareResponse struct {
+	OwnerID    uuid.UUID `json:"owner_id"`
+	FullName   string    `json:"full_name"`
+	Percentage float64   `json:"percentage"`
+	Income     float64   `json:"income"`
+	Expense    float64   `json:"expense"`
+	Net        float64   `json:"net"`
+}
+
 func (t *Transaction) BeforeCreate(tx *gorm.DB) (err error) {
 	t.ID = uuid.New()
 	t.CreatedAt = time.Now()
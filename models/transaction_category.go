@@ -7,11 +7,17 @@ import (
 	"gorm.io/gorm"
 )
 
+// TransactionCategory is global when OwnerID is nil, otherwise it belongs to
+// the owner and, when RoomingHouseID is set, only to that rooming house.
+// System categories carry a Code and are never editable.
 type TransactionCategory struct {
 	BaseModel
-	Name         string        `json:"name" gorm:"not null"`
-	IsExpense    bool          `json:"is_expense" gorm:"not null"`
-	Transactions []Transaction `json:"transactions" gorm:"foreignKey:TransactionCategoryID"`
+	Name           string        `json:"name" gorm:"not null"`
+	Code           string        `json:"code" gorm:"size:32;index"`
+	IsExpense      bool          `json:"is_expense" gorm:"not null"`
+	OwnerID        *uuid.UUID    `json:"owner_id" gorm:"size:191;index"`
+	RoomingHouseID *uuid.UUID    `json:"rooming_house_id" gorm:"size:191"`
+	Transactions   []Transaction `json:"transactions" gorm:"foreignKey:TransactionCategoryID"`
 }
 
 type TransactionCategoryBody struct {
@@ -19,6 +25,14 @@ type TransactionCategoryBody struct {
 	IsExpense bool   `json:"is_expense" gorm:"column:transaction_category_is_expense"`
 }
 
+// AddTransactionCategoryBody creates an owner-wide category, or one limited to
+// RoomingHouseID when set. Admins always create for their own rooming house.
+type AddTransactionCategoryBody struct {
+	Name           string     `json:"name"`
+	IsExpense      bool       `json:"is_expense"`
+	RoomingHouseID *uuid.UUID `json:"rooming_house_id"`
+}
+
 func (tc *TransactionCategory) BeforeCreate(tx *gorm.DB) (err error) {
 	tc.ID = uuid.New()
 	tc.CreatedAt = time.Now()
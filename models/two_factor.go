@@ -0,0 +1,103 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TwoFactor is the TOTP enrollment of an owner or admin. It is pending until
+// the first code is confirmed and EnabledAt is set; only enabled enrollments
+// are asked for at login.
+type TwoFactor struct {
+	BaseModel
+	UserID        uuid.UUID               `json:"user_id" gorm:"not null;size:191;index"`
+	Role          string                  `json:"role" gorm:"not null"`
+	Secret        string                  `json:"-" gorm:"not null"`
+	EnabledAt     *time.Time              `json:"enabled_at"`
+	LastUsedStep  int64                   `json:"-" gorm:"not null;default:0"`
+	RecoveryCodes []TwoFactorRecoveryCode `json:"-" gorm:"foreignKey:TwoFactorID"`
+}
+
+// TwoFactorRecoveryCode is a single-use code that replaces a TOTP code when
+// the authenticator is lost. Only its hash is stored.
+type TwoFactorRecoveryCode struct {
+	BaseModel
+	TwoFactorID uuid.UUID  `json:"two_factor_id" gorm:"not null;size:191;index"`
+	CodeHash    string     `json:"-" gorm:"not null;size:64"`
+	UsedAt      *time.Time `json:"used_at"`
+}
+
+// LoginChallenge is the pending second step of a login whose password was
+// accepted. The token is returned instead of a JWT and exchanged for one once
+// the TOTP or recovery code is verified.
+type LoginChallenge struct {
+	BaseModel
+	Email          string     `json:"email" gorm:"not null"`
+	UserID         uuid.UUID  `json:"user_id" gorm:"not null;size:191;index"`
+	Role           string     `json:"role" gorm:"not null"`
+	RoomingHouseID uuid.UUID  `json:"rooming_house_id" gorm:"size:191"`
+	TokenHash      string     `json:"-" gorm:"not null;uniqueIndex;size:64"`
+	Attempts       int        `json:"attempts" gorm:"not null;default:0"`
+	ExpiresAt      time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt         *time.Time `json:"used_at"`
+}
+
+type TwoFactorCodeBody struct {
+	Code string `json:"code"`
+}
+
+type DisableTwoFactorBody struct {
+	Password     string `json:"password"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+type VerifyLoginBody struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recovery_code"`
+}
+
+type TwoFactorStatusResponse struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabled_at"`
+	RecoveryCodesRemaining int64      `json:"recovery_codes_remaining"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type LoginChallengeResponse struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
+	ExpiresIn         int64  `json:"expires_in"`
+}
+
+func (tf *TwoFactor) BeforeCreate(tx *gorm.DB) (err error) {
+	tf.ID = uuid.New()
+	tf.CreatedAt = time.Now()
+
+	return
+}
+
+func (rc *TwoFactorRecoveryCode) BeforeCreate(tx *gorm.DB) (err error) {
+	rc.ID = uuid.New()
+	rc.CreatedAt = time.Now()
+
+	return
+}
+
+func (lc *LoginChallenge) BeforeCreate(tx *gorm.DB) (err error) {
+	lc.ID = uuid.New()
+	lc.CreatedAt = time.Now()
+
+	return
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WorkOrder struct {
+	BaseModel
+	Title                  string     `json:"title" gorm:"not null"`
+	Description            string     `json:"description"`
+	Status                 string     `json:"status" gorm:"not null;size:32;index"`
+	Priority               string     `json:"priority" gorm:"not null;size:32"`
+	Assignee               string     `json:"assignee"`
+	RoomingHouseID         uuid.UUID  `json:"rooming_house_id" gorm:"not null;size:191;index"`
+	RoomID                 *uuid.UUID `json:"room_id" gorm:"size:191"`
+	RoomingHouseFacilityID *uuid.UUID `json:"rooming_house_facility_id" gorm:"size:191"`
+	ReportedBy             uuid.UUID  `json:"reported_by" gorm:"not null;size:191"`
+	ReporterRole           string     `json:"reporter_role" gorm:"not null"`
+	ResolutionNote         string     `json:"resolution_note"`
+	Cost                   float64    `json:"cost"`
+	TransactionID          *uuid.UUID `json:"transaction_id" gorm:"size:191"`
+	MaintenanceScheduleID  *uuid.UUID `json:"maintenance_schedule_id" gorm:"size:191"`
+	AssignedAt             *time.Time `json:"assigned_at"`
+	StartedAt              *time.Time `json:"started_at"`
+	CompletedAt            *time.Time `json:"completed_at"`
+}
+
+type AddWorkOrderBody struct {
+	Title                  string     `json:"title"`
+	Description            string     `json:"description"`
+	Priority               string     `json:"priority"`
+	RoomingHouseID         uuid.UUID  `json:"rooming_house_id"`
+	RoomID                 *uuid.UUID `json:"room_id"`
+	RoomingHouseFacilityID *uuid.UUID `json:"rooming_house_facility_id"`
+}
+
+type UpdateWorkOrderBody struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Priority    string `json:"priority"`
+}
+
+type UpdateWorkOrderStatusBody struct {
+	Status                string     `json:"status"`
+	Assignee              string     `json:"assignee"`
+	ResolutionNote        string     `json:"resolution_note"`
+	Cost                  float64    `json:"cost"`
+	CreateExpense         bool       `json:"create_expense"`
+	TransactionCategoryID *uuid.UUID `json:"transaction_category_id"`
+}
+
+type WorkOrderResponse struct {
+	ID                     uuid.UUID                  `json:"id"`
+	Title                  string                     `json:"title"`
+	Description            string                     `json:"description"`
+	Status                 string                     `json:"status"`
+	Priority               string                     `json:"priority"`
+	Assignee               string                     `json:"assignee"`
+	RoomID                 *uuid.UUID                 `json:"room_id"`
+	RoomName               *string                    `json:"room_name"`
+	RoomingHouseFacilityID *uuid.UUID                 `json:"rooming_house_facility_id"`
+	FacilityName           *string                    `json:"facility_name"`
+	ReportedBy             uuid.UUID                  `json:"reported_by"`
+	ReporterRole           string                     `json:"reporter_role"`
+	ResolutionNote         string                     `json:"resolution_note"`
+	Cost                   float64                    `json:"cost"`
+	TransactionID          *uuid.UUID                 `json:"transaction_id"`
+	MaintenanceScheduleID  *uuid.UUID                 `json:"maintenance_schedule_id"`
+	AssignedAt             *time.Time                 `json:"assigned_at"`
+	StartedAt              *time.Time                 `json:"started_at"`
+	CompletedAt            *time.Time                 `json:"completed_at"`
+	CreatedAt              time.Time                  `json:"created_at"`
+	RoomingHouse           TenantRoomingHouseResponse `json:"rooming_house" gorm:"embedded"`
+}
+
+func (wo *WorkOrder) BeforeCreate(tx *gorm.DB) (err error) {
+	wo.ID = uuid.New()
+	wo.CreatedAt = time.Now()
+
+	return
+}
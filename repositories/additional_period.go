@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"errors"
 	"rooming-house-cms-be/models"
 
@@ -9,9 +10,9 @@ import (
 )
 
 type AdditionalPeriodRepository interface {
-	CreateAdditionalPeriod(additionalPeriod *[]models.AdditionalPeriod) error
+	CreateAdditionalPeriod(ctx context.Context, additionalPeriod *[]models.AdditionalPeriod) error
 	FindPrice(periodID uuid.UUID, additionalPriceID uuid.UUID) (*models.AdditionalPeriod, error)
-	UpdateAdditionalPeriod(additionalPeriod *[]models.AdditionalPeriod, additionalPriceID uuid.UUID) error
+	UpdateAdditionalPeriod(ctx context.Context, additionalPeriod *[]models.AdditionalPeriod, additionalPriceID uuid.UUID) error
 }
 
 type additionalPeriodRepository struct {
@@ -22,8 +23,8 @@ func NewAdditionalPeriodRepository(db *gorm.DB) AdditionalPeriodRepository {
 	return &additionalPeriodRepository{db: db}
 }
 
-func (r *additionalPeriodRepository) CreateAdditionalPeriod(additionalPeriod *[]models.AdditionalPeriod) error {
-	if err := r.db.Create(additionalPeriod).Error; err != nil {
+func (r *additionalPeriodRepository) CreateAdditionalPeriod(ctx context.Context, additionalPeriod *[]models.AdditionalPeriod) error {
+	if err := r.db.WithContext(ctx).Create(additionalPeriod).Error; err != nil {
 		return err
 	}
 	return nil
@@ -37,8 +38,8 @@ func (r *additionalPeriodRepository) FindPrice(periodID uuid.UUID, additionalPri
 	return &additionalPeriod, nil
 }
 
-func (r *additionalPeriodRepository) UpdateAdditionalPeriod(additionalPeriod *[]models.AdditionalPeriod, additionalPriceID uuid.UUID) error {
-	tx := r.db.Begin()
+func (r *additionalPeriodRepository) UpdateAdditionalPeriod(ctx context.Context, additionalPeriod *[]models.AdditionalPeriod, additionalPriceID uuid.UUID) error {
+	tx := r.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
 		return tx.Error
 	}
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"rooming-house-cms-be/models"
 
 	"github.com/google/uuid"
@@ -8,11 +9,11 @@ import (
 )
 
 type AdditionalPriceRepository interface {
-	CreateAdditionalPrice(additionalPrice *models.AdditionalPrice) error
+	CreateAdditionalPrice(ctx context.Context, additionalPrice *models.AdditionalPrice) error
 	FindAdditionalPriceByID(id uuid.UUID) (*models.AdditionalPriceResponse, error)
 	FindAllAdditionalPrices(roomingHouseIDs []uuid.UUID) (*[]models.AdditionalPriceResponse, error)
-	UpdateAdditionalPriceByID(additionalPrice *models.AdditionalPrice, id uuid.UUID) error
-	DeleteAdditionalPriceByID(id uuid.UUID) error
+	UpdateAdditionalPriceByID(ctx context.Context, additionalPrice *models.AdditionalPrice, id uuid.UUID) error
+	DeleteAdditionalPriceByID(ctx context.Context, id uuid.UUID) error
 }
 
 type additionalPriceRepository struct {
@@ -23,8 +24,8 @@ func NewAdditionalPriceRepository(db *gorm.DB) AdditionalPriceRepository {
 	return &additionalPriceRepository{db: db}
 }
 
-func (r *additionalPriceRepository) CreateAdditionalPrice(additionalPrice *models.AdditionalPrice) error {
-	if err := r.db.Create(additionalPrice).Error; err != nil {
+func (r *additionalPriceRepository) CreateAdditionalPrice(ctx context.Context, additionalPrice *models.AdditionalPrice) error {
+	if err := r.db.WithContext(ctx).Create(additionalPrice).Error; err != nil {
 		return err
 	}
 	return nil
@@ -37,10 +38,10 @@ func (r *additionalPriceRepository) FindAdditionalPriceByID(id uuid.UUID) (*mode
 	}
 
 	response := models.AdditionalPriceResponse{
-		ID:   additionalPrice.ID,
-		Name: additionalPrice.Name,
-		// RoomingHouseID: additionalPrice.RoomingHouseID,
-		Prices: make(map[string]float64),
+		ID:           additionalPrice.ID,
+		Name:         additionalPrice.Name,
+		RoomingHouse: models.TenantRoomingHouseResponse{ID: additionalPrice.RoomingHouseID},
+		Prices:       make(map[string]float64),
 	}
 
 	for _, period := range additionalPrice.AdditionalPeriods {
@@ -94,8 +95,8 @@ func (r *additionalPriceRepository) FindAllAdditionalPrices(roomingHouseIDs []uu
 	return &responses, nil
 }
 
-func (r *additionalPriceRepository) UpdateAdditionalPriceByID(additionalPrice *models.AdditionalPrice, id uuid.UUID) error {
-	res := r.db.Where("id = ?", id).Updates(additionalPrice)
+func (r *additionalPriceRepository) UpdateAdditionalPriceByID(ctx context.Context, additionalPrice *models.AdditionalPrice, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("id = ?", id).Updates(additionalPrice)
 	if res.Error != nil {
 		return res.Error
 	}
@@ -107,8 +108,8 @@ func (r *additionalPriceRepository) UpdateAdditionalPriceByID(additionalPrice *m
 	return nil
 }
 
-func (r *additionalPriceRepository) DeleteAdditionalPriceByID(id uuid.UUID) error {
-	res := r.db.Where("id = ?", id).Delete(&models.AdditionalPrice{})
+func (r *additionalPriceRepository) DeleteAdditionalPriceByID(ctx context.Context, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.AdditionalPrice{})
 	if res.Error != nil {
 		return res.Error
 	}
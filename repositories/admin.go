@@ -1,18 +1,30 @@
 package repositories
 
 import (
+	"context"
 	"errors"
 	"rooming-house-cms-be/models"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type AdminRepository interface {
-	CreateAdmin(user *models.Admin) error
 	FindAdminByEmail(email string) (*models.Admin, error)
+	FindAdminByID(id uuid.UUID) (*models.Admin, error)
+	FindAdminByUsername(username string) (*models.Admin, error)
+	UpdateAdminPassword(ctx context.Context, id uuid.UUID, passwordHash string) error
+	UpdateAdmin(ctx context.Context, admin *models.Admin) error
+	SetAdminDisabled(ctx context.Context, id uuid.UUID, disabledAt *time.Time) error
 	FindAllAdmin(roomingHouseIDs []uuid.UUID) (*[]models.GetAllAdminResponse, error)
-	DeleteAdminByID(id uuid.UUID) error
+	FindAdminRoomingHouseIDs(adminID uuid.UUID) ([]uuid.UUID, error)
+	FindAdminRoomingHouse(adminID uuid.UUID, roomingHouseID uuid.UUID) (*models.AdminRoomingHouse, error)
+	AssignAdminRoomingHouse(ctx context.Context, adminRoomingHouse *models.AdminRoomingHouse) error
+	UpdateAdminRoomingHouseRole(ctx context.Context, adminID uuid.UUID, roomingHouseID uuid.UUID, roleID uuid.UUID) error
+	UnassignAdminRoomingHouse(ctx context.Context, adminID uuid.UUID, roomingHouseID uuid.UUID) error
+	MoveAdminRoomingHouse(ctx context.Context, adminID uuid.UUID, fromRoomingHouseID uuid.UUID, toRoomingHouseID uuid.UUID, roleID uuid.UUID) error
+	DeleteAdminByID(ctx context.Context, id uuid.UUID) error
 }
 
 type adminRepository struct {
@@ -23,13 +35,6 @@ func NewAdminRepository(db *gorm.DB) AdminRepository {
 	return &adminRepository{db: db}
 }
 
-func (r *adminRepository) CreateAdmin(admin *models.Admin) error {
-	if err := r.db.Create(admin).Error; err != nil {
-		return err
-	}
-	return nil
-}
-
 func (r *adminRepository) FindAdminByEmail(email string) (*models.Admin, error) {
 	var admin models.Admin
 	if err := r.db.Where("email = ?", email).First(&admin).Error; err != nil {
@@ -38,54 +43,176 @@ func (r *adminRepository) FindAdminByEmail(email string) (*models.Admin, error)
 	return &admin, nil
 }
 
+func (r *adminRepository) FindAdminByUsername(username string) (*models.Admin, error) {
+	var admin models.Admin
+	if err := r.db.Where("username = ?", username).First(&admin).Error; err != nil {
+		return nil, errors.New("admin not found")
+	}
+	return &admin, nil
+}
+
+func (r *adminRepository) FindAdminByID(id uuid.UUID) (*models.Admin, error) {
+	var admin models.Admin
+	if err := r.db.Where("id = ?", id).First(&admin).Error; err != nil {
+		return nil, errors.New("admin not found")
+	}
+	return &admin, nil
+}
+
+func (r *adminRepository) UpdateAdminPassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	return r.db.WithContext(ctx).Model(&models.Admin{}).Where("id = ?", id).Update("password", passwordHash).Error
+}
+
+// UpdateAdmin saves the admin's name, username and email.
+func (r *adminRepository) UpdateAdmin(ctx context.Context, admin *models.Admin) error {
+	return r.db.WithContext(ctx).Model(&models.Admin{}).Where("id = ?", admin.ID).Updates(map[string]interface{}{
+		"full_name": admin.FullName,
+		"username":  admin.Username,
+		"email":     admin.Email,
+	}).Error
+}
+
+// SetAdminDisabled disables the admin at disabledAt, or enables it again when
+// disabledAt is nil.
+func (r *adminRepository) SetAdminDisabled(ctx context.Context, id uuid.UUID, disabledAt *time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.Admin{}).Where("id = ?", id).Update("disabled_at", disabledAt).Error
+}
+
+// FindAllAdmin returns the admins assigned to any of the rooming houses, each
+// with all of its assignments and roles within those houses.
 func (r *adminRepository) FindAllAdmin(roomingHouseIDs []uuid.UUID) (*[]models.GetAllAdminResponse, error) {
 	var rawResults []struct {
-		ID               uuid.UUID `json:"id"`
-		FullName         string    `json:"full_name"`
-		Username         string    `json:"username"`
-		Role             string    `json:"role"`
-		RoomingHouseID   uuid.UUID `json:"rooming_house_id"`
-		RoomingHouseName string    `json:"rooming_house_name"`
+		ID               uuid.UUID  `json:"id"`
+		FullName         string     `json:"full_name"`
+		Username         string     `json:"username"`
+		Email            string     `json:"email"`
+		Role             string     `json:"role"`
+		DisabledAt       *time.Time `json:"disabled_at"`
+		RoomingHouseID   uuid.UUID  `json:"rooming_house_id"`
+		RoomingHouseName string     `json:"rooming_house_name"`
+		RoleID           *uuid.UUID `json:"role_id"`
+		RoleName         string     `json:"role_name"`
 	}
 
-	var admins []models.GetAllAdminResponse
+	admins := []models.GetAllAdminResponse{}
 
 	if err := r.db.Table("admins").
-		Select("admins.id, admins.full_name, admins.username, admins.role, admins.rooming_house_id AS rooming_house_id, rooming_houses.name AS rooming_house_name").
-		Joins("JOIN rooming_houses ON admins.rooming_house_id = rooming_houses.id").
-		Where("admins.rooming_house_id IN (?)", roomingHouseIDs).
+		Select("admins.id, admins.full_name, admins.username, admins.email, admins.role, admins.disabled_at, arh.rooming_house_id AS rooming_house_id, rooming_houses.name AS rooming_house_name, arh.role_id AS role_id, roles.name AS role_name").
+		Joins("JOIN admin_rooming_houses arh ON arh.admin_id = admins.id AND arh.deleted_at IS NULL").
+		Joins("JOIN rooming_houses ON arh.rooming_house_id = rooming_houses.id").
+		Joins("LEFT JOIN roles ON roles.id = arh.role_id").
+		Where("arh.rooming_house_id IN (?) AND admins.deleted_at IS NULL", roomingHouseIDs).
+		Order("admins.full_name, rooming_houses.name").
 		Scan(&rawResults).Error; err != nil {
 		return nil, err
 	}
 
+	indexByID := map[uuid.UUID]int{}
 	for _, rawResult := range rawResults {
-		admins = append(admins, models.GetAllAdminResponse{
-			ID:       rawResult.ID,
-			FullName: rawResult.FullName,
-			Username: rawResult.Username,
-			Role:     rawResult.Role,
-			RoomingHouse: models.TenantRoomingHouseResponse{
-				ID:   rawResult.RoomingHouseID,
-				Name: rawResult.RoomingHouseName,
-			},
+		index, ok := indexByID[rawResult.ID]
+		if !ok {
+			index = len(admins)
+			indexByID[rawResult.ID] = index
+			admins = append(admins, models.GetAllAdminResponse{
+				ID:            rawResult.ID,
+				FullName:      rawResult.FullName,
+				Username:      rawResult.Username,
+				Email:         rawResult.Email,
+				Role:          rawResult.Role,
+				DisabledAt:    rawResult.DisabledAt,
+				RoomingHouses: []models.AdminRoomingHouseResponse{},
+			})
+		}
+
+		admins[index].RoomingHouses = append(admins[index].RoomingHouses, models.AdminRoomingHouseResponse{
+			ID:       rawResult.RoomingHouseID,
+			Name:     rawResult.RoomingHouseName,
+			RoleID:   rawResult.RoleID,
+			RoleName: rawResult.RoleName,
 		})
 	}
 
 	return &admins, nil
 }
 
-func (r *adminRepository) DeleteAdminByID(id uuid.UUID) error {
-	res := r.db.Where("id = ?", id).Delete(&models.Admin{})
+func (r *adminRepository) FindAdminRoomingHouseIDs(adminID uuid.UUID) ([]uuid.UUID, error) {
+	var roomingHouseIDs []uuid.UUID
+	if err := r.db.Table("admin_rooming_houses arh").
+		Joins("JOIN rooming_houses rh ON rh.id = arh.rooming_house_id AND rh.deleted_at IS NULL").
+		Where("arh.admin_id = ? AND arh.deleted_at IS NULL", adminID).
+		Order("arh.created_at").
+		Pluck("arh.rooming_house_id", &roomingHouseIDs).Error; err != nil {
+		return nil, err
+	}
+	return roomingHouseIDs, nil
+}
+
+func (r *adminRepository) FindAdminRoomingHouse(adminID uuid.UUID, roomingHouseID uuid.UUID) (*models.AdminRoomingHouse, error) {
+	var adminRoomingHouse models.AdminRoomingHouse
+	if err := r.db.Where("admin_id = ? AND rooming_house_id = ?", adminID, roomingHouseID).First(&adminRoomingHouse).Error; err != nil {
+		return nil, err
+	}
+	return &adminRoomingHouse, nil
+}
+
+func (r *adminRepository) AssignAdminRoomingHouse(ctx context.Context, adminRoomingHouse *models.AdminRoomingHouse) error {
+	if err := r.db.WithContext(ctx).Create(adminRoomingHouse).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *adminRepository) UpdateAdminRoomingHouseRole(ctx context.Context, adminID uuid.UUID, roomingHouseID uuid.UUID, roleID uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&models.AdminRoomingHouse{}).
+		Where("admin_id = ? AND rooming_house_id = ?", adminID, roomingHouseID).
+		Update("role_id", roleID).Error
+}
+
+func (r *adminRepository) UnassignAdminRoomingHouse(ctx context.Context, adminID uuid.UUID, roomingHouseID uuid.UUID) error {
+	// Hard delete so the admin can be assigned to the house again later
+	res := r.db.WithContext(ctx).Unscoped().Where("admin_id = ? AND rooming_house_id = ?", adminID, roomingHouseID).Delete(&models.AdminRoomingHouse{})
 	if res.Error != nil {
-		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
-			return errors.New("admin not found")
-		}
 		return res.Error
 	}
 
 	if res.RowsAffected == 0 {
-		return errors.New("admin not found")
+		return gorm.ErrRecordNotFound
 	}
 
 	return nil
 }
+
+// MoveAdminRoomingHouse replaces the admin's assignment to one rooming house
+// with an assignment to another, with the given role.
+func (r *adminRepository) MoveAdminRoomingHouse(ctx context.Context, adminID uuid.UUID, fromRoomingHouseID uuid.UUID, toRoomingHouseID uuid.UUID, roleID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Unscoped().Where("admin_id = ? AND rooming_house_id = ?", adminID, fromRoomingHouseID).Delete(&models.AdminRoomingHouse{})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Create(&models.AdminRoomingHouse{AdminID: adminID, RoomingHouseID: toRoomingHouseID, RoleID: &roleID}).Error
+	})
+}
+
+func (r *adminRepository) DeleteAdminByID(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ?", id).Delete(&models.Admin{})
+		if res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return errors.New("admin not found")
+			}
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return errors.New("admin not found")
+		}
+
+		return tx.Unscoped().Where("admin_id = ?", id).Delete(&models.AdminRoomingHouse{}).Error
+	})
+}
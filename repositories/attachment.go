@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AttachmentRepository interface {
+	CreateAttachment(ctx context.Context, attachment *models.Attachment) error
+	FindAttachmentByID(id uuid.UUID) (*models.Attachment, error)
+	FindAllAttachments(entityType string, entityID uuid.UUID) (*[]models.AttachmentResponse, error)
+	FindEntityRoomingHouseID(entityType string, entityID uuid.UUID) (uuid.UUID, error)
+	DeleteAttachmentByID(ctx context.Context, id uuid.UUID) error
+}
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+func (r *attachmentRepository) CreateAttachment(ctx context.Context, attachment *models.Attachment) error {
+	if err := r.db.WithContext(ctx).Create(attachment).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *attachmentRepository) FindAttachmentByID(id uuid.UUID) (*models.Attachment, error) {
+	var attachment models.Attachment
+	if err := r.db.Where("id = ?", id).First(&attachment).Error; err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *attachmentRepository) FindAllAttachments(entityType string, entityID uuid.UUID) (*[]models.AttachmentResponse, error) {
+	var attachments []models.AttachmentResponse
+	if err := r.db.Model(&models.Attachment{}).
+		Select("id, entity_type, entity_id, rooming_house_id, file_name, mime_type, size, description, uploaded_by, created_at").
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("created_at DESC").
+		Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return &attachments, nil
+}
+
+func (r *attachmentRepository) FindEntityRoomingHouseID(entityType string, entityID uuid.UUID) (uuid.UUID, error) {
+	table, ok := constants.AttachmentEntityTables[entityType]
+	if !ok {
+		return uuid.Nil, errors.New("invalid entity type")
+	}
+
+	var result struct {
+		RoomingHouseID uuid.UUID
+	}
+
+	res := r.db.Table(table).
+		Select("rooming_house_id").
+		Where("id = ? AND deleted_at IS NULL", entityID).
+		Limit(1).
+		Scan(&result)
+	if res.Error != nil {
+		return uuid.Nil, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return uuid.Nil, gorm.ErrRecordNotFound
+	}
+
+	return result.RoomingHouseID, nil
+}
+
+func (r *attachmentRepository) DeleteAttachmentByID(ctx context.Context, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Attachment{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"rooming-house-cms-be/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepository interface {
+	FindAllAuditLogs(ownerID uuid.UUID, filter models.AuditLogFilter) (*[]models.AuditLog, error)
+}
+
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepository {
+	return &auditLogRepository{db: db}
+}
+
+// FindAllAuditLogs returns the newest audit entries of the owner's data and
+// of the rooming houses the owner holds a share of that match the filter.
+func (r *auditLogRepository) FindAllAuditLogs(ownerID uuid.UUID, filter models.AuditLogFilter) (*[]models.AuditLog, error) {
+	query := r.db.Where("owner_id = ? OR rooming_house_id IN (?)", ownerID, coOwnedRoomingHouseIDs(r.db, ownerID))
+
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+
+	if filter.RoomingHouseID != nil {
+		query = query.Where("rooming_house_id = ?", *filter.RoomingHouseID)
+	}
+
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var auditLogs []models.AuditLog
+	if err := query.Order("created_at DESC").Limit(filter.Limit).Find(&auditLogs).Error; err != nil {
+		return nil, err
+	}
+	return &auditLogs, nil
+}
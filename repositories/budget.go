@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"rooming-house-cms-be/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BudgetRepository interface {
+	ReplaceBudgets(ctx context.Context, roomingHouseID uuid.UUID, transactionCategoryID uuid.UUID, year int, budgets []models.Budget) error
+	FindAllBudgets(roomingHouseIDs []uuid.UUID, year int) (*[]models.BudgetResponse, error)
+	FindBudgetByID(id uuid.UUID) (*models.Budget, error)
+	DeleteBudgetByID(ctx context.Context, id uuid.UUID) error
+}
+
+type budgetRepository struct {
+	db *gorm.DB
+}
+
+func NewBudgetRepository(db *gorm.DB) BudgetRepository {
+	return &budgetRepository{db: db}
+}
+
+// ReplaceBudgets replaces every month of the category's budget for the year.
+func (r *budgetRepository) ReplaceBudgets(ctx context.Context, roomingHouseID uuid.UUID, transactionCategoryID uuid.UUID, year int, budgets []models.Budget) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Hard delete so the months can be budgeted again
+		if err := tx.Unscoped().
+			Where("rooming_house_id = ? AND transaction_category_id = ? AND year = ?", roomingHouseID, transactionCategoryID, year).
+			Delete(&models.Budget{}).Error; err != nil {
+			return err
+		}
+
+		if len(budgets) == 0 {
+			return nil
+		}
+
+		return tx.Create(&budgets).Error
+	})
+}
+
+func (r *budgetRepository) FindAllBudgets(roomingHouseIDs []uuid.UUID, year int) (*[]models.BudgetResponse, error) {
+	budgets := []models.BudgetResponse{}
+
+	if err := r.db.Table("budgets b").
+		Select("b.id, b.transaction_category_id, tc.name AS category_name, tc.is_expense, b.year, b.month, b.amount, b.alert_threshold, rh.id AS rooming_house_id, rh.name AS rooming_house_name").
+		Joins("JOIN rooming_houses rh ON b.rooming_house_id = rh.id").
+		Joins("JOIN transaction_categories tc ON b.transaction_category_id = tc.id").
+		Where("b.rooming_house_id IN (?) AND b.year = ? AND b.deleted_at IS NULL", roomingHouseIDs, year).
+		Order("rh.name, tc.is_expense DESC, tc.name, b.month").
+		Scan(&budgets).Error; err != nil {
+		return nil, err
+	}
+
+	return &budgets, nil
+}
+
+func (r *budgetRepository) FindBudgetByID(id uuid.UUID) (*models.Budget, error) {
+	var budget models.Budget
+	if err := r.db.Where("id = ?", id).First(&budget).Error; err != nil {
+		return nil, err
+	}
+	return &budget, nil
+}
+
+// DeleteBudgetByID removes one month of a budget. It is a hard delete so the
+// month can be budgeted again.
+func (r *budgetRepository) DeleteBudgetByID(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Unscoped().Where("id = ?", id).Delete(&models.Budget{}).Error
+}
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"rooming-house-cms-be/models"
 
 	"github.com/google/uuid"
@@ -8,8 +9,13 @@ import (
 )
 
 type FacilityRepository interface {
-	GetAllFacilities() (*[]models.Facility, error)
+	GetAllFacilities(ownerID uuid.UUID) (*[]models.Facility, error)
 	GetFacilityByID(id uuid.UUID) (*models.Facility, error)
+	FindFacilityByName(name string, ownerID uuid.UUID, excludedID uuid.UUID) (*models.Facility, error)
+	CountFacilityReferences(id uuid.UUID) (int64, error)
+	CreateFacility(ctx context.Context, facility *models.Facility) error
+	UpdateFacilityByID(ctx context.Context, facility *models.Facility, id uuid.UUID) error
+	DeleteFacilityByID(ctx context.Context, id uuid.UUID) error
 }
 
 type facilityRepository struct {
@@ -20,9 +26,11 @@ func NewFacilityRepository(db *gorm.DB) FacilityRepository {
 	return &facilityRepository{db: db}
 }
 
-func (r *facilityRepository) GetAllFacilities() (*[]models.Facility, error) {
+// GetAllFacilities returns the global facilities together with the custom
+// facilities of the given owner.
+func (r *facilityRepository) GetAllFacilities(ownerID uuid.UUID) (*[]models.Facility, error) {
 	var facilities []models.Facility
-	if err := r.db.Find(&facilities).Error; err != nil {
+	if err := r.db.Where("owner_id IS NULL OR owner_id = ?", ownerID).Order("name").Find(&facilities).Error; err != nil {
 		return nil, err
 	}
 	return &facilities, nil
@@ -30,8 +38,69 @@ func (r *facilityRepository) GetAllFacilities() (*[]models.Facility, error) {
 
 func (r *facilityRepository) GetFacilityByID(id uuid.UUID) (*models.Facility, error) {
 	var facility models.Facility
-	if err := r.db.Where("id = ?", id).Find(&facility).Error; err != nil {
+	if err := r.db.Where("id = ?", id).First(&facility).Error; err != nil {
 		return nil, err
 	}
 	return &facility, nil
 }
+
+func (r *facilityRepository) FindFacilityByName(name string, ownerID uuid.UUID, excludedID uuid.UUID) (*models.Facility, error) {
+	var facility models.Facility
+	if err := r.db.
+		Where("LOWER(name) = LOWER(?) AND (owner_id IS NULL OR owner_id = ?) AND id <> ?", name, ownerID, excludedID).
+		First(&facility).Error; err != nil {
+		return nil, err
+	}
+	return &facility, nil
+}
+
+// CountFacilityReferences counts the rooms, rooming houses and assets still using the facility.
+func (r *facilityRepository) CountFacilityReferences(id uuid.UUID) (int64, error) {
+	var total int64
+
+	for _, model := range []interface{}{&models.RoomFacility{}, &models.RoomingHouseFacility{}, &models.FacilityAsset{}} {
+		var count int64
+		if err := r.db.Model(model).Where("facility_id = ?", id).Count(&count).Error; err != nil {
+			return 0, err
+		}
+		total += count
+	}
+
+	return total, nil
+}
+
+func (r *facilityRepository) CreateFacility(ctx context.Context, facility *models.Facility) error {
+	if err := r.db.WithContext(ctx).Create(facility).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *facilityRepository) UpdateFacilityByID(ctx context.Context, facility *models.Facility, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Model(&models.Facility{}).
+		Where("id = ?", id).
+		Select("name", "description", "is_public", "is_room").
+		Updates(facility)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *facilityRepository) DeleteFacilityByID(ctx context.Context, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Facility{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"context"
+	"rooming-house-cms-be/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type FacilityAssetRepository interface {
+	CreateFacilityAsset(ctx context.Context, facilityAsset *models.FacilityAsset) error
+	FindFacilityAssetByID(id uuid.UUID) (*models.FacilityAsset, error)
+	FindFacilityAssetBySerialNumber(roomingHouseID uuid.UUID, serialNumber string, excludedID uuid.UUID) (*models.FacilityAsset, error)
+	FindAllFacilityAssets(roomingHouseIDs []uuid.UUID, roomID *uuid.UUID) (*[]models.FacilityAssetResponse, error)
+	UpdateFacilityAssetByID(ctx context.Context, facilityAsset *models.FacilityAsset, id uuid.UUID) error
+	MoveFacilityAsset(ctx context.Context, facilityAsset *models.FacilityAsset, movement *models.FacilityAssetMovement) error
+	FindFacilityAssetMovements(facilityAssetID uuid.UUID) (*[]models.FacilityAssetMovement, error)
+	DeleteFacilityAssetByID(ctx context.Context, id uuid.UUID) error
+}
+
+type facilityAssetRepository struct {
+	db *gorm.DB
+}
+
+func NewFacilityAssetRepository(db *gorm.DB) FacilityAssetRepository {
+	return &facilityAssetRepository{db: db}
+}
+
+func (r *facilityAssetRepository) CreateFacilityAsset(ctx context.Context, facilityAsset *models.FacilityAsset) error {
+	if err := r.db.WithContext(ctx).Create(facilityAsset).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *facilityAssetRepository) FindFacilityAssetByID(id uuid.UUID) (*models.FacilityAsset, error) {
+	var facilityAsset models.FacilityAsset
+	if err := r.db.Where("id = ?", id).First(&facilityAsset).Error; err != nil {
+		return nil, err
+	}
+	return &facilityAsset, nil
+}
+
+func (r *facilityAssetRepository) FindFacilityAssetBySerialNumber(roomingHouseID uuid.UUID, serialNumber string, excludedID uuid.UUID) (*models.FacilityAsset, error) {
+	var facilityAsset models.FacilityAsset
+	if err := r.db.
+		Where("rooming_house_id = ? AND serial_number = ? AND id <> ?", roomingHouseID, serialNumber, excludedID).
+		First(&facilityAsset).Error; err != nil {
+		return nil, err
+	}
+	return &facilityAsset, nil
+}
+
+func (r *facilityAssetRepository) FindAllFacilityAssets(roomingHouseIDs []uuid.UUID, roomID *uuid.UUID) (*[]models.FacilityAssetResponse, error) {
+	var facilityAssets []models.FacilityAssetResponse
+
+	query := r.db.Table("facility_assets fa").
+		Select("fa.id, fa.facility_id, f.name AS facility_name, fa.room_id, r.name AS room_name, fa.serial_number, fa.brand, fa.purchase_date, fa.purchase_cost, fa.salvage_value, fa.useful_life_months, fa.warranty_expiry, fa.condition, rh.id AS rooming_house_id, rh.name AS rooming_house_name").
+		Joins("JOIN rooming_houses rh ON fa.rooming_house_id = rh.id").
+		Joins("JOIN facilities f ON fa.facility_id = f.id").
+		Joins("LEFT JOIN rooms r ON fa.room_id = r.id").
+		Where("fa.rooming_house_id IN (?) AND fa.deleted_at IS NULL", roomingHouseIDs)
+
+	if roomID != nil {
+		query = query.Where("fa.room_id = ?", *roomID)
+	}
+
+	if err := query.Order("rh.name, f.name, r.name").Scan(&facilityAssets).Error; err != nil {
+		return nil, err
+	}
+
+	return &facilityAssets, nil
+}
+
+func (r *facilityAssetRepository) UpdateFacilityAssetByID(ctx context.Context, facilityAsset *models.FacilityAsset, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Model(&models.FacilityAsset{}).
+		Where("id = ?", id).
+		Select("serial_number", "brand", "purchase_date", "purchase_cost", "salvage_value", "useful_life_months", "warranty_expiry", "condition").
+		Updates(facilityAsset)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// MoveFacilityAsset relocates the asset and records the movement. When the
+// asset lands in a room that does not list its facility yet, the room
+// facility is added, and when it was the source room's last asset of the
+// facility, the source room facility is removed, so the room details stay in
+// line with the inventory.
+func (r *facilityAssetRepository) MoveFacilityAsset(ctx context.Context, facilityAsset *models.FacilityAsset, movement *models.FacilityAssetMovement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.FacilityAsset{}).Where("id = ?", facilityAsset.ID).Update("room_id", movement.ToRoomID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(movement).Error; err != nil {
+			return err
+		}
+
+		// The source room loses the facility with its last asset of it
+		if movement.FromRoomID != nil {
+			var remaining int64
+			if err := tx.Model(&models.FacilityAsset{}).Where("room_id = ? AND facility_id = ? AND id <> ?", *movement.FromRoomID, facilityAsset.FacilityID, facilityAsset.ID).Count(&remaining).Error; err != nil {
+				return err
+			}
+
+			if remaining == 0 {
+				if err := tx.Where("room_id = ? AND facility_id = ?", *movement.FromRoomID, facilityAsset.FacilityID).Delete(&models.RoomFacility{}).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if movement.ToRoomID == nil {
+			return nil
+		}
+
+		var count int64
+		if err := tx.Model(&models.RoomFacility{}).Where("room_id = ? AND facility_id = ?", *movement.ToRoomID, facilityAsset.FacilityID).Count(&count).Error; err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return nil
+		}
+
+		return tx.Create(&models.RoomFacility{RoomID: *movement.ToRoomID, FacilityID: facilityAsset.FacilityID}).Error
+	})
+}
+
+func (r *facilityAssetRepository) FindFacilityAssetMovements(facilityAssetID uuid.UUID) (*[]models.FacilityAssetMovement, error) {
+	var movements []models.FacilityAssetMovement
+	if err := r.db.Where("facility_asset_id = ?", facilityAssetID).Order("created_at DESC").Find(&movements).Error; err != nil {
+		return nil, err
+	}
+	return &movements, nil
+}
+
+func (r *facilityAssetRepository) DeleteFacilityAssetByID(ctx context.Context, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.FacilityAsset{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"rooming-house-cms-be/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type InvitationRepository interface {
+	CreateInvitation(ctx context.Context, invitation *models.Invitation) error
+	FindInvitationByID(id uuid.UUID) (*models.Invitation, error)
+	FindInvitationByTokenHash(tokenHash string) (*models.Invitation, error)
+	FindPendingInvitations(ownerID uuid.UUID) (*[]models.InvitationResponse, error)
+	RevokeInvitation(ctx context.Context, id uuid.UUID) error
+	AcceptInvitation(ctx context.Context, invitation *models.Invitation, admin *models.Admin) error
+}
+
+type invitationRepository struct {
+	db *gorm.DB
+}
+
+func NewInvitationRepository(db *gorm.DB) InvitationRepository {
+	return &invitationRepository{db: db}
+}
+
+// CreateInvitation stores a new invitation and revokes the earlier pending
+// invitations of the same email to the same rooming house, so only the latest
+// email works.
+func (r *invitationRepository) CreateInvitation(ctx context.Context, invitation *models.Invitation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Invitation{}).
+			Where("email = ? AND rooming_house_id = ? AND accepted_at IS NULL AND revoked_at IS NULL", invitation.Email, invitation.RoomingHouseID).
+			Update("revoked_at", time.Now()).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(invitation).Error
+	})
+}
+
+func (r *invitationRepository) FindInvitationByID(id uuid.UUID) (*models.Invitation, error) {
+	var invitation models.Invitation
+	if err := r.db.Where("id = ?", id).First(&invitation).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *invitationRepository) FindInvitationByTokenHash(tokenHash string) (*models.Invitation, error) {
+	var invitation models.Invitation
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&invitation).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// FindPendingInvitations returns the owner's invitations that can still be
+// accepted, newest first.
+func (r *invitationRepository) FindPendingInvitations(ownerID uuid.UUID) (*[]models.InvitationResponse, error) {
+	var invitations []models.InvitationResponse
+	if err := r.db.Table("invitations i").
+		Select("i.id, i.email, i.full_name, i.rooming_house_id, rh.name AS rooming_house_name, i.role_id, ro.name AS role_name, i.expires_at, i.created_at").
+		Joins("JOIN rooming_houses rh ON rh.id = i.rooming_house_id AND rh.deleted_at IS NULL").
+		Joins("LEFT JOIN roles ro ON ro.id = i.role_id").
+		Where("i.owner_id = ? AND i.accepted_at IS NULL AND i.revoked_at IS NULL AND i.expires_at > ? AND i.deleted_at IS NULL", ownerID, time.Now()).
+		Order("i.created_at DESC").
+		Scan(&invitations).Error; err != nil {
+		return nil, err
+	}
+	return &invitations, nil
+}
+
+// RevokeInvitation revokes a pending invitation, failing if it was already
+// accepted or revoked.
+func (r *invitationRepository) RevokeInvitation(ctx context.Context, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Model(&models.Invitation{}).
+		Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// AcceptInvitation creates the admin, assigns it to the invitation's rooming
+// house with the invited role and marks the invitation as accepted, all or
+// nothing. It fails with gorm.ErrRecordNotFound if the invitation is no longer
+// pending.
+func (r *invitationRepository) AcceptInvitation(ctx context.Context, invitation *models.Invitation, admin *models.Admin) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(admin).Error; err != nil {
+			return err
+		}
+
+		res := tx.Model(&models.Invitation{}).
+			Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", invitation.ID).
+			Updates(map[string]interface{}{"accepted_at": time.Now(), "admin_id": admin.ID})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Create(&models.AdminRoomingHouse{AdminID: admin.ID, RoomingHouseID: invitation.RoomingHouseID, RoleID: &invitation.RoleID}).Error
+	})
+}
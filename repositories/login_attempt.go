@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type LoginAttemptRepository interface {
+	CreateLoginAttempt(loginAttempt *models.LoginAttempt) error
+	SummarizeAccountFailures(email string, role string, since time.Time) (*models.LoginFailureSummary, error)
+	SummarizeIPFailures(ipAddress string, since time.Time) (*models.LoginFailureSummary, error)
+	FindAllLoginAttempts(ownerID uuid.UUID, filter models.LoginAttemptFilter) (*[]models.LoginAttempt, error)
+}
+
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) LoginAttemptRepository {
+	return &loginAttemptRepository{db: db}
+}
+
+func (r *loginAttemptRepository) CreateLoginAttempt(loginAttempt *models.LoginAttempt) error {
+	return r.db.Create(loginAttempt).Error
+}
+
+// SummarizeAccountFailures counts the failed logins of the account since the
+// given time. A successful login starts the count over.
+func (r *loginAttemptRepository) SummarizeAccountFailures(email string, role string, since time.Time) (*models.LoginFailureSummary, error) {
+	var lastSuccess models.LoginAttempt
+	err := r.db.Where("email = ? AND role = ? AND success = ? AND created_at >= ?", email, role, true, since).
+		Order("created_at DESC").
+		First(&lastSuccess).Error
+	if err == nil {
+		since = lastSuccess.CreatedAt
+	} else if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	return r.summarizeFailures(r.db.Where("email = ? AND role = ?", email, role), since)
+}
+
+// SummarizeIPFailures counts the failed logins from the IP address since the
+// given time, across all accounts.
+func (r *loginAttemptRepository) SummarizeIPFailures(ipAddress string, since time.Time) (*models.LoginFailureSummary, error) {
+	return r.summarizeFailures(r.db.Where("ip_address = ?", ipAddress), since)
+}
+
+// summarizeFailures leaves out attempts that were refused by the throttling
+// itself, so waiting out a lockout is enough to lift it.
+func (r *loginAttemptRepository) summarizeFailures(query *gorm.DB, since time.Time) (*models.LoginFailureSummary, error) {
+	var summary models.LoginFailureSummary
+	if err := query.Model(&models.LoginAttempt{}).
+		Select("COUNT(*) AS failures, MAX(created_at) AS last_failed_at").
+		Where("success = ? AND failure_reason <> ? AND created_at > ?", false, constants.LoginFailureThrottled, since).
+		Scan(&summary).Error; err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// FindAllLoginAttempts returns the newest logins of the owner and of the
+// admins assigned to the owner's rooming houses.
+func (r *loginAttemptRepository) FindAllLoginAttempts(ownerID uuid.UUID, filter models.LoginAttemptFilter) (*[]models.LoginAttempt, error) {
+	adminIDs := r.db.Table("admin_rooming_houses arh").
+		Select("arh.admin_id").
+		Joins("JOIN rooming_houses rh ON rh.id = arh.rooming_house_id AND rh.deleted_at IS NULL").
+		Where("rh.owner_id = ? AND arh.deleted_at IS NULL", ownerID)
+
+	query := r.db.Where("(role = ? AND user_id = ?) OR (role = ? AND user_id IN (?))", "owner", ownerID, "admin", adminIDs)
+
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+
+	if filter.Success != nil {
+		query = query.Where("success = ?", *filter.Success)
+	}
+
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var loginAttempts []models.LoginAttempt
+	if err := query.Order("created_at DESC").Limit(filter.Limit).Find(&loginAttempts).Error; err != nil {
+		return nil, err
+	}
+	return &loginAttempts, nil
+}
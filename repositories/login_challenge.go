@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"rooming-house-cms-be/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type LoginChallengeRepository interface {
+	CreateLoginChallenge(loginChallenge *models.LoginChallenge) error
+	FindLoginChallengeByTokenHash(tokenHash string) (*models.LoginChallenge, error)
+	IncrementLoginChallengeAttempts(id uuid.UUID) error
+	UseLoginChallenge(id uuid.UUID) error
+}
+
+type loginChallengeRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginChallengeRepository(db *gorm.DB) LoginChallengeRepository {
+	return &loginChallengeRepository{db: db}
+}
+
+func (r *loginChallengeRepository) CreateLoginChallenge(loginChallenge *models.LoginChallenge) error {
+	return r.db.Create(loginChallenge).Error
+}
+
+func (r *loginChallengeRepository) FindLoginChallengeByTokenHash(tokenHash string) (*models.LoginChallenge, error) {
+	var loginChallenge models.LoginChallenge
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&loginChallenge).Error; err != nil {
+		return nil, err
+	}
+	return &loginChallenge, nil
+}
+
+func (r *loginChallengeRepository) IncrementLoginChallengeAttempts(id uuid.UUID) error {
+	return r.db.Model(&models.LoginChallenge{}).
+		Where("id = ?", id).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+// UseLoginChallenge marks the challenge as completed, failing if it already was.
+func (r *loginChallengeRepository) UseLoginChallenge(id uuid.UUID) error {
+	res := r.db.Model(&models.LoginChallenge{}).Where("id = ? AND used_at IS NULL", id).Update("used_at", time.Now())
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package repositories
+
+import (
+	"context"
+	"rooming-house-cms-be/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MaintenanceScheduleRepository interface {
+	CreateMaintenanceSchedule(ctx context.Context, maintenanceSchedule *models.MaintenanceSchedule) error
+	FindMaintenanceScheduleByID(id uuid.UUID) (*models.MaintenanceSchedule, error)
+	FindAllMaintenanceSchedules(roomingHouseIDs []uuid.UUID, dueBefore *time.Time) (*[]models.MaintenanceScheduleResponse, error)
+	FindDueMaintenanceSchedules(now time.Time) (*[]models.MaintenanceSchedule, error)
+	UpdateMaintenanceScheduleByID(ctx context.Context, maintenanceSchedule *models.MaintenanceSchedule, id uuid.UUID) error
+	CreateScheduledWorkOrder(ctx context.Context, maintenanceSchedule *models.MaintenanceSchedule, workOrder *models.WorkOrder) error
+	CompleteMaintenanceSchedule(ctx context.Context, maintenanceSchedule *models.MaintenanceSchedule, maintenanceLog *models.MaintenanceLog) error
+	FindMaintenanceLogs(roomingHouseIDs []uuid.UUID, scheduleID *uuid.UUID, roomID *uuid.UUID, facilityID *uuid.UUID) (*[]models.MaintenanceLogResponse, error)
+	DeleteMaintenanceScheduleByID(ctx context.Context, id uuid.UUID) error
+}
+
+type maintenanceScheduleRepository struct {
+	db *gorm.DB
+}
+
+func NewMaintenanceScheduleRepository(db *gorm.DB) MaintenanceScheduleRepository {
+	return &maintenanceScheduleRepository{db: db}
+}
+
+func (r *maintenanceScheduleRepository) CreateMaintenanceSchedule(ctx context.Context, maintenanceSchedule *models.MaintenanceSchedule) error {
+	if err := r.db.WithContext(ctx).Create(maintenanceSchedule).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *maintenanceScheduleRepository) FindMaintenanceScheduleByID(id uuid.UUID) (*models.MaintenanceSchedule, error) {
+	var maintenanceSchedule models.MaintenanceSchedule
+	if err := r.db.Where("id = ?", id).First(&maintenanceSchedule).Error; err != nil {
+		return nil, err
+	}
+	return &maintenanceSchedule, nil
+}
+
+func (r *maintenanceScheduleRepository) FindAllMaintenanceSchedules(roomingHouseIDs []uuid.UUID, dueBefore *time.Time) (*[]models.MaintenanceScheduleResponse, error) {
+	var maintenanceSchedules []models.MaintenanceScheduleResponse
+
+	query := r.db.Table("maintenance_schedules ms").
+		Select("ms.id, ms.title, ms.description, ms.room_id, r.name AS room_name, ms.facility_id, f.name AS facility_name, ms.interval_unit, ms.interval_count, ms.lead_days, ms.next_due_date, ms.last_completed_at, ms.open_work_order_id, ms.is_active, rh.id AS rooming_house_id, rh.name AS rooming_house_name").
+		Joins("JOIN rooming_houses rh ON ms.rooming_house_id = rh.id").
+		Joins("JOIN facilities f ON ms.facility_id = f.id").
+		Joins("LEFT JOIN rooms r ON ms.room_id = r.id").
+		Where("ms.rooming_house_id IN (?) AND ms.deleted_at IS NULL", roomingHouseIDs)
+
+	if dueBefore != nil {
+		query = query.Where("ms.is_active = ? AND ms.next_due_date <= ?", true, *dueBefore)
+	}
+
+	if err := query.Order("ms.next_due_date ASC").Scan(&maintenanceSchedules).Error; err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range maintenanceSchedules {
+		maintenanceSchedules[i].IsOverdue = maintenanceSchedules[i].IsActive && maintenanceSchedules[i].NextDueDate.Before(now)
+	}
+
+	return &maintenanceSchedules, nil
+}
+
+// FindDueMaintenanceSchedules returns active schedules whose lead window has
+// started and that do not have an open work order yet.
+func (r *maintenanceScheduleRepository) FindDueMaintenanceSchedules(now time.Time) (*[]models.MaintenanceSchedule, error) {
+	var maintenanceSchedules []models.MaintenanceSchedule
+	if err := r.db.
+		Where("is_active = ? AND open_work_order_id IS NULL AND DATE_SUB(next_due_date, INTERVAL lead_days DAY) <= ?", true, now).
+		Find(&maintenanceSchedules).Error; err != nil {
+		return nil, err
+	}
+	return &maintenanceSchedules, nil
+}
+
+func (r *maintenanceScheduleRepository) UpdateMaintenanceScheduleByID(ctx context.Context, maintenanceSchedule *models.MaintenanceSchedule, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Model(&models.MaintenanceSchedule{}).
+		Where("id = ?", id).
+		Select("title", "description", "interval_unit", "interval_count", "lead_days", "next_due_date", "is_active").
+		Updates(maintenanceSchedule)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// CreateScheduledWorkOrder opens the work order for a due schedule and links
+// it so the schedule is not picked up again until the work order is done.
+func (r *maintenanceScheduleRepository) CreateScheduledWorkOrder(ctx context.Context, maintenanceSchedule *models.MaintenanceSchedule, workOrder *models.WorkOrder) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(workOrder).Error; err != nil {
+			return err
+		}
+
+		maintenanceSchedule.OpenWorkOrderID = &workOrder.ID
+		return tx.Model(&models.MaintenanceSchedule{}).Where("id = ?", maintenanceSchedule.ID).Update("open_work_order_id", workOrder.ID).Error
+	})
+}
+
+// CompleteMaintenanceSchedule records the completion log and moves the
+// schedule on to its next due date.
+func (r *maintenanceScheduleRepository) CompleteMaintenanceSchedule(ctx context.Context, maintenanceSchedule *models.MaintenanceSchedule, maintenanceLog *models.MaintenanceLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(maintenanceLog).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.MaintenanceSchedule{}).Where("id = ?", maintenanceSchedule.ID).Updates(map[string]interface{}{
+			"next_due_date":      maintenanceSchedule.NextDueDate,
+			"last_completed_at":  maintenanceSchedule.LastCompletedAt,
+			"open_work_order_id": nil,
+		}).Error
+	})
+}
+
+func (r *maintenanceScheduleRepository) FindMaintenanceLogs(roomingHouseIDs []uuid.UUID, scheduleID *uuid.UUID, roomID *uuid.UUID, facilityID *uuid.UUID) (*[]models.MaintenanceLogResponse, error) {
+	var maintenanceLogs []models.MaintenanceLogResponse
+
+	query := r.db.Table("maintenance_logs ml").
+		Select("ml.id, ml.maintenance_schedule_id, ms.title AS schedule_title, ml.room_id, r.name AS room_name, ml.facility_id, f.name AS facility_name, ml.work_order_id, ml.due_date, ml.completed_at, ml.completed_by, ml.completer_role, ml.note, ml.cost").
+		Joins("JOIN maintenance_schedules ms ON ml.maintenance_schedule_id = ms.id").
+		Joins("JOIN facilities f ON ml.facility_id = f.id").
+		Joins("LEFT JOIN rooms r ON ml.room_id = r.id").
+		Where("ml.rooming_house_id IN (?) AND ml.deleted_at IS NULL", roomingHouseIDs)
+
+	if scheduleID != nil {
+		query = query.Where("ml.maintenance_schedule_id = ?", *scheduleID)
+	}
+
+	if roomID != nil {
+		query = query.Where("ml.room_id = ?", *roomID)
+	}
+
+	if facilityID != nil {
+		query = query.Where("ml.facility_id = ?", *facilityID)
+	}
+
+	if err := query.Order("ml.completed_at DESC").Scan(&maintenanceLogs).Error; err != nil {
+		return nil, err
+	}
+
+	return &maintenanceLogs, nil
+}
+
+func (r *maintenanceScheduleRepository) DeleteMaintenanceScheduleByID(ctx context.Context, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.MaintenanceSchedule{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
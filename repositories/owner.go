@@ -1,15 +1,19 @@
 package repositories
 
 import (
+	"context"
 	"errors"
 	"rooming-house-cms-be/models"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type OwnerRepository interface {
-	CreateOwner(user *models.Owner) error
+	CreateOwner(ctx context.Context, user *models.Owner) error
 	FindOwnerByEmail(email string) (*models.Owner, error)
+	FindOwnerByID(id uuid.UUID) (*models.Owner, error)
+	UpdateOwnerPassword(ctx context.Context, id uuid.UUID, passwordHash string) error
 }
 
 type ownerRepository struct {
@@ -20,8 +24,8 @@ func NewOwnerRepository(db *gorm.DB) OwnerRepository {
 	return &ownerRepository{db: db}
 }
 
-func (r *ownerRepository) CreateOwner(owner *models.Owner) error {
-	if err := r.db.Create(owner).Error; err != nil {
+func (r *ownerRepository) CreateOwner(ctx context.Context, owner *models.Owner) error {
+	if err := r.db.WithContext(ctx).Create(owner).Error; err != nil {
 		return err
 	}
 	return nil
@@ -34,3 +38,15 @@ func (r *ownerRepository) FindOwnerByEmail(email string) (*models.Owner, error)
 	}
 	return &owner, nil
 }
+
+func (r *ownerRepository) FindOwnerByID(id uuid.UUID) (*models.Owner, error) {
+	var owner models.Owner
+	if err := r.db.Where("id = ?", id).First(&owner).Error; err != nil {
+		return nil, errors.New("owner not found")
+	}
+	return &owner, nil
+}
+
+func (r *ownerRepository) UpdateOwnerPassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	return r.db.WithContext(ctx).Model(&models.Owner{}).Where("id = ?", id).Update("password", passwordHash).Error
+}
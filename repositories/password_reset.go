@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"rooming-house-cms-be/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PasswordResetRepository interface {
+	CreatePasswordReset(passwordReset *models.PasswordReset) error
+	FindPasswordResetByTokenHash(tokenHash string) (*models.PasswordReset, error)
+	UsePasswordReset(id uuid.UUID) error
+	CreatePasswordResetRequest(passwordResetRequest *models.PasswordResetRequest) error
+	SummarizeAccountResetRequests(email string, role string, since time.Time) (*models.PasswordResetRequestSummary, error)
+	SummarizeIPResetRequests(ipAddress string, since time.Time) (*models.PasswordResetRequestSummary, error)
+}
+
+type passwordResetRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepository{db: db}
+}
+
+// CreatePasswordReset stores a new reset token and invalidates the user's
+// earlier unused tokens so only the latest email works.
+func (r *passwordResetRepository) CreatePasswordReset(passwordReset *models.PasswordReset) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.PasswordReset{}).
+			Where("user_id = ? AND role = ? AND used_at IS NULL", passwordReset.UserID, passwordReset.Role).
+			Update("used_at", time.Now()).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(passwordReset).Error
+	})
+}
+
+func (r *passwordResetRepository) FindPasswordResetByTokenHash(tokenHash string) (*models.PasswordReset, error) {
+	var passwordReset models.PasswordReset
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&passwordReset).Error; err != nil {
+		return nil, err
+	}
+	return &passwordReset, nil
+}
+
+// UsePasswordReset marks the token as used, failing if it already was.
+func (r *passwordResetRepository) UsePasswordReset(id uuid.UUID) error {
+	res := r.db.Model(&models.PasswordReset{}).Where("id = ? AND used_at IS NULL", id).Update("used_at", time.Now())
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *passwordResetRepository) CreatePasswordResetRequest(passwordResetRequest *models.PasswordResetRequest) error {
+	return r.db.Create(passwordResetRequest).Error
+}
+
+// SummarizeAccountResetRequests counts the reset requests of the account
+// since the given time.
+func (r *passwordResetRepository) SummarizeAccountResetRequests(email string, role string, since time.Time) (*models.PasswordResetRequestSummary, error) {
+	return r.summarizeResetRequests(r.db.Where("email = ? AND role = ?", email, role), since)
+}
+
+// SummarizeIPResetRequests counts the reset requests from the IP address
+// since the given time, across all accounts.
+func (r *passwordResetRepository) SummarizeIPResetRequests(ipAddress string, since time.Time) (*models.PasswordResetRequestSummary, error) {
+	return r.summarizeResetRequests(r.db.Where("ip_address = ?", ipAddress), since)
+}
+
+func (r *passwordResetRepository) summarizeResetRequests(query *gorm.DB, since time.Time) (*models.PasswordResetRequestSummary, error) {
+	var summary models.PasswordResetRequestSummary
+	if err := query.Model(&models.PasswordResetRequest{}).
+		Select("COUNT(*) AS requests, MIN(created_at) AS first_requested_at").
+		Where("created_at > ?", since).
+		Scan(&summary).Error; err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
@@ -0,0 +1,186 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PayrollRepository interface {
+	CountPayrollRunsByPeriod(roomingHouseID uuid.UUID, year int, month int) (int64, error)
+	CreatePayrollRun(ctx context.Context, payrollRun *models.PayrollRun) error
+	FindAllPayrollRuns(roomingHouseIDs []uuid.UUID, year int) (*[]models.PayrollRun, error)
+	FindPayrollRunByID(id uuid.UUID) (*models.PayrollRun, error)
+	ApprovePayrollRun(ctx context.Context, payrollRun *models.PayrollRun, salaryCategoryID uuid.UUID, approvedByID uuid.UUID, approvedByRole string) error
+	DeletePayrollRun(ctx context.Context, id uuid.UUID) error
+	FindPayslipByID(id uuid.UUID) (*models.Payslip, error)
+	FindPayslipsByStaffID(staffID uuid.UUID) (*[]models.Payslip, error)
+	FindPayslipsByAdminID(adminID uuid.UUID) (*[]models.Payslip, error)
+	CountPayslipsByStaffID(staffID uuid.UUID) (int64, error)
+}
+
+type payrollRepository struct {
+	db *gorm.DB
+}
+
+func NewPayrollRepository(db *gorm.DB) PayrollRepository {
+	return &payrollRepository{db: db}
+}
+
+func (r *payrollRepository) CountPayrollRunsByPeriod(roomingHouseID uuid.UUID, year int, month int) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.PayrollRun{}).Where("rooming_house_id = ? AND year = ? AND month = ?", roomingHouseID, year, month).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CreatePayrollRun creates the run with its payslips and their items.
+func (r *payrollRepository) CreatePayrollRun(ctx context.Context, payrollRun *models.PayrollRun) error {
+	return r.db.WithContext(ctx).Create(payrollRun).Error
+}
+
+func (r *payrollRepository) FindAllPayrollRuns(roomingHouseIDs []uuid.UUID, year int) (*[]models.PayrollRun, error) {
+	payrollRuns := []models.PayrollRun{}
+
+	query := r.db.Where("rooming_house_id IN (?)", roomingHouseIDs)
+	if year != 0 {
+		query = query.Where("year = ?", year)
+	}
+
+	if err := query.Order("year DESC, month DESC").Find(&payrollRuns).Error; err != nil {
+		return nil, err
+	}
+	return &payrollRuns, nil
+}
+
+func (r *payrollRepository) FindPayrollRunByID(id uuid.UUID) (*models.PayrollRun, error) {
+	var payrollRun models.PayrollRun
+	if err := r.db.Preload("Payslips", func(db *gorm.DB) *gorm.DB {
+		return db.Order("staff_name")
+	}).Preload("Payslips.Items").Where("id = ?", id).First(&payrollRun).Error; err != nil {
+		return nil, err
+	}
+	return &payrollRun, nil
+}
+
+// ApprovePayrollRun approves a draft run and posts a Salary expense
+// transaction for every payslip with pay, dated the last day of the month.
+// The expense is the net pay, the amount actually paid out to the staff. It
+// fails with gorm.ErrRecordNotFound when the run is no longer a draft.
+func (r *payrollRepository) ApprovePayrollRun(ctx context.Context, payrollRun *models.PayrollRun, salaryCategoryID uuid.UUID, approvedByID uuid.UUID, approvedByRole string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.PayrollRun{}).
+			Where("id = ? AND status = ?", payrollRun.ID, constants.PayrollStatusDraft).
+			Updates(map[string]interface{}{
+				"status":           constants.PayrollStatusApproved,
+				"approved_at":      now,
+				"approved_by_id":   approvedByID,
+				"approved_by_role": approvedByRole,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		lastDay := time.Date(payrollRun.Year, time.Month(payrollRun.Month)+1, 0, 0, 0, 0, 0, time.Local).Day()
+		for i := range payrollRun.Payslips {
+			payslip := &payrollRun.Payslips[i]
+			if payslip.NetPay <= 0 {
+				continue
+			}
+
+			transaction := models.Transaction{
+				Day:                   lastDay,
+				Month:                 payrollRun.Month,
+				Year:                  payrollRun.Year,
+				Amount:                payslip.NetPay,
+				Description:           fmt.Sprintf("Salary %s (%s) %d/%d", payslip.StaffName, payslip.Position, payrollRun.Month, payrollRun.Year),
+				TransactionCategoryID: salaryCategoryID,
+				RoomingHouseID:        payrollRun.RoomingHouseID,
+			}
+			if err := tx.Create(&transaction).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Model(&models.Payslip{}).Where("id = ?", payslip.ID).Update("transaction_id", transaction.ID).Error; err != nil {
+				return err
+			}
+			payslip.TransactionID = &transaction.ID
+		}
+
+		payrollRun.Status = constants.PayrollStatusApproved
+		payrollRun.ApprovedAt = &now
+		payrollRun.ApprovedByID = &approvedByID
+		payrollRun.ApprovedByRole = approvedByRole
+		return nil
+	})
+}
+
+// DeletePayrollRun removes a draft run with its payslips. It is a hard delete
+// so the month can be generated again, and fails with gorm.ErrRecordNotFound
+// when the run is no longer a draft.
+func (r *payrollRepository) DeletePayrollRun(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Where("id = ? AND status = ?", id, constants.PayrollStatusDraft).Delete(&models.PayrollRun{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Unscoped().Where("payslip_id IN (?)", tx.Model(&models.Payslip{}).Select("id").Where("payroll_run_id = ?", id)).Delete(&models.PayslipItem{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Where("payroll_run_id = ?", id).Delete(&models.Payslip{}).Error
+	})
+}
+
+func (r *payrollRepository) FindPayslipByID(id uuid.UUID) (*models.Payslip, error) {
+	var payslip models.Payslip
+	if err := r.db.Preload("Items").Where("id = ?", id).First(&payslip).Error; err != nil {
+		return nil, err
+	}
+	return &payslip, nil
+}
+
+func (r *payrollRepository) FindPayslipsByStaffID(staffID uuid.UUID) (*[]models.Payslip, error) {
+	payslips := []models.Payslip{}
+	if err := r.db.Preload("Items").Where("staff_id = ?", staffID).Order("year DESC, month DESC").Find(&payslips).Error; err != nil {
+		return nil, err
+	}
+	return &payslips, nil
+}
+
+// FindPayslipsByAdminID returns the approved payslips of the staff records
+// linked to the admin account.
+func (r *payrollRepository) FindPayslipsByAdminID(adminID uuid.UUID) (*[]models.Payslip, error) {
+	payslips := []models.Payslip{}
+	if err := r.db.Preload("Items").
+		Joins("JOIN staffs s ON s.id = payslips.staff_id").
+		Joins("JOIN payroll_runs pr ON pr.id = payslips.payroll_run_id").
+		Where("s.admin_id = ? AND pr.status = ?", adminID, constants.PayrollStatusApproved).
+		Order("payslips.year DESC, payslips.month DESC").
+		Find(&payslips).Error; err != nil {
+		return nil, err
+	}
+	return &payslips, nil
+}
+
+func (r *payrollRepository) CountPayslipsByStaffID(staffID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.Payslip{}).Where("staff_id = ?", staffID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"rooming-house-cms-be/models"
 
 	"github.com/google/uuid"
@@ -8,12 +9,13 @@ import (
 )
 
 type PeriodRepository interface {
-	CreatePeriod(period *models.Period) error
+	CreatePeriod(ctx context.Context, period *models.Period) error
 	FindPeriodByName(name string) (*models.Period, error)
 	FindPeriodByID(id uuid.UUID) (*models.Period, error)
-	FindAllPeriods() (*[]models.Period, error)
-	UpdatePeriodByID(period *models.Period, id uuid.UUID) error
-	DeletePeriodByID(id uuid.UUID) error
+	FindAllPeriods(ownerID uuid.UUID) (*[]models.Period, error)
+	CountPeriodReferences(id uuid.UUID) (int64, error)
+	UpdatePeriodByID(ctx context.Context, period *models.Period, id uuid.UUID) error
+	DeletePeriodByID(ctx context.Context, id uuid.UUID) error
 }
 
 type periodRepository struct {
@@ -24,8 +26,8 @@ func NewPeriodRepository(db *gorm.DB) PeriodRepository {
 	return &periodRepository{db: db}
 }
 
-func (r *periodRepository) CreatePeriod(period *models.Period) error {
-	if err := r.db.Create(period).Error; err != nil {
+func (r *periodRepository) CreatePeriod(ctx context.Context, period *models.Period) error {
+	if err := r.db.WithContext(ctx).Create(period).Error; err != nil {
 		return err
 	}
 	return nil
@@ -41,22 +43,38 @@ func (r *periodRepository) FindPeriodByName(name string) (*models.Period, error)
 
 func (r *periodRepository) FindPeriodByID(id uuid.UUID) (*models.Period, error) {
 	var period models.Period
-	if err := r.db.Where("id = ?", id).Find(&period).Error; err != nil {
+	if err := r.db.Where("id = ?", id).First(&period).Error; err != nil {
 		return nil, err
 	}
 	return &period, nil
 }
 
-func (r *periodRepository) FindAllPeriods() (*[]models.Period, error) {
+// FindAllPeriods returns the global periods together with the custom periods of the given owner.
+func (r *periodRepository) FindAllPeriods(ownerID uuid.UUID) (*[]models.Period, error) {
 	var periods []models.Period
-	if err := r.db.Find(&periods).Error; err != nil {
+	if err := r.db.Where("owner_id IS NULL OR owner_id = ?", ownerID).Order("unit, count").Find(&periods).Error; err != nil {
 		return nil, err
 	}
 	return &periods, nil
 }
 
-func (r *periodRepository) UpdatePeriodByID(period *models.Period, id uuid.UUID) error {
-	res := r.db.Where("id = ?", id).Updates(period)
+// CountPeriodReferences counts the package prices, additional prices and tenants using the period.
+func (r *periodRepository) CountPeriodReferences(id uuid.UUID) (int64, error) {
+	var total int64
+
+	for _, model := range []interface{}{&models.PeriodPackage{}, &models.AdditionalPeriod{}, &models.Tenant{}} {
+		var count int64
+		if err := r.db.Model(model).Where("period_id = ?", id).Count(&count).Error; err != nil {
+			return 0, err
+		}
+		total += count
+	}
+
+	return total, nil
+}
+
+func (r *periodRepository) UpdatePeriodByID(ctx context.Context, period *models.Period, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Model(&models.Period{}).Where("id = ?", id).Select("name", "unit", "count").Updates(period)
 	if res.Error != nil {
 		if res.Error == gorm.ErrRecordNotFound {
 			return res.Error
@@ -71,8 +89,8 @@ func (r *periodRepository) UpdatePeriodByID(period *models.Period, id uuid.UUID)
 	return nil
 }
 
-func (r *periodRepository) DeletePeriodByID(id uuid.UUID) error {
-	res := r.db.Where("id = ?", id).Delete(&models.Period{})
+func (r *periodRepository) DeletePeriodByID(ctx context.Context, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Period{})
 	if res.Error != nil {
 		if res.Error == gorm.ErrRecordNotFound {
 			return res.Error
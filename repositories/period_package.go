@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"rooming-house-cms-be/models"
 
 	"github.com/google/uuid"
@@ -8,10 +9,11 @@ import (
 )
 
 type PeriodPackageRepository interface {
-	CreatePeriodPackage(periodPackage *[]models.PeriodPackage) error
+	CreatePeriodPackage(ctx context.Context, periodPackage *[]models.PeriodPackage) error
 	FindPeriodPackageByPackageID(packageID uuid.UUID) (*[]models.PeriodPackage, error)
 	FindPeriodPackageByPeriodIDPackageID(periodID uuid.UUID, packageID uuid.UUID) (*models.PeriodPackage, error)
-	UpdatePeriodPackageByPackageID(periodPackage []models.PeriodPackage, packageID uuid.UUID) error
+	UpdatePeriodPackageByPackageID(ctx context.Context, periodPackage []models.PeriodPackage, packageID uuid.UUID) error
+	CountTenantsOutsidePeriods(packageID uuid.UUID, periodIDs []uuid.UUID) (int64, error)
 }
 
 type periodPackageRepository struct {
@@ -22,8 +24,8 @@ func NewPeriodPackageRepository(db *gorm.DB) PeriodPackageRepository {
 	return &periodPackageRepository{db: db}
 }
 
-func (r *periodPackageRepository) CreatePeriodPackage(periodPackage *[]models.PeriodPackage) error {
-	if err := r.db.Create(periodPackage).Error; err != nil {
+func (r *periodPackageRepository) CreatePeriodPackage(ctx context.Context, periodPackage *[]models.PeriodPackage) error {
+	if err := r.db.WithContext(ctx).Create(periodPackage).Error; err != nil {
 		return err
 	}
 	return nil
@@ -45,16 +47,26 @@ func (r *periodPackageRepository) FindPeriodPackageByPeriodIDPackageID(periodID
 	return &periodPackage, nil
 }
 
-func (r *periodPackageRepository) UpdatePeriodPackageByPackageID(periodPackage []models.PeriodPackage, packageID uuid.UUID) error {
-	for _, periodPackage := range periodPackage {
-		err := r.db.Model(&models.PeriodPackage{}).
-			Where("pricing_package_id = ? AND period_id = ?", packageID, periodPackage.PeriodID).
-			Update("price", periodPackage.Price).Error
-
-		if err != nil {
+// UpdatePeriodPackageByPackageID replaces the package's prices with the given set.
+func (r *periodPackageRepository) UpdatePeriodPackageByPackageID(ctx context.Context, periodPackage []models.PeriodPackage, packageID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Delete(&models.PeriodPackage{}, "pricing_package_id = ?", packageID).Error; err != nil {
 			return err
 		}
-	}
 
-	return nil
+		return tx.Create(&periodPackage).Error
+	})
+}
+
+// CountTenantsOutsidePeriods counts current tenants of rooms on the package
+// who are billed on a period other than the given ones.
+func (r *periodPackageRepository) CountTenantsOutsidePeriods(packageID uuid.UUID, periodIDs []uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.Table("tenants t").
+		Joins("JOIN rooms r ON t.room_id = r.id").
+		Where("r.package_id = ? AND t.is_tenant = ? AND t.deleted_at IS NULL AND t.period_id NOT IN (?)", packageID, true, periodIDs).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
 }
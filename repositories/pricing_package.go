@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"rooming-house-cms-be/models"
 
 	"github.com/google/uuid"
@@ -8,11 +9,11 @@ import (
 )
 
 type PricingPackageRepository interface {
-	CreatePricingPackage(pricingPackage *models.PricingPackage) error
+	CreatePricingPackage(ctx context.Context, pricingPackage *models.PricingPackage) error
 	FindPricingPackageByID(packageID uuid.UUID) (*models.PricingPackage, error)
 	FindAllPricingPackages(roomingHouseIDs []uuid.UUID) (*[]models.AllPackageResponse, error)
-	UpdatePricingPackageByID(pricingPackage *models.PricingPackage, id uuid.UUID) error
-	DeletePricingPackageByID(id uuid.UUID) error
+	UpdatePricingPackageByID(ctx context.Context, pricingPackage *models.PricingPackage, id uuid.UUID) error
+	DeletePricingPackageByID(ctx context.Context, id uuid.UUID) error
 }
 
 type pricingPackageRepository struct {
@@ -23,8 +24,8 @@ func NewPricingPackageRepository(db *gorm.DB) PricingPackageRepository {
 	return &pricingPackageRepository{db: db}
 }
 
-func (r *pricingPackageRepository) CreatePricingPackage(pricingPackage *models.PricingPackage) error {
-	if err := r.db.Create(pricingPackage).Error; err != nil {
+func (r *pricingPackageRepository) CreatePricingPackage(ctx context.Context, pricingPackage *models.PricingPackage) error {
+	if err := r.db.WithContext(ctx).Create(pricingPackage).Error; err != nil {
 		return err
 	}
 	return nil
@@ -69,9 +70,9 @@ func (r *pricingPackageRepository) FindAllPricingPackages(roomingHouseIDs []uuid
 			Prices: make(map[string]float64),
 		}
 
-		// Memetakan harga berdasarkan unit periode
+		// Memetakan harga berdasarkan nama periode
 		for _, periodPackage := range pkg.PeriodPackages {
-			response.Prices[periodPackage.Period.Unit] = periodPackage.Price
+			response.Prices[periodPackage.Period.Name] = periodPackage.Price
 		}
 
 		responses = append(responses, response)
@@ -80,8 +81,8 @@ func (r *pricingPackageRepository) FindAllPricingPackages(roomingHouseIDs []uuid
 	return &responses, nil
 }
 
-func (r *pricingPackageRepository) UpdatePricingPackageByID(pricingPackage *models.PricingPackage, id uuid.UUID) error {
-	res := r.db.Where("id = ?", id).Updates(pricingPackage)
+func (r *pricingPackageRepository) UpdatePricingPackageByID(ctx context.Context, pricingPackage *models.PricingPackage, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("id = ?", id).Updates(pricingPackage)
 	if res.Error != nil {
 		if res.Error == gorm.ErrRecordNotFound {
 			return res.Error
@@ -95,8 +96,8 @@ func (r *pricingPackageRepository) UpdatePricingPackageByID(pricingPackage *mode
 	return nil
 }
 
-func (r *pricingPackageRepository) DeletePricingPackageByID(id uuid.UUID) error {
-	res := r.db.Where("id = ?", id).Delete(&models.PricingPackage{})
+func (r *pricingPackageRepository) DeletePricingPackageByID(ctx context.Context, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.PricingPackage{})
 	if res.Error != nil {
 		if res.Error == gorm.ErrRecordNotFound {
 			return res.Error
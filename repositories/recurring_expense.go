@@ -0,0 +1,241 @@
+package repositories
+
+import (
+	"context"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RecurringExpenseRepository interface {
+	CreateRecurringExpense(ctx context.Context, recurringExpense *models.RecurringExpense) error
+	FindRecurringExpenseByID(id uuid.UUID) (*models.RecurringExpense, error)
+	FindAllRecurringExpenses(roomingHouseIDs []uuid.UUID) (*[]models.RecurringExpenseResponse, error)
+	FindDueRecurringExpenses(now time.Time) (*[]models.RecurringExpense, error)
+	UpdateRecurringExpenseByID(ctx context.Context, recurringExpense *models.RecurringExpense, id uuid.UUID) error
+	DeleteRecurringExpenseByID(ctx context.Context, id uuid.UUID) error
+	CreateRecurringExpenseDraft(ctx context.Context, recurringExpense *models.RecurringExpense, draft *models.RecurringExpenseDraft, nextDueDate time.Time) error
+	FlagSkippedRecurringExpenseDrafts(dueBefore time.Time, now time.Time) (int64, error)
+	FindAllRecurringExpenseDrafts(roomingHouseIDs []uuid.UUID, status string, skippedOnly bool) (*[]models.RecurringExpenseDraftResponse, error)
+	FindRecurringExpenseDraftByID(id uuid.UUID) (*models.RecurringExpenseDraft, error)
+	ConfirmRecurringExpenseDraft(ctx context.Context, draft *models.RecurringExpenseDraft, transaction *models.Transaction, resolvedBy uuid.UUID, resolverRole string) error
+	DismissRecurringExpenseDraft(ctx context.Context, draft *models.RecurringExpenseDraft, resolvedBy uuid.UUID, resolverRole string) error
+}
+
+type recurringExpenseRepository struct {
+	db *gorm.DB
+}
+
+func NewRecurringExpenseRepository(db *gorm.DB) RecurringExpenseRepository {
+	return &recurringExpenseRepository{db: db}
+}
+
+func (r *recurringExpenseRepository) CreateRecurringExpense(ctx context.Context, recurringExpense *models.RecurringExpense) error {
+	if err := r.db.WithContext(ctx).Create(recurringExpense).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *recurringExpenseRepository) FindRecurringExpenseByID(id uuid.UUID) (*models.RecurringExpense, error) {
+	var recurringExpense models.RecurringExpense
+	if err := r.db.Where("id = ?", id).First(&recurringExpense).Error; err != nil {
+		return nil, err
+	}
+	return &recurringExpense, nil
+}
+
+func (r *recurringExpenseRepository) FindAllRecurringExpenses(roomingHouseIDs []uuid.UUID) (*[]models.RecurringExpenseResponse, error) {
+	recurringExpenses := []models.RecurringExpenseResponse{}
+
+	if err := r.db.Table("recurring_expenses re").
+		Select("re.id, re.name, re.transaction_category_id, tc.name AS category_name, re.amount, re.day_of_month, re.end_date, re.next_due_date, re.is_active, rh.id AS rooming_house_id, rh.name AS rooming_house_name").
+		Joins("JOIN rooming_houses rh ON re.rooming_house_id = rh.id").
+		Joins("JOIN transaction_categories tc ON re.transaction_category_id = tc.id").
+		Where("re.rooming_house_id IN (?) AND re.deleted_at IS NULL", roomingHouseIDs).
+		Order("rh.name, re.day_of_month, re.name").
+		Scan(&recurringExpenses).Error; err != nil {
+		return nil, err
+	}
+
+	return &recurringExpenses, nil
+}
+
+// FindDueRecurringExpenses returns active recurring expenses whose next draft
+// is due and falls before their end date.
+func (r *recurringExpenseRepository) FindDueRecurringExpenses(now time.Time) (*[]models.RecurringExpense, error) {
+	var recurringExpenses []models.RecurringExpense
+	if err := r.db.
+		Where("is_active = ? AND next_due_date <= ? AND (end_date IS NULL OR next_due_date <= end_date)", true, now).
+		Find(&recurringExpenses).Error; err != nil {
+		return nil, err
+	}
+	return &recurringExpenses, nil
+}
+
+func (r *recurringExpenseRepository) UpdateRecurringExpenseByID(ctx context.Context, recurringExpense *models.RecurringExpense, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Model(&models.RecurringExpense{}).
+		Where("id = ?", id).
+		Select("name", "transaction_category_id", "amount", "day_of_month", "end_date", "next_due_date", "is_active").
+		Updates(recurringExpense)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// DeleteRecurringExpenseByID removes the recurring expense with its pending
+// drafts. Confirmed and dismissed drafts are kept as history.
+func (r *recurringExpenseRepository) DeleteRecurringExpenseByID(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("recurring_expense_id = ? AND status = ?", id, constants.RecurringExpenseDraftPending).Delete(&models.RecurringExpenseDraft{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", id).Delete(&models.RecurringExpense{}).Error
+	})
+}
+
+// CreateRecurringExpenseDraft creates the draft of a due recurring expense and
+// moves the expense on to nextDueDate. The move only applies while the due
+// date is unchanged, so a draft is created once even if runs overlap.
+func (r *recurringExpenseRepository) CreateRecurringExpenseDraft(ctx context.Context, recurringExpense *models.RecurringExpense, draft *models.RecurringExpenseDraft, nextDueDate time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.RecurringExpense{}).
+			Where("id = ? AND next_due_date = ?", recurringExpense.ID, recurringExpense.NextDueDate).
+			Update("next_due_date", nextDueDate)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Create(draft).Error; err != nil {
+			return err
+		}
+
+		recurringExpense.NextDueDate = nextDueDate
+		return nil
+	})
+}
+
+// FlagSkippedRecurringExpenseDrafts flags the pending drafts due before
+// dueBefore, the start of the current month, and returns how many were flagged.
+func (r *recurringExpenseRepository) FlagSkippedRecurringExpenseDrafts(dueBefore time.Time, now time.Time) (int64, error) {
+	res := r.db.Model(&models.RecurringExpenseDraft{}).
+		Where("status = ? AND skipped_at IS NULL AND due_date < ?", constants.RecurringExpenseDraftPending, dueBefore).
+		Update("skipped_at", now)
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
+
+func (r *recurringExpenseRepository) FindAllRecurringExpenseDrafts(roomingHouseIDs []uuid.UUID, status string, skippedOnly bool) (*[]models.RecurringExpenseDraftResponse, error) {
+	drafts := []models.RecurringExpenseDraftResponse{}
+
+	query := r.db.Table("recurring_expense_drafts red").
+		Select("red.id, red.recurring_expense_id, re.name, tc.name AS category_name, red.description, red.amount, red.due_date, red.status, red.skipped_at, red.transaction_id, red.resolved_at, rh.id AS rooming_house_id, rh.name AS rooming_house_name").
+		Joins("JOIN recurring_expenses re ON red.recurring_expense_id = re.id").
+		Joins("JOIN rooming_houses rh ON red.rooming_house_id = rh.id").
+		Joins("JOIN transaction_categories tc ON red.transaction_category_id = tc.id").
+		Where("red.rooming_house_id IN (?) AND red.deleted_at IS NULL", roomingHouseIDs)
+
+	if status != "" {
+		query = query.Where("red.status = ?", status)
+	}
+
+	if skippedOnly {
+		query = query.Where("red.skipped_at IS NOT NULL")
+	}
+
+	if err := query.Order("red.due_date, re.name").Scan(&drafts).Error; err != nil {
+		return nil, err
+	}
+
+	return &drafts, nil
+}
+
+func (r *recurringExpenseRepository) FindRecurringExpenseDraftByID(id uuid.UUID) (*models.RecurringExpenseDraft, error) {
+	var draft models.RecurringExpenseDraft
+	if err := r.db.Where("id = ?", id).First(&draft).Error; err != nil {
+		return nil, err
+	}
+	return &draft, nil
+}
+
+// ConfirmRecurringExpenseDraft posts the transaction of a pending draft. It
+// fails with gorm.ErrRecordNotFound when the draft is no longer pending.
+func (r *recurringExpenseRepository) ConfirmRecurringExpenseDraft(ctx context.Context, draft *models.RecurringExpenseDraft, transaction *models.Transaction, resolvedBy uuid.UUID, resolverRole string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(transaction).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		res := tx.Model(&models.RecurringExpenseDraft{}).
+			Where("id = ? AND status = ?", draft.ID, constants.RecurringExpenseDraftPending).
+			Updates(map[string]interface{}{
+				"status":         constants.RecurringExpenseDraftConfirmed,
+				"amount":         transaction.Amount,
+				"description":    transaction.Description,
+				"transaction_id": transaction.ID,
+				"resolved_at":    now,
+				"resolved_by":    resolvedBy,
+				"resolver_role":  resolverRole,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		draft.Status = constants.RecurringExpenseDraftConfirmed
+		draft.Amount = transaction.Amount
+		draft.Description = transaction.Description
+		draft.TransactionID = &transaction.ID
+		draft.ResolvedAt = &now
+		draft.ResolvedBy = &resolvedBy
+		draft.ResolverRole = resolverRole
+		return nil
+	})
+}
+
+// DismissRecurringExpenseDraft closes a pending draft without posting it. It
+// fails with gorm.ErrRecordNotFound when the draft is no longer pending.
+func (r *recurringExpenseRepository) DismissRecurringExpenseDraft(ctx context.Context, draft *models.RecurringExpenseDraft, resolvedBy uuid.UUID, resolverRole string) error {
+	now := time.Now()
+	res := r.db.WithContext(ctx).Model(&models.RecurringExpenseDraft{}).
+		Where("id = ? AND status = ?", draft.ID, constants.RecurringExpenseDraftPending).
+		Updates(map[string]interface{}{
+			"status":        constants.RecurringExpenseDraftDismissed,
+			"resolved_at":   now,
+			"resolved_by":   resolvedBy,
+			"resolver_role": resolverRole,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	draft.Status = constants.RecurringExpenseDraftDismissed
+	draft.ResolvedAt = &now
+	draft.ResolvedBy = &resolvedBy
+	draft.ResolverRole = resolverRole
+	return nil
+}
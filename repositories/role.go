@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RoleRepository interface {
+	CreateRole(ctx context.Context, role *models.Role) error
+	FindRoleByID(id uuid.UUID) (*models.Role, error)
+	FindSystemRoleByCode(code string) (*models.Role, error)
+	FindAllRoles(ownerID uuid.UUID) (*[]models.Role, error)
+	UpdateRole(ctx context.Context, role *models.Role) error
+	CountRoleAssignments(id uuid.UUID) (int64, error)
+	DeleteRoleByID(ctx context.Context, id uuid.UUID) error
+	FindAdminPermissions(adminID uuid.UUID) (map[uuid.UUID]map[string]bool, error)
+}
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+// CreateRole creates the role together with its permissions.
+func (r *roleRepository) CreateRole(ctx context.Context, role *models.Role) error {
+	return r.db.WithContext(ctx).Create(role).Error
+}
+
+func (r *roleRepository) FindRoleByID(id uuid.UUID) (*models.Role, error) {
+	var role models.Role
+	if err := r.db.Preload("Permissions").Where("id = ?", id).First(&role).Error; err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) FindSystemRoleByCode(code string) (*models.Role, error) {
+	var role models.Role
+	if err := r.db.Where("owner_id IS NULL AND code = ?", code).First(&role).Error; err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// FindAllRoles returns the system roles together with the custom roles of the given owner.
+func (r *roleRepository) FindAllRoles(ownerID uuid.UUID) (*[]models.Role, error) {
+	var roles []models.Role
+	if err := r.db.Preload("Permissions").
+		Where("owner_id IS NULL OR owner_id = ?", ownerID).
+		Order("owner_id IS NOT NULL, name").
+		Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return &roles, nil
+}
+
+// UpdateRole saves the name and description and replaces the permissions.
+func (r *roleRepository) UpdateRole(ctx context.Context, role *models.Role) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Role{}).Where("id = ?", role.ID).
+			Select("name", "description").
+			Updates(role).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Where("role_id = ?", role.ID).Delete(&models.RolePermission{}).Error; err != nil {
+			return err
+		}
+
+		for i := range role.Permissions {
+			role.Permissions[i].RoleID = role.ID
+			if err := tx.Create(&role.Permissions[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *roleRepository) CountRoleAssignments(id uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.AdminRoomingHouse{}).Where("role_id = ?", id).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *roleRepository) DeleteRoleByID(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("role_id = ?", id).Delete(&models.RolePermission{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", id).Delete(&models.Role{}).Error
+	})
+}
+
+// FindAdminPermissions returns the "resource:action" grants of the admin in
+// each assigned rooming house.
+func (r *roleRepository) FindAdminPermissions(adminID uuid.UUID) (map[uuid.UUID]map[string]bool, error) {
+	var rows []struct {
+		RoomingHouseID uuid.UUID
+		Resource       string
+		Action         string
+	}
+
+	if err := r.db.Table("admin_rooming_houses arh").
+		Select("arh.rooming_house_id, rp.resource, rp.action").
+		Joins("JOIN roles ON roles.id = arh.role_id AND roles.deleted_at IS NULL").
+		Joins("JOIN role_permissions rp ON rp.role_id = roles.id AND rp.deleted_at IS NULL").
+		Where("arh.admin_id = ? AND arh.deleted_at IS NULL", adminID).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	permissions := map[uuid.UUID]map[string]bool{}
+	for _, row := range rows {
+		if permissions[row.RoomingHouseID] == nil {
+			permissions[row.RoomingHouseID] = map[string]bool{}
+		}
+		permissions[row.RoomingHouseID][constants.Permission(row.Resource, row.Action)] = true
+	}
+
+	return permissions, nil
+}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"context"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/models"
 	"rooming-house-cms-be/utils"
 	"time"
@@ -10,11 +12,14 @@ import (
 )
 
 type RoomRepository interface {
-	CreateRoom(room *models.Room) error
-	FindAllRooms(roomingHouseIDs []uuid.UUID) (*[]models.AllRoomResponse, error)
-	FindRoomByID(roomID uuid.UUID, roomingHouseID uuid.UUID, userID uuid.UUID, userRole string) (*models.RoomDetailResponse, error)
-	UpdateRoomByID(room *models.Room, id uuid.UUID) error
-	DeleteRoomByID(id uuid.UUID) error
+	CreateRoom(ctx context.Context, room *models.Room) error
+	FindAllRooms(roomingHouseIDs []uuid.UUID, status string) (*[]models.AllRoomResponse, error)
+	FindRoomByID(roomID uuid.UUID, roomingHouseIDs []uuid.UUID, userID uuid.UUID, userRole string) (*models.RoomDetailResponse, error)
+	FindTenantPortalRoom(roomID uuid.UUID, roomingHouseID uuid.UUID) (*models.TenantPortalRoomResponse, error)
+	UpdateRoomByID(ctx context.Context, room *models.Room, id uuid.UUID) error
+	UpdateRoomStatus(ctx context.Context, roomStatusHistory *models.RoomStatusHistory) error
+	FindRoomStatusHistories(roomID uuid.UUID) (*[]models.RoomStatusHistory, error)
+	DeleteRoomByID(ctx context.Context, id uuid.UUID) error
 }
 
 type roomRepository struct {
@@ -25,14 +30,14 @@ func NewRoomRepository(db *gorm.DB) RoomRepository {
 	return &roomRepository{db: db}
 }
 
-func (r *roomRepository) CreateRoom(room *models.Room) error {
-	if err := r.db.Create(room).Error; err != nil {
+func (r *roomRepository) CreateRoom(ctx context.Context, room *models.Room) error {
+	if err := r.db.WithContext(ctx).Create(room).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *roomRepository) FindAllRooms(roomingHouseIDs []uuid.UUID) (*[]models.AllRoomResponse, error) {
+func (r *roomRepository) FindAllRooms(roomingHouseIDs []uuid.UUID, status string) (*[]models.AllRoomResponse, error) {
 	var response []models.AllRoomResponse
 
 	now := time.Now()
@@ -44,6 +49,7 @@ func (r *roomRepository) FindAllRooms(roomingHouseIDs []uuid.UUID) (*[]models.Al
 			r.floor AS floor_number,
 			r.max_capacity,
 			r.rooming_house_id,
+			r.status,
 			t.id AS tenant_id,
 			t.name AS tenant_name,
 			t.gender AS tenant_gender,
@@ -61,11 +67,17 @@ func (r *roomRepository) FindAllRooms(roomingHouseIDs []uuid.UUID) (*[]models.Al
 			AND t.start_date <= ? 
 			AND t.end_date >= ?
 		WHERE 
-			r.rooming_house_id IN (?);
+			r.rooming_house_id IN (?)
 	`
+	args := []interface{}{now, now, roomingHouseIDs}
+
+	if status != "" {
+		query += " AND r.status = ?"
+		args = append(args, status)
+	}
 
 	// Replace with raw query and scan into a struct
-	rows, err := r.db.Raw(query, now, now, roomingHouseIDs).Rows()
+	rows, err := r.db.Raw(query, args...).Rows()
 	if err != nil {
 		return nil, err
 	}
@@ -91,6 +103,7 @@ func (r *roomRepository) FindAllRooms(roomingHouseIDs []uuid.UUID) (*[]models.Al
 			&room.Floor,
 			&room.MaxCapacity,
 			&room.RoomingHouseID,
+			&room.Status,
 			&tenant.ID,
 			&tenant.Name,
 			&tenant.Gender,
@@ -118,24 +131,37 @@ func (r *roomRepository) FindAllRooms(roomingHouseIDs []uuid.UUID) (*[]models.Al
 			}
 		}
 
+		room.IsAvailable = room.Status == constants.RoomStatusAvailable && tenant.ID == nil
+
 		response = append(response, room)
 	}
 
 	return &response, nil
 }
 
-func (r *roomRepository) FindRoomByID(roomID uuid.UUID, roomingHouseID uuid.UUID, userPayload uuid.UUID, userRole string) (*models.RoomDetailResponse, error) {
+// FindRoomByID returns the room of an owner's or co-owner's house. Admins only
+// see rooms of their assigned houses within roomingHouseIDs, the request scope
+// narrowed by permission; owners ignore it.
+func (r *roomRepository) FindRoomByID(roomID uuid.UUID, roomingHouseIDs []uuid.UUID, userPayload uuid.UUID, userRole string) (*models.RoomDetailResponse, error) {
 	var room models.Room
 	now := time.Now()
 
 	if userRole == "admin" {
-		if err := r.db.Preload("Facilities").Where("id = ? AND rooming_house_id = ?", roomID, roomingHouseID).First(&room).Error; err != nil {
+		if len(roomingHouseIDs) == 0 {
+			return nil, gorm.ErrRecordNotFound
+		}
+
+		if err := r.db.Preload("Facilities").
+			Where("id = ?", roomID).
+			Where("rooming_house_id IN (?)", roomingHouseIDs).
+			Where("rooming_house_id IN (?)", r.db.Model(&models.AdminRoomingHouse{}).Select("rooming_house_id").Where("admin_id = ?", userPayload)).
+			First(&room).Error; err != nil {
 			return nil, err
 		}
 	} else {
 		var roomingHouses []models.RoomingHouse
 
-		if err := r.db.Find(&roomingHouses, "owner_id = ?", userPayload).Error; err != nil {
+		if err := r.db.Where("owner_id = ? OR id IN (?)", userPayload, coOwnedRoomingHouseIDs(r.db, userPayload)).Find(&roomingHouses).Error; err != nil {
 			return nil, err
 		}
 
@@ -153,7 +179,7 @@ func (r *roomRepository) FindRoomByID(roomID uuid.UUID, roomingHouseID uuid.UUID
 
 	// Query pertama untuk tenant utama
 	if err := r.db.Table("tenants as t").
-		Select("t.id, t.name, t.gender, t.phone_number, t.emergency_contact, t.is_tenant, t.start_date, t.end_date, t.regular_payment_duration").
+		Select("t.id, t.name, t.gender, t.phone_number, t.emergency_contact, t.id_number, t.occupation, t.institution, t.is_tenant, t.start_date, t.end_date, t.regular_payment_duration").
 		Where("t.room_id = ? AND t.is_tenant = true AND t.start_date <= ? AND t.end_date >= ? AND t.deleted_at IS NULL", roomID, now, now).
 		Scan(&tenantWithAssists).Error; err != nil {
 		return nil, err
@@ -190,6 +216,7 @@ func (r *roomRepository) FindRoomByID(roomID uuid.UUID, roomingHouseID uuid.UUID
 		MaxCapacity:    room.MaxCapacity,
 		Size:           size,
 		RoomingHouseID: room.RoomingHouseID,
+		Status:         room.Status,
 		Tenants:        &tenantWithAssists,
 		Facilities:     room.Facilities,
 		PricingPackage: models.PackageResponse{
@@ -207,8 +234,56 @@ func (r *roomRepository) FindRoomByID(roomID uuid.UUID, roomingHouseID uuid.UUID
 	return &roomDetailResponse, nil
 }
 
-func (r *roomRepository) UpdateRoomByID(room *models.Room, id uuid.UUID) error {
-	res := r.db.Model(&models.Room{}).Where("id = ?", id).Updates(room)
+func (r *roomRepository) FindTenantPortalRoom(roomID uuid.UUID, roomingHouseID uuid.UUID) (*models.TenantPortalRoomResponse, error) {
+	var room models.Room
+	if err := r.db.Preload("Facilities").Where("id = ? AND rooming_house_id = ?", roomID, roomingHouseID).First(&room).Error; err != nil {
+		return nil, err
+	}
+
+	var size models.Size
+	if err := r.db.Where("id = ?", room.SizeID).First(&size).Error; err != nil {
+		return nil, err
+	}
+
+	var roomingHouse models.RoomingHouse
+	if err := r.db.Where("id = ?", room.RoomingHouseID).First(&roomingHouse).Error; err != nil {
+		return nil, err
+	}
+
+	var pricingPackage models.PricingPackage
+	if err := r.db.Preload("PeriodPackages.Period").
+		Where("id = ?", room.PackageID).
+		First(&pricingPackage).Error; err != nil {
+		return nil, err
+	}
+
+	response := models.TenantPortalRoomResponse{
+		ID:    room.ID,
+		Name:  room.Name,
+		Floor: room.Floor,
+		Size:  size,
+		RoomingHouse: models.TenantRoomingHouseResponse{
+			ID:   roomingHouse.ID,
+			Name: roomingHouse.Name,
+		},
+		PricingPackage: models.PackageResponse{
+			ID:             pricingPackage.ID,
+			Name:           pricingPackage.Name,
+			RoomingHouseID: pricingPackage.RoomingHouseID,
+			Prices:         map[string]float64{},
+		},
+		Facilities: room.Facilities,
+	}
+
+	for _, periodPackage := range pricingPackage.PeriodPackages {
+		response.PricingPackage.Prices[periodPackage.Period.Name] = periodPackage.Price
+	}
+
+	return &response, nil
+}
+
+func (r *roomRepository) UpdateRoomByID(ctx context.Context, room *models.Room, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Model(&models.Room{}).Where("id = ?", id).Updates(room)
 	if res.Error != nil {
 		if res.Error == gorm.ErrRecordNotFound {
 			return res.Error
@@ -223,8 +298,33 @@ func (r *roomRepository) UpdateRoomByID(room *models.Room, id uuid.UUID) error {
 	return nil
 }
 
-func (r *roomRepository) DeleteRoomByID(id uuid.UUID) error {
-	res := r.db.Delete(&models.Room{}, "id = ?", id)
+// UpdateRoomStatus moves the room to the history entry's target status and
+// records the entry alongside it.
+func (r *roomRepository) UpdateRoomStatus(ctx context.Context, roomStatusHistory *models.RoomStatusHistory) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.Room{}).Where("id = ?", roomStatusHistory.RoomID).Update("status", roomStatusHistory.ToStatus)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Create(roomStatusHistory).Error
+	})
+}
+
+func (r *roomRepository) FindRoomStatusHistories(roomID uuid.UUID) (*[]models.RoomStatusHistory, error) {
+	var roomStatusHistories []models.RoomStatusHistory
+	if err := r.db.Where("room_id = ?", roomID).Order("created_at DESC").Find(&roomStatusHistories).Error; err != nil {
+		return nil, err
+	}
+	return &roomStatusHistories, nil
+}
+
+func (r *roomRepository) DeleteRoomByID(ctx context.Context, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Delete(&models.Room{}, "id = ?", id)
 	if res.Error != nil {
 		if res.Error == gorm.ErrRecordNotFound {
 			return res.Error
@@ -1,7 +1,7 @@
 package repositories
 
 import (
-	"errors"
+	"context"
 	"rooming-house-cms-be/models"
 
 	"github.com/google/uuid"
@@ -9,9 +9,9 @@ import (
 )
 
 type RoomFacilityRepository interface {
-	CreateRoomFacility(roomFacility *[]models.RoomFacility) error
+	CreateRoomFacility(ctx context.Context, roomFacility *[]models.RoomFacility) error
 	FindRoomFacilitiesByRoomID(id uuid.UUID) (*[]models.RoomFacility, error)
-	UpdateRoomFacilityByRoomID(roomFacility *[]models.RoomFacility, id uuid.UUID) error
+	UpdateRoomFacilityByRoomID(ctx context.Context, roomFacility *[]models.RoomFacility, id uuid.UUID) error
 }
 
 type roomFacilityRepository struct {
@@ -22,8 +22,8 @@ func NewRoomFacilityRepository(db *gorm.DB) RoomFacilityRepository {
 	return &roomFacilityRepository{db: db}
 }
 
-func (r *roomFacilityRepository) CreateRoomFacility(roomFacility *[]models.RoomFacility) error {
-	if err := r.db.Create(roomFacility).Error; err != nil {
+func (r *roomFacilityRepository) CreateRoomFacility(ctx context.Context, roomFacility *[]models.RoomFacility) error {
+	if err := r.db.WithContext(ctx).Create(roomFacility).Error; err != nil {
 		return err
 	}
 	return nil
@@ -37,17 +37,43 @@ func (r *roomFacilityRepository) FindRoomFacilitiesByRoomID(id uuid.UUID) (*[]mo
 	return &roomFacilities, nil
 }
 
-func (r *roomFacilityRepository) UpdateRoomFacilityByRoomID(roomFacility *[]models.RoomFacility, id uuid.UUID) error {
-	res := r.db.Delete(&roomFacility, "room_id = ?", id)
-	if res.Error != nil {
-		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
-			return errors.New("room facility not found")
+// UpdateRoomFacilityByRoomID sets the facilities of the room, keeping the rows
+// of facilities it already has and only deleting removed ones and creating new
+// ones.
+func (r *roomFacilityRepository) UpdateRoomFacilityByRoomID(ctx context.Context, roomFacility *[]models.RoomFacility, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []models.RoomFacility
+		if err := tx.Where("room_id = ?", id).Find(&existing).Error; err != nil {
+			return err
 		}
-	}
 
-	if err := r.db.Create(roomFacility).Error; err != nil {
-		return err
-	}
+		rows := make([]facilityRow, len(existing))
+		for i, row := range existing {
+			rows[i] = facilityRow{id: row.ID, facilityID: row.FacilityID}
+		}
 
-	return nil
+		var facilityIDs []uuid.UUID
+		for _, row := range *roomFacility {
+			facilityIDs = append(facilityIDs, row.FacilityID)
+		}
+
+		removedRowIDs, addedFacilityIDs := diffFacilityRows(rows, facilityIDs)
+
+		if len(removedRowIDs) > 0 {
+			if err := tx.Where("id IN ?", removedRowIDs).Delete(&models.RoomFacility{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(addedFacilityIDs) == 0 {
+			return nil
+		}
+
+		added := make([]models.RoomFacility, len(addedFacilityIDs))
+		for i, facilityID := range addedFacilityIDs {
+			added[i] = models.RoomFacility{RoomID: id, FacilityID: facilityID}
+		}
+
+		return tx.Create(&added).Error
+	})
 }
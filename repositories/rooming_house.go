@@ -1,7 +1,9 @@
 package repositories
 
 import (
+	"context"
 	"errors"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/models"
 
 	"github.com/google/uuid"
@@ -9,11 +11,11 @@ import (
 )
 
 type RoomingHouseRepository interface {
-	CreateRoomingHouse(roomingHouse *models.RoomingHouse) error
-	FindRoomingHouseByID(roomingHouseID uuid.UUID, userID uuid.UUID, role string) (*models.RoomingHouseByIDResponse, error)
-	FindAllRoomingHouse(roomingHouseID uuid.UUID, userID uuid.UUID, role string) ([]models.AllRoomingHouseResponse, error)
-	UpdateRoomingHouse(roomingHouse *models.RoomingHouse, id uuid.UUID) error
-	DeleteRoomingHouse(id uuid.UUID) error
+	CreateRoomingHouse(ctx context.Context, roomingHouse *models.RoomingHouse) error
+	FindRoomingHouseByID(roomingHouseID uuid.UUID, userID uuid.UUID, role string, scopedRoomingHouseIDs []uuid.UUID) (*models.RoomingHouseByIDResponse, error)
+	FindAllRoomingHouse(userID uuid.UUID, role string) ([]models.AllRoomingHouseResponse, error)
+	UpdateRoomingHouse(ctx context.Context, roomingHouse *models.RoomingHouse, id uuid.UUID) error
+	DeleteRoomingHouse(ctx context.Context, id uuid.UUID) error
 }
 
 type roomingHouseRepository struct {
@@ -24,20 +26,29 @@ func NewRoomingHouseRepository(db *gorm.DB) RoomingHouseRepository {
 	return &roomingHouseRepository{db: db}
 }
 
-func (r *roomingHouseRepository) CreateRoomingHouse(roomingHouse *models.RoomingHouse) error {
-	if err := r.db.Create(roomingHouse).Error; err != nil {
-		return err
-	}
-	return nil
+// CreateRoomingHouse creates the rooming house with its owner holding the full share.
+func (r *roomingHouseRepository) CreateRoomingHouse(ctx context.Context, roomingHouse *models.RoomingHouse) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(roomingHouse).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&models.RoomingHouseOwner{
+			RoomingHouseID: roomingHouse.ID,
+			OwnerID:        roomingHouse.OwnerID,
+			Percentage:     constants.OwnershipTotalPercentage,
+		}).Error
+	})
 }
 
-func (r *roomingHouseRepository) FindRoomingHouseByID(roomingHouseID uuid.UUID, userID uuid.UUID, role string) (*models.RoomingHouseByIDResponse, error) {
+// FindRoomingHouseByID returns the rooming house when the user may access it:
+// owners and co-owners of the house, and admins assigned to it when it is
+// within scopedRoomingHouseIDs, the request scope narrowed by permission.
+func (r *roomingHouseRepository) FindRoomingHouseByID(roomingHouseID uuid.UUID, userID uuid.UUID, role string, scopedRoomingHouseIDs []uuid.UUID) (*models.RoomingHouseByIDResponse, error) {
 	var roomingHouse models.RoomingHouse
 
-	if err := r.db.Preload("Transactions").
-		Preload("Facilities").
+	if err := r.db.Preload("Facilities").
 		Preload("Rooms").
-		Preload("Admin").
 		Where("id = ?", roomingHouseID).
 		First(&roomingHouse).Error; err != nil {
 		return nil, err
@@ -45,10 +56,47 @@ func (r *roomingHouseRepository) FindRoomingHouseByID(roomingHouseID uuid.UUID,
 
 	if role == "owner" {
 		if roomingHouse.OwnerID != userID {
+			var shares int64
+			if err := r.db.Model(&models.RoomingHouseOwner{}).Where("owner_id = ? AND rooming_house_id = ?", userID, roomingHouseID).Count(&shares).Error; err != nil {
+				return nil, err
+			}
+
+			if shares == 0 {
+				return nil, errors.New("rooming house not found")
+			}
+		}
+	} else if role == "admin" {
+		inScope := false
+		for _, scopedRoomingHouseID := range scopedRoomingHouseIDs {
+			if scopedRoomingHouseID == roomingHouseID {
+				inScope = true
+				break
+			}
+		}
+
+		if !inScope {
+			return nil, errors.New("rooming house not found")
+		}
+
+		var assignments int64
+		if err := r.db.Model(&models.AdminRoomingHouse{}).Where("admin_id = ? AND rooming_house_id = ?", userID, roomingHouseID).Count(&assignments).Error; err != nil {
+			return nil, err
+		}
+
+		if assignments == 0 {
 			return nil, errors.New("rooming house not found")
 		}
 	}
 
+	admins := []models.AdminResponse{}
+	if err := r.db.Table("admins").
+		Select("admins.id, admins.username, admins.role").
+		Joins("JOIN admin_rooming_houses arh ON arh.admin_id = admins.id AND arh.deleted_at IS NULL").
+		Where("arh.rooming_house_id = ? AND admins.deleted_at IS NULL", roomingHouseID).
+		Scan(&admins).Error; err != nil {
+		return nil, err
+	}
+
 	roomingHouseResponse := models.RoomingHouseByIDResponse{
 		ID:          roomingHouse.ID,
 		Name:        roomingHouse.Name,
@@ -56,28 +104,22 @@ func (r *roomingHouseRepository) FindRoomingHouseByID(roomingHouseID uuid.UUID,
 		Address:     roomingHouse.Address,
 		FloorTotal:  roomingHouse.FloorTotal,
 		OwnerID:     roomingHouse.OwnerID,
-		Admin: models.AdminResponse{
-			ID:             roomingHouse.Admin.ID,
-			Username:       roomingHouse.Admin.Username,
-			Role:           roomingHouse.Admin.Role,
-			RoomingHouseID: roomingHouse.Admin.RoomingHouseID,
-		},
-		Transactions: roomingHouse.Transactions,
-		Facilities:   roomingHouse.Facilities,
-		Rooms:        roomingHouse.Rooms,
+		Admins:      admins,
+		Facilities:  roomingHouse.Facilities,
+		Rooms:       roomingHouse.Rooms,
 	}
 
 	return &roomingHouseResponse, nil
 }
 
-func (r *roomingHouseRepository) FindAllRoomingHouse(roomingHouseID uuid.UUID, userID uuid.UUID, role string) ([]models.AllRoomingHouseResponse, error) {
+func (r *roomingHouseRepository) FindAllRoomingHouse(userID uuid.UUID, role string) ([]models.AllRoomingHouseResponse, error) {
 	var roomingHouses []models.RoomingHouse
 	if role == "owner" {
-		if err := r.db.Where("owner_id = ?", userID).Find(&roomingHouses).Error; err != nil {
+		if err := r.db.Where("owner_id = ? OR id IN (?)", userID, coOwnedRoomingHouseIDs(r.db, userID)).Find(&roomingHouses).Error; err != nil {
 			return nil, err
 		}
 	} else {
-		if err := r.db.Where("id = ?", roomingHouseID).Find(&roomingHouses).Error; err != nil {
+		if err := r.db.Where("id IN (?)", r.db.Model(&models.AdminRoomingHouse{}).Select("rooming_house_id").Where("admin_id = ?", userID)).Find(&roomingHouses).Error; err != nil {
 			return nil, err
 		}
 	}
@@ -108,8 +150,8 @@ func (r *roomingHouseRepository) Dashboard(roomingHouseIDs []uuid.UUID) ([]model
 	return transactions, nil
 }
 
-func (r *roomingHouseRepository) UpdateRoomingHouse(roomingHouse *models.RoomingHouse, id uuid.UUID) error {
-	res := r.db.Model(&roomingHouse).Where("id = ?", id).Updates(roomingHouse)
+func (r *roomingHouseRepository) UpdateRoomingHouse(ctx context.Context, roomingHouse *models.RoomingHouse, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Model(&roomingHouse).Where("id = ?", id).Updates(roomingHouse)
 	if res.Error != nil {
 		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
 			return errors.New("rooming house not found")
@@ -125,10 +167,10 @@ func (r *roomingHouseRepository) UpdateRoomingHouse(roomingHouse *models.Rooming
 	return nil
 }
 
-func (r *roomingHouseRepository) DeleteRoomingHouse(id uuid.UUID) error {
+func (r *roomingHouseRepository) DeleteRoomingHouse(ctx context.Context, id uuid.UUID) error {
 	roomingHouse := models.RoomingHouse{}
 
-	res := r.db.Delete(&roomingHouse, "id = ?", id)
+	res := r.db.WithContext(ctx).Delete(&roomingHouse, "id = ?", id)
 	if res.Error != nil {
 		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
 			return errors.New("rooming house not found")
@@ -143,3 +185,8 @@ func (r *roomingHouseRepository) DeleteRoomingHouse(id uuid.UUID) error {
 
 	return nil
 }
+
+// coOwnedRoomingHouseIDs selects the rooming houses the owner holds a share of.
+func coOwnedRoomingHouseIDs(db *gorm.DB, ownerID uuid.UUID) *gorm.DB {
+	return db.Model(&models.RoomingHouseOwner{}).Select("rooming_house_id").Where("owner_id = ?", ownerID)
+}
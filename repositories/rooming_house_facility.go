@@ -1,7 +1,7 @@
 package repositories
 
 import (
-	"errors"
+	"context"
 	"rooming-house-cms-be/models"
 
 	"github.com/google/uuid"
@@ -9,9 +9,11 @@ import (
 )
 
 type RoomingHouseFacilityRepository interface {
-	CreateRoomingHouseFacility(roomingHouseFacility *[]models.RoomingHouseFacility) error
+	CreateRoomingHouseFacility(ctx context.Context, roomingHouseFacility *[]models.RoomingHouseFacility) error
 	FindRoomingHouseFacilitiesByRoomingHouseID(id uuid.UUID) (*[]models.RoomingHouseFacility, error)
-	UpdateRoomingHouseFacilityByRoomingHouseID(roomingHouseFacility *[]models.RoomingHouseFacility, id uuid.UUID) error
+	FindRoomingHouseFacilityByID(id uuid.UUID) (*models.RoomingHouseFacility, error)
+	FindRoomingHouseFacilityByFacilityID(roomingHouseID uuid.UUID, facilityID uuid.UUID) (*models.RoomingHouseFacility, error)
+	UpdateRoomingHouseFacilityByRoomingHouseID(ctx context.Context, roomingHouseFacility *[]models.RoomingHouseFacility, id uuid.UUID) error
 }
 
 type roomingHouseFacilityRepository struct {
@@ -22,8 +24,8 @@ func NewRoomingHouseFacilityRepository(db *gorm.DB) RoomingHouseFacilityReposito
 	return &roomingHouseFacilityRepository{db: db}
 }
 
-func (r *roomingHouseFacilityRepository) CreateRoomingHouseFacility(roomingHouseFacility *[]models.RoomingHouseFacility) error {
-	if err := r.db.Create(roomingHouseFacility).Error; err != nil {
+func (r *roomingHouseFacilityRepository) CreateRoomingHouseFacility(ctx context.Context, roomingHouseFacility *[]models.RoomingHouseFacility) error {
+	if err := r.db.WithContext(ctx).Create(roomingHouseFacility).Error; err != nil {
 		return err
 	}
 	return nil
@@ -37,17 +39,94 @@ func (r *roomingHouseFacilityRepository) FindRoomingHouseFacilitiesByRoomingHous
 	return &roomingHouseFacility, nil
 }
 
-func (r *roomingHouseFacilityRepository) UpdateRoomingHouseFacilityByRoomingHouseID(roomingHouseFacility *[]models.RoomingHouseFacility, id uuid.UUID) error {
-	res := r.db.Delete(&roomingHouseFacility, "rooming_house_id = ?", id)
-	if res.Error != nil {
-		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
-			return errors.New("rooming house facility not found")
+func (r *roomingHouseFacilityRepository) FindRoomingHouseFacilityByID(id uuid.UUID) (*models.RoomingHouseFacility, error) {
+	var roomingHouseFacility models.RoomingHouseFacility
+	if err := r.db.Where("id = ?", id).First(&roomingHouseFacility).Error; err != nil {
+		return nil, err
+	}
+	return &roomingHouseFacility, nil
+}
+
+func (r *roomingHouseFacilityRepository) FindRoomingHouseFacilityByFacilityID(roomingHouseID uuid.UUID, facilityID uuid.UUID) (*models.RoomingHouseFacility, error) {
+	var roomingHouseFacility models.RoomingHouseFacility
+	if err := r.db.Where("rooming_house_id = ? AND facility_id = ?", roomingHouseID, facilityID).First(&roomingHouseFacility).Error; err != nil {
+		return nil, err
+	}
+	return &roomingHouseFacility, nil
+}
+
+// UpdateRoomingHouseFacilityByRoomingHouseID sets the facilities of the
+// rooming house. The rows of facilities it keeps are left as they are, so work
+// orders and assets referring to them stay valid; only removed facilities are
+// deleted and new ones created.
+func (r *roomingHouseFacilityRepository) UpdateRoomingHouseFacilityByRoomingHouseID(ctx context.Context, roomingHouseFacility *[]models.RoomingHouseFacility, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []models.RoomingHouseFacility
+		if err := tx.Where("rooming_house_id = ?", id).Find(&existing).Error; err != nil {
+			return err
+		}
+
+		rows := make([]facilityRow, len(existing))
+		for i, row := range existing {
+			rows[i] = facilityRow{id: row.ID, facilityID: row.FacilityID}
+		}
+
+		var facilityIDs []uuid.UUID
+		for _, row := range *roomingHouseFacility {
+			facilityIDs = append(facilityIDs, row.FacilityID)
+		}
+
+		removedRowIDs, addedFacilityIDs := diffFacilityRows(rows, facilityIDs)
+
+		if len(removedRowIDs) > 0 {
+			if err := tx.Where("id IN ?", removedRowIDs).Delete(&models.RoomingHouseFacility{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(addedFacilityIDs) == 0 {
+			return nil
+		}
+
+		added := make([]models.RoomingHouseFacility, len(addedFacilityIDs))
+		for i, facilityID := range addedFacilityIDs {
+			added[i] = models.RoomingHouseFacility{RoomingHouseID: id, FacilityID: facilityID}
+		}
+
+		return tx.Create(&added).Error
+	})
+}
+
+// facilityRow is a room or rooming house facility join row.
+type facilityRow struct {
+	id         uuid.UUID
+	facilityID uuid.UUID
+}
+
+// diffFacilityRows compares the facility rows of a room or rooming house with
+// the facilities it should have. It returns the rows to delete, including
+// duplicates, and the facilities that have no row yet.
+func diffFacilityRows(rows []facilityRow, facilityIDs []uuid.UUID) (removedRowIDs []uuid.UUID, addedFacilityIDs []uuid.UUID) {
+	wanted := map[uuid.UUID]bool{}
+	for _, facilityID := range facilityIDs {
+		wanted[facilityID] = true
+	}
+
+	present := map[uuid.UUID]bool{}
+	for _, row := range rows {
+		if wanted[row.facilityID] && !present[row.facilityID] {
+			present[row.facilityID] = true
+			continue
 		}
+		removedRowIDs = append(removedRowIDs, row.id)
 	}
 
-	if err := r.db.Create(roomingHouseFacility).Error; err != nil {
-		return err
+	for _, facilityID := range facilityIDs {
+		if !present[facilityID] {
+			present[facilityID] = true
+			addedFacilityIDs = append(addedFacilityIDs, facilityID)
+		}
 	}
 
-	return nil
+	return removedRowIDs, addedFacilityIDs
 }
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestDiffFacilityRows(t *testing.T) {
+	kept, removed, added := uuid.New(), uuid.New(), uuid.New()
+	keptRow, duplicateRow, removedRow := uuid.New(), uuid.New(), uuid.New()
+
+	rows := []facilityRow{
+		{id: keptRow, facilityID: kept},
+		{id: duplicateRow, facilityID: kept},
+		{id: removedRow, facilityID: removed},
+	}
+
+	removedRowIDs, addedFacilityIDs := diffFacilityRows(rows, []uuid.UUID{kept, added, added})
+
+	if slices.Contains(removedRowIDs, keptRow) {
+		t.Fatalf("row of kept facility was removed")
+	}
+	if len(removedRowIDs) != 2 || !slices.Contains(removedRowIDs, duplicateRow) || !slices.Contains(removedRowIDs, removedRow) {
+		t.Fatalf("got removed rows %v, want the duplicate and the removed facility", removedRowIDs)
+	}
+	if len(addedFacilityIDs) != 1 || addedFacilityIDs[0] != added {
+		t.Fatalf("got added facilities %v, want only %v", addedFacilityIDs, added)
+	}
+}
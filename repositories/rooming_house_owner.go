@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+	"rooming-house-cms-be/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RoomingHouseOwnerRepository interface {
+	FindRoomingHouseOwners(roomingHouseIDs []uuid.UUID) (*[]models.RoomingHouseOwnerResponse, error)
+	ReplaceRoomingHouseOwners(ctx context.Context, roomingHouseID uuid.UUID, owners []models.RoomingHouseOwner) error
+}
+
+type roomingHouseOwnerRepository struct {
+	db *gorm.DB
+}
+
+func NewRoomingHouseOwnerRepository(db *gorm.DB) RoomingHouseOwnerRepository {
+	return &roomingHouseOwnerRepository{db: db}
+}
+
+// FindRoomingHouseOwners returns the owners of the rooming houses with their
+// shares, the primary owner of each house first.
+func (r *roomingHouseOwnerRepository) FindRoomingHouseOwners(roomingHouseIDs []uuid.UUID) (*[]models.RoomingHouseOwnerResponse, error) {
+	owners := []models.RoomingHouseOwnerResponse{}
+	if err := r.db.Table("rooming_house_owners rho").
+		Select("rho.rooming_house_id, rho.owner_id, o.full_name, o.email, rho.percentage, rho.owner_id = rh.owner_id AS is_primary").
+		Joins("JOIN owners o ON o.id = rho.owner_id").
+		Joins("JOIN rooming_houses rh ON rh.id = rho.rooming_house_id").
+		Where("rho.rooming_house_id IN (?) AND rho.deleted_at IS NULL", roomingHouseIDs).
+		Order("rho.rooming_house_id, is_primary DESC, rho.percentage DESC, o.full_name").
+		Scan(&owners).Error; err != nil {
+		return nil, err
+	}
+	return &owners, nil
+}
+
+// ReplaceRoomingHouseOwners replaces every share of the rooming house.
+func (r *roomingHouseOwnerRepository) ReplaceRoomingHouseOwners(ctx context.Context, roomingHouseID uuid.UUID, owners []models.RoomingHouseOwner) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Hard delete so a removed owner can be added back later
+		if err := tx.Unscoped().Where("rooming_house_id = ?", roomingHouseID).Delete(&models.RoomingHouseOwner{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&owners).Error
+	})
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"rooming-house-cms-be/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SessionRepository interface {
+	CreateSession(session *models.Session) error
+	FindSessionByID(id uuid.UUID) (*models.Session, error)
+	RotateSession(id uuid.UUID, currentHash string, newHash string, expiresAt time.Time) error
+	RevokeSessionByID(id uuid.UUID) error
+	RevokeSessionsByUserID(userID uuid.UUID) error
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+func (r *sessionRepository) CreateSession(session *models.Session) error {
+	if err := r.db.Create(session).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *sessionRepository) FindSessionByID(id uuid.UUID) (*models.Session, error) {
+	var session models.Session
+	if err := r.db.Where("id = ?", id).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// RotateSession swaps the refresh token hash only if currentHash is still the
+// active one, so two concurrent refreshes with the same token cannot both win.
+func (r *sessionRepository) RotateSession(id uuid.UUID, currentHash string, newHash string, expiresAt time.Time) error {
+	res := r.db.Model(&models.Session{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", id, currentHash).
+		Updates(map[string]interface{}{"refresh_token_hash": newHash, "expires_at": expiresAt})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) RevokeSessionByID(id uuid.UUID) error {
+	return r.db.Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeSessionsByUserID ends every session of the user. Call it whenever the
+// user's credentials change or the user is removed.
+func (r *sessionRepository) RevokeSessionsByUserID(userID uuid.UUID) error {
+	return r.db.Model(&models.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"rooming-house-cms-be/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SettlementRepository interface {
+	FindManagementFee(roomingHouseID uuid.UUID) (*models.ManagementFee, error)
+	SaveManagementFee(ctx context.Context, fee *models.ManagementFee) error
+	CreateSettlement(ctx context.Context, settlement *models.Settlement) error
+	CountSettlementsByPeriod(roomingHouseID uuid.UUID, year int, month int) (int64, error)
+	FindAllSettlements(roomingHouseIDs []uuid.UUID, year int) (*[]models.Settlement, error)
+	FindSettlementByID(id uuid.UUID, roomingHouseIDs []uuid.UUID) (*models.Settlement, error)
+	FindSettlementPayouts(settlementID uuid.UUID) (*[]models.SettlementPayoutResponse, error)
+	RecordPayout(ctx context.Context, settlementID uuid.UUID, payoutID uuid.UUID, paidAt time.Time, reference string, paidByID uuid.UUID) error
+	CountPaidPayouts(settlementID uuid.UUID) (int64, error)
+	DeleteSettlement(ctx context.Context, id uuid.UUID) error
+}
+
+type settlementRepository struct {
+	db *gorm.DB
+}
+
+func NewSettlementRepository(db *gorm.DB) SettlementRepository {
+	return &settlementRepository{db: db}
+}
+
+func (r *settlementRepository) FindManagementFee(roomingHouseID uuid.UUID) (*models.ManagementFee, error) {
+	var fee models.ManagementFee
+	if err := r.db.Where("rooming_house_id = ?", roomingHouseID).First(&fee).Error; err != nil {
+		return nil, err
+	}
+	return &fee, nil
+}
+
+// SaveManagementFee creates the rooming house's fee or replaces its terms.
+func (r *settlementRepository) SaveManagementFee(ctx context.Context, fee *models.ManagementFee) error {
+	var existing models.ManagementFee
+	err := r.db.Where("rooming_house_id = ?", fee.RoomingHouseID).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return r.db.WithContext(ctx).Create(fee).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.WithContext(ctx).Model(&existing).Updates(map[string]interface{}{
+		"fee_type": fee.FeeType,
+		"value":    fee.Value,
+	}).Error; err != nil {
+		return err
+	}
+
+	fee.ID = existing.ID
+	fee.CreatedAt = existing.CreatedAt
+	return nil
+}
+
+// CreateSettlement creates the settlement with its lines and payouts.
+func (r *settlementRepository) CreateSettlement(ctx context.Context, settlement *models.Settlement) error {
+	return r.db.WithContext(ctx).Create(settlement).Error
+}
+
+func (r *settlementRepository) CountSettlementsByPeriod(roomingHouseID uuid.UUID, year int, month int) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.Settlement{}).Where("rooming_house_id = ? AND year = ? AND month = ?", roomingHouseID, year, month).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *settlementRepository) FindAllSettlements(roomingHouseIDs []uuid.UUID, year int) (*[]models.Settlement, error) {
+	settlements := []models.Settlement{}
+
+	query := r.db.Where("rooming_house_id IN (?)", roomingHouseIDs)
+	if year != 0 {
+		query = query.Where("year = ?", year)
+	}
+
+	if err := query.Order("year DESC, month DESC").Find(&settlements).Error; err != nil {
+		return nil, err
+	}
+	return &settlements, nil
+}
+
+func (r *settlementRepository) FindSettlementByID(id uuid.UUID, roomingHouseIDs []uuid.UUID) (*models.Settlement, error) {
+	var settlement models.Settlement
+	if err := r.db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("is_expense, category_name")
+	}).Where("id = ? AND rooming_house_id IN (?)", id, roomingHouseIDs).First(&settlement).Error; err != nil {
+		return nil, err
+	}
+	return &settlement, nil
+}
+
+func (r *settlementRepository) FindSettlementPayouts(settlementID uuid.UUID) (*[]models.SettlementPayoutResponse, error) {
+	payouts := []models.SettlementPayoutResponse{}
+	if err := r.db.Table("settlement_payouts sp").
+		Select("sp.id, sp.owner_id, o.full_name AS owner_name, sp.percentage, sp.amount, sp.paid_at, sp.reference").
+		Joins("JOIN owners o ON o.id = sp.owner_id").
+		Where("sp.settlement_id = ? AND sp.deleted_at IS NULL", settlementID).
+		Order("sp.percentage DESC, o.full_name").
+		Scan(&payouts).Error; err != nil {
+		return nil, err
+	}
+	return &payouts, nil
+}
+
+// RecordPayout marks an unpaid payout of the settlement as paid. It fails with
+// gorm.ErrRecordNotFound when the payout does not exist or is already paid.
+func (r *settlementRepository) RecordPayout(ctx context.Context, settlementID uuid.UUID, payoutID uuid.UUID, paidAt time.Time, reference string, paidByID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Model(&models.SettlementPayout{}).
+		Where("id = ? AND settlement_id = ? AND paid_at IS NULL", payoutID, settlementID).
+		Updates(map[string]interface{}{
+			"paid_at":    paidAt,
+			"reference":  reference,
+			"paid_by_id": paidByID,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *settlementRepository) CountPaidPayouts(settlementID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.SettlementPayout{}).Where("settlement_id = ? AND paid_at IS NOT NULL", settlementID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteSettlement removes the settlement with its lines and payouts. It is a
+// hard delete so the month can be settled again.
+func (r *settlementRepository) DeleteSettlement(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("settlement_id = ?", id).Delete(&models.SettlementPayout{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Where("settlement_id = ?", id).Delete(&models.SettlementLine{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Where("id = ?", id).Delete(&models.Settlement{}).Error
+	})
+}
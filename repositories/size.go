@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"errors"
 	"rooming-house-cms-be/models"
 
@@ -9,11 +10,11 @@ import (
 )
 
 type SizeRepository interface {
-	CreateSize(size *models.Size) error
+	CreateSize(ctx context.Context, size *models.Size) error
 	FindSizeByID(id uuid.UUID) (*models.Size, error)
 	FindAllSizes(roomingHouseID []uuid.UUID) (*[]models.AllSizeResponse, error)
-	UpdateSizeByID(size *models.Size, id uuid.UUID) error
-	DeleteSizeByID(id uuid.UUID) error
+	UpdateSizeByID(ctx context.Context, size *models.Size, id uuid.UUID) error
+	DeleteSizeByID(ctx context.Context, id uuid.UUID) error
 }
 
 type sizeRepository struct {
@@ -24,8 +25,8 @@ func NewSizeRepository(db *gorm.DB) SizeRepository {
 	return &sizeRepository{db: db}
 }
 
-func (r *sizeRepository) CreateSize(size *models.Size) error {
-	if err := r.db.Create(size).Error; err != nil {
+func (r *sizeRepository) CreateSize(ctx context.Context, size *models.Size) error {
+	if err := r.db.WithContext(ctx).Create(size).Error; err != nil {
 		return err
 	}
 	return nil
@@ -74,8 +75,8 @@ func (r *sizeRepository) FindAllSizes(roomingHouseID []uuid.UUID) (*[]models.All
 	return &responses, nil
 }
 
-func (r *sizeRepository) UpdateSizeByID(size *models.Size, id uuid.UUID) error {
-	res := r.db.Where("id = ?", id).Updates(size)
+func (r *sizeRepository) UpdateSizeByID(ctx context.Context, size *models.Size, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("id = ?", id).Updates(size)
 	if res.Error != nil {
 		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
 			return errors.New("size not found")
@@ -90,8 +91,8 @@ func (r *sizeRepository) UpdateSizeByID(size *models.Size, id uuid.UUID) error {
 	return nil
 }
 
-func (r *sizeRepository) DeleteSizeByID(id uuid.UUID) error {
-	res := r.db.Delete(&models.Size{}, "id = ?", id)
+func (r *sizeRepository) DeleteSizeByID(ctx context.Context, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Delete(&models.Size{}, "id = ?", id)
 	if res.Error != nil {
 		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
 			return errors.New("size not found")
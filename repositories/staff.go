@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"context"
+	"rooming-house-cms-be/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StaffRepository interface {
+	CreateStaff(ctx context.Context, staff *models.Staff) error
+	FindAllStaff(roomingHouseIDs []uuid.UUID, includeInactive bool) (*[]models.Staff, error)
+	FindStaffByID(id uuid.UUID) (*models.Staff, error)
+	FindPayableStaff(roomingHouseID uuid.UUID, periodStart time.Time, periodEnd time.Time) (*[]models.Staff, error)
+	UpdateStaff(ctx context.Context, staff *models.Staff) error
+	DeleteStaffByID(ctx context.Context, id uuid.UUID) error
+}
+
+type staffRepository struct {
+	db *gorm.DB
+}
+
+func NewStaffRepository(db *gorm.DB) StaffRepository {
+	return &staffRepository{db: db}
+}
+
+// CreateStaff creates the staff member with their pay components.
+func (r *staffRepository) CreateStaff(ctx context.Context, staff *models.Staff) error {
+	if err := r.db.WithContext(ctx).Create(staff).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *staffRepository) FindAllStaff(roomingHouseIDs []uuid.UUID, includeInactive bool) (*[]models.Staff, error) {
+	staff := []models.Staff{}
+
+	query := r.db.Preload("Components").Where("rooming_house_id IN (?)", roomingHouseIDs)
+	if !includeInactive {
+		query = query.Where("inactive_at IS NULL")
+	}
+
+	if err := query.Order("name").Find(&staff).Error; err != nil {
+		return nil, err
+	}
+	return &staff, nil
+}
+
+func (r *staffRepository) FindStaffByID(id uuid.UUID) (*models.Staff, error) {
+	var staff models.Staff
+	if err := r.db.Preload("Components").Where("id = ?", id).First(&staff).Error; err != nil {
+		return nil, err
+	}
+	return &staff, nil
+}
+
+// FindPayableStaff returns the staff of the rooming house employed during the
+// period: joined before it ends and not made inactive before it starts.
+func (r *staffRepository) FindPayableStaff(roomingHouseID uuid.UUID, periodStart time.Time, periodEnd time.Time) (*[]models.Staff, error) {
+	staff := []models.Staff{}
+	if err := r.db.Preload("Components").
+		Where("rooming_house_id = ?", roomingHouseID).
+		Where("joined_at IS NULL OR joined_at < ?", periodEnd).
+		Where("inactive_at IS NULL OR inactive_at >= ?", periodStart).
+		Order("name").
+		Find(&staff).Error; err != nil {
+		return nil, err
+	}
+	return &staff, nil
+}
+
+// UpdateStaff saves the staff member's details and replaces their pay components.
+func (r *staffRepository) UpdateStaff(ctx context.Context, staff *models.Staff) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Staff{}).Where("id = ?", staff.ID).Updates(map[string]interface{}{
+			"admin_id":     staff.AdminID,
+			"name":         staff.Name,
+			"position":     staff.Position,
+			"phone_number": staff.PhoneNumber,
+			"base_salary":  staff.BaseSalary,
+			"joined_at":    staff.JoinedAt,
+			"inactive_at":  staff.InactiveAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("staff_id = ?", staff.ID).Delete(&models.StaffPayComponent{}).Error; err != nil {
+			return err
+		}
+
+		for i := range staff.Components {
+			staff.Components[i].StaffID = staff.ID
+			if err := tx.Create(&staff.Components[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *staffRepository) DeleteStaffByID(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("staff_id = ?", id).Delete(&models.StaffPayComponent{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", id).Delete(&models.Staff{}).Error
+	})
+}
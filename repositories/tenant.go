@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"fmt"
 	"rooming-house-cms-be/models"
 	"time"
@@ -10,11 +11,13 @@ import (
 )
 
 type TenantRepository interface {
-	CreateTenant(tenant *models.Tenant) error
+	CreateTenant(ctx context.Context, tenant *models.Tenant) error
 	FindAllTenants(roomingHouseIDs []uuid.UUID, IsTenant bool) (*[]models.AllTenantRepoResponse, error)
 	FindTenantByID(tenantID uuid.UUID, roomingHouseIDs []uuid.UUID) (*models.TenantDetailResponse, error)
-	UpdateTenantByID(tenant *models.Tenant, id uuid.UUID) error
-	DeleteTenantByID(id uuid.UUID) error
+	FindActiveTenantMatches(roomingHouseIDs []uuid.UUID, phoneNumber string, idNumber string) ([]models.TenantScreeningMatch, error)
+	UpdateTenantByID(ctx context.Context, tenant *models.Tenant, id uuid.UUID) error
+	UpdateTenantProfileByID(ctx context.Context, tenant *models.Tenant, id uuid.UUID) error
+	DeleteTenantByID(ctx context.Context, id uuid.UUID) error
 }
 
 type tenantRepository struct {
@@ -25,8 +28,8 @@ func NewTenantRepository(db *gorm.DB) TenantRepository {
 	return &tenantRepository{db: db}
 }
 
-func (r *tenantRepository) CreateTenant(tenant *models.Tenant) error {
-	if err := r.db.Create(tenant).Error; err != nil {
+func (r *tenantRepository) CreateTenant(ctx context.Context, tenant *models.Tenant) error {
+	if err := r.db.WithContext(ctx).Create(tenant).Error; err != nil {
 		return err
 	}
 	return nil
@@ -59,7 +62,7 @@ func (r *tenantRepository) FindTenantByID(tenantID uuid.UUID, roomingHouseIDs []
 	// Query tenant utama untuk mengecek nilai is_tenant
 	if err := r.db.
 		Table("tenants t").
-		Select("t.id, t.name, t.gender, t.phone_number, t.emergency_contact, t.is_tenant, t.rooming_house_id").
+		Select("t.id, t.name, t.gender, t.phone_number, t.emergency_contact, t.id_number, t.date_of_birth, t.occupation, t.institution, t.origin_address, t.is_tenant, t.rooming_house_id").
 		Where("t.id = ? AND t.deleted_at IS NULL AND t.rooming_house_id IN (?)", tenantID, roomingHouseIDs).
 		First(&tenant).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -80,6 +83,11 @@ func (r *tenantRepository) FindTenantByID(tenantID uuid.UUID, roomingHouseIDs []
 			return nil, err
 		}
 
+		vehicles, err := r.findTenantVehicles(tenant.ID)
+		if err != nil {
+			return nil, err
+		}
+
 		// Buat response sederhana
 		return &models.TenantDetailResponse{
 			ID:               tenant.ID,
@@ -87,14 +95,20 @@ func (r *tenantRepository) FindTenantByID(tenantID uuid.UUID, roomingHouseIDs []
 			Gender:           tenant.Gender,
 			PhoneNumber:      tenant.PhoneNumber,
 			EmergencyContact: tenant.EmergencyContact,
+			IDNumber:         tenant.IDNumber,
+			DateOfBirth:      tenant.DateOfBirth,
+			Occupation:       tenant.Occupation,
+			Institution:      tenant.Institution,
+			OriginAddress:    tenant.OriginAddress,
 			RoomingHouse:     roomingHouse,
+			Vehicles:         vehicles,
 		}, nil
 	}
 
 	// Jika is_tenant = true, ambil seluruh data detail tenant
 	var tenantResponse models.TenantDetailResponse
 	if err := r.db.
-		Select("t.id, t.created_at, t.deleted_at, t.updated_at, t.name, t.gender, t.phone_number, t.emergency_contact, t.room_id as booked_room_id, t.start_date, t.end_date, t.regular_payment_duration, t.is_tenant, t.is_deposit_paid, t.is_deposit_back, r.id AS room_id, r.name AS room_name, rh.id AS rooming_house_id, rh.name AS rooming_house_name, p.id AS period_id, p.name AS period_name").
+		Select("t.id, t.created_at, t.deleted_at, t.updated_at, t.name, t.gender, t.phone_number, t.emergency_contact, t.id_number, t.date_of_birth, t.occupation, t.institution, t.origin_address, t.room_id as booked_room_id, t.start_date, t.end_date, t.regular_payment_duration, t.is_tenant, t.is_deposit_paid, t.is_deposit_back, r.id AS room_id, r.name AS room_name, rh.id AS rooming_house_id, rh.name AS rooming_house_name, p.id AS period_id, p.name AS period_name, p.unit AS period_unit, p.count AS period_count").
 		Table("tenants t").
 		Joins("LEFT JOIN rooms r ON t.room_id = r.id AND t.start_date <= ? AND t.end_date >= ?", now, now).
 		Joins("JOIN periods p ON t.period_id = p.id").
@@ -119,7 +133,7 @@ func (r *tenantRepository) FindTenantByID(tenantID uuid.UUID, roomingHouseIDs []
 	// Transactions
 	var transactions []models.TransactionResponse
 	if err := r.db.Table("transactions t").
-		Select("t.id, t.created_at, t.day, t.month, t.year, t.amount, t.description, tc.name, tc.is_expense").
+		Select("t.id, t.created_at, t.day, t.month, t.year, t.amount, t.description, t.transaction_category_id, tc.name, tc.is_expense").
 		Joins("JOIN transaction_categories tc ON t.transaction_category_id = tc.id").
 		Where("tenant_id = ?", tenantID).
 		Scan(&transactions).Error; err != nil {
@@ -140,18 +154,77 @@ func (r *tenantRepository) FindTenantByID(tenantID uuid.UUID, roomingHouseIDs []
 	}
 	tenantResponse.AdditionalPrices = additionalPrices
 
+	vehicles, err := r.findTenantVehicles(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	tenantResponse.Vehicles = vehicles
+
 	return &tenantResponse, nil
 }
 
-func (r *tenantRepository) UpdateTenantByID(tenant *models.Tenant, id uuid.UUID) error {
-	if err := r.db.Where("id = ?", id).Updates(tenant).Error; err != nil {
+func (r *tenantRepository) findTenantVehicles(tenantID uuid.UUID) ([]models.TenantVehicleResponse, error) {
+	var vehicles []models.TenantVehicleResponse
+	if err := r.db.Table("tenant_vehicles").
+		Select("id, plate_number, vehicle_type").
+		Where("tenant_id = ? AND deleted_at IS NULL", tenantID).
+		Scan(&vehicles).Error; err != nil {
+		return nil, err
+	}
+	return vehicles, nil
+}
+
+func (r *tenantRepository) FindActiveTenantMatches(roomingHouseIDs []uuid.UUID, phoneNumber string, idNumber string) ([]models.TenantScreeningMatch, error) {
+	var matches []models.TenantScreeningMatch
+
+	now := time.Now()
+
+	// Only primary tenants currently occupying a room, matching FindAllRooms
+	query := r.db.Table("tenants t").
+		Select("'tenant' AS source, t.id, t.name, t.phone_number, t.id_number, rh.id AS rooming_house_id, rh.name AS rooming_house_name").
+		Joins("JOIN rooming_houses rh ON t.rooming_house_id = rh.id").
+		Where("t.rooming_house_id IN (?) AND t.deleted_at IS NULL", roomingHouseIDs).
+		Where("t.is_tenant = true AND t.start_date <= ? AND t.end_date >= ?", now, now)
+
+	switch {
+	case phoneNumber != "" && idNumber != "":
+		query = query.Where("t.phone_number = ? OR t.id_number = ?", phoneNumber, idNumber)
+	case phoneNumber != "":
+		query = query.Where("t.phone_number = ?", phoneNumber)
+	case idNumber != "":
+		query = query.Where("t.id_number = ?", idNumber)
+	default:
+		return matches, nil
+	}
+
+	if err := query.Scan(&matches).Error; err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+func (r *tenantRepository) UpdateTenantByID(ctx context.Context, tenant *models.Tenant, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Updates(tenant).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateTenantProfileByID writes every profile column, so optional fields
+// left empty in the update are cleared instead of skipped.
+func (r *tenantRepository) UpdateTenantProfileByID(ctx context.Context, tenant *models.Tenant, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Model(&models.Tenant{}).
+		Where("id = ?", id).
+		Select("name", "gender", "phone_number", "emergency_contact", "id_number", "date_of_birth", "occupation", "institution", "origin_address").
+		Updates(tenant).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *tenantRepository) DeleteTenantByID(id uuid.UUID) error {
-	res := r.db.Delete(&models.Tenant{}, "id = ?", id)
+func (r *tenantRepository) DeleteTenantByID(ctx context.Context, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Delete(&models.Tenant{}, "id = ?", id)
 	if res.Error != nil {
 		if res.Error == gorm.ErrRecordNotFound {
 			return res.Error
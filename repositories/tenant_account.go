@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"rooming-house-cms-be/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TenantAccountRepository interface {
+	CreateTenantAccount(ctx context.Context, tenantAccount *models.TenantAccount) error
+	FindTenantAccountByEmail(email string) (*models.TenantAccount, error)
+	FindTenantAccountByTenantID(tenantID uuid.UUID) (*models.TenantAccount, error)
+	UpdateTenantAccountPassword(ctx context.Context, tenantID uuid.UUID, passwordHash string) error
+	DeleteTenantAccountByTenantID(ctx context.Context, tenantID uuid.UUID) error
+}
+
+type tenantAccountRepository struct {
+	db *gorm.DB
+}
+
+func NewTenantAccountRepository(db *gorm.DB) TenantAccountRepository {
+	return &tenantAccountRepository{db: db}
+}
+
+func (r *tenantAccountRepository) CreateTenantAccount(ctx context.Context, tenantAccount *models.TenantAccount) error {
+	if err := r.db.WithContext(ctx).Create(tenantAccount).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *tenantAccountRepository) FindTenantAccountByEmail(email string) (*models.TenantAccount, error) {
+	var tenantAccount models.TenantAccount
+	if err := r.db.Where("email = ?", email).First(&tenantAccount).Error; err != nil {
+		return nil, errors.New("tenant account not found")
+	}
+	return &tenantAccount, nil
+}
+
+func (r *tenantAccountRepository) FindTenantAccountByTenantID(tenantID uuid.UUID) (*models.TenantAccount, error) {
+	var tenantAccount models.TenantAccount
+	if err := r.db.Where("tenant_id = ?", tenantID).First(&tenantAccount).Error; err != nil {
+		return nil, err
+	}
+	return &tenantAccount, nil
+}
+
+func (r *tenantAccountRepository) UpdateTenantAccountPassword(ctx context.Context, tenantID uuid.UUID, passwordHash string) error {
+	return r.db.WithContext(ctx).Model(&models.TenantAccount{}).Where("tenant_id = ?", tenantID).Update("password", passwordHash).Error
+}
+
+func (r *tenantAccountRepository) DeleteTenantAccountByTenantID(ctx context.Context, tenantID uuid.UUID) error {
+	// Hard delete so the email can be reused by a new account
+	res := r.db.WithContext(ctx).Unscoped().Where("tenant_id = ?", tenantID).Delete(&models.TenantAccount{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"rooming-house-cms-be/models"
 
 	"github.com/google/uuid"
@@ -8,9 +9,9 @@ import (
 )
 
 type TenantAdditionalRepository interface {
-	CreateTenantAdditional(tenantAdditional *[]models.TenantAdditionalPrice) error
+	CreateTenantAdditional(ctx context.Context, tenantAdditional *[]models.TenantAdditionalPrice) error
 	FindAllTenantAdditionalsByTenantID(id uuid.UUID) (*[]models.TenantAdditionalPrice, error)
-	UpdateTenantAdditionalByTenantID(tenantAdditional *[]models.TenantAdditionalPrice, id uuid.UUID) error
+	UpdateTenantAdditionalByTenantID(ctx context.Context, tenantAdditional *[]models.TenantAdditionalPrice, id uuid.UUID) error
 }
 
 type tenantAdditionalRepository struct {
@@ -21,8 +22,8 @@ func NewTenantAdditionalRepository(db *gorm.DB) TenantAdditionalRepository {
 	return &tenantAdditionalRepository{db: db}
 }
 
-func (r *tenantAdditionalRepository) CreateTenantAdditional(tenantAdditional *[]models.TenantAdditionalPrice) error {
-	if err := r.db.Create(tenantAdditional).Error; err != nil {
+func (r *tenantAdditionalRepository) CreateTenantAdditional(ctx context.Context, tenantAdditional *[]models.TenantAdditionalPrice) error {
+	if err := r.db.WithContext(ctx).Create(tenantAdditional).Error; err != nil {
 		return err
 	}
 	return nil
@@ -37,13 +38,13 @@ func (r *tenantAdditionalRepository) FindAllTenantAdditionalsByTenantID(id uuid.
 	return &tenantAdditionals, nil
 }
 
-func (r *tenantAdditionalRepository) UpdateTenantAdditionalByTenantID(tenantAdditional *[]models.TenantAdditionalPrice, id uuid.UUID) error {
-	res := r.db.Delete(&tenantAdditional, "tenant_id = ?", id)
+func (r *tenantAdditionalRepository) UpdateTenantAdditionalByTenantID(ctx context.Context, tenantAdditional *[]models.TenantAdditionalPrice, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Delete(&tenantAdditional, "tenant_id = ?", id)
 	if res.Error != nil {
 		return res.Error
 	}
 
-	if err := r.db.Create(tenantAdditional).Error; err != nil {
+	if err := r.db.WithContext(ctx).Create(tenantAdditional).Error; err != nil {
 		return err
 	}
 
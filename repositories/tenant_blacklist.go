@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"rooming-house-cms-be/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TenantBlacklistRepository interface {
+	CreateTenantBlacklist(ctx context.Context, tenantBlacklist *models.TenantBlacklist) error
+	FindAllTenantBlacklists(ownerID uuid.UUID) (*[]models.TenantBlacklistResponse, error)
+	FindTenantBlacklistByID(id uuid.UUID, ownerID uuid.UUID) (*models.TenantBlacklist, error)
+	FindTenantBlacklistMatches(ownerID uuid.UUID, phoneNumber string, idNumber string) ([]models.TenantScreeningMatch, error)
+	DeleteTenantBlacklistByID(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error
+	CreateScreeningOverride(ctx context.Context, override *models.TenantScreeningOverride) error
+	FindAllScreeningOverrides(ownerID uuid.UUID) (*[]models.TenantScreeningOverrideResponse, error)
+}
+
+type tenantBlacklistRepository struct {
+	db *gorm.DB
+}
+
+func NewTenantBlacklistRepository(db *gorm.DB) TenantBlacklistRepository {
+	return &tenantBlacklistRepository{db: db}
+}
+
+func (r *tenantBlacklistRepository) CreateTenantBlacklist(ctx context.Context, tenantBlacklist *models.TenantBlacklist) error {
+	if err := r.db.WithContext(ctx).Create(tenantBlacklist).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *tenantBlacklistRepository) FindAllTenantBlacklists(ownerID uuid.UUID) (*[]models.TenantBlacklistResponse, error) {
+	var tenantBlacklists []models.TenantBlacklistResponse
+	if err := r.db.Table("tenant_blacklists tb").
+		Select("tb.id, tb.tenant_id, tb.name, tb.phone_number, tb.id_number, tb.reason, tb.evidence, tb.created_by, tb.created_at, rh.id AS rooming_house_id, rh.name AS rooming_house_name").
+		Joins("JOIN rooming_houses rh ON tb.rooming_house_id = rh.id").
+		Where("tb.owner_id = ? AND tb.deleted_at IS NULL", ownerID).
+		Order("tb.created_at DESC").
+		Scan(&tenantBlacklists).Error; err != nil {
+		return nil, err
+	}
+	return &tenantBlacklists, nil
+}
+
+func (r *tenantBlacklistRepository) FindTenantBlacklistByID(id uuid.UUID, ownerID uuid.UUID) (*models.TenantBlacklist, error) {
+	var tenantBlacklist models.TenantBlacklist
+	if err := r.db.Where("id = ? AND owner_id = ?", id, ownerID).First(&tenantBlacklist).Error; err != nil {
+		return nil, err
+	}
+	return &tenantBlacklist, nil
+}
+
+func (r *tenantBlacklistRepository) FindTenantBlacklistMatches(ownerID uuid.UUID, phoneNumber string, idNumber string) ([]models.TenantScreeningMatch, error) {
+	var matches []models.TenantScreeningMatch
+
+	query := r.db.Table("tenant_blacklists tb").
+		Select("'blacklist' AS source, tb.id, tb.name, tb.phone_number, tb.id_number, tb.reason, rh.id AS rooming_house_id, rh.name AS rooming_house_name").
+		Joins("JOIN rooming_houses rh ON tb.rooming_house_id = rh.id").
+		Where("tb.owner_id = ? AND tb.deleted_at IS NULL", ownerID)
+
+	switch {
+	case phoneNumber != "" && idNumber != "":
+		query = query.Where("tb.phone_number = ? OR tb.id_number = ?", phoneNumber, idNumber)
+	case phoneNumber != "":
+		query = query.Where("tb.phone_number = ?", phoneNumber)
+	case idNumber != "":
+		query = query.Where("tb.id_number = ?", idNumber)
+	default:
+		return matches, nil
+	}
+
+	if err := query.Scan(&matches).Error; err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+func (r *tenantBlacklistRepository) DeleteTenantBlacklistByID(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("id = ? AND owner_id = ?", id, ownerID).Delete(&models.TenantBlacklist{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *tenantBlacklistRepository) CreateScreeningOverride(ctx context.Context, override *models.TenantScreeningOverride) error {
+	if err := r.db.WithContext(ctx).Create(override).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *tenantBlacklistRepository) FindAllScreeningOverrides(ownerID uuid.UUID) (*[]models.TenantScreeningOverrideResponse, error) {
+	var overrides []models.TenantScreeningOverrideResponse
+	if err := r.db.Table("tenant_screening_overrides tso").
+		Select("tso.id, tso.tenant_id, t.name AS tenant_name, tso.matches, tso.justification, tso.approved_by, tso.created_at, rh.id AS rooming_house_id, rh.name AS rooming_house_name").
+		Joins("JOIN tenants t ON tso.tenant_id = t.id").
+		Joins("JOIN rooming_houses rh ON tso.rooming_house_id = rh.id").
+		Where("tso.owner_id = ? AND tso.deleted_at IS NULL", ownerID).
+		Order("tso.created_at DESC").
+		Scan(&overrides).Error; err != nil {
+		return nil, err
+	}
+	return &overrides, nil
+}
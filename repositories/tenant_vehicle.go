@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"rooming-house-cms-be/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TenantVehicleRepository interface {
+	CreateTenantVehicles(ctx context.Context, tenantVehicles *[]models.TenantVehicle) error
+	UpdateTenantVehiclesByTenantID(ctx context.Context, tenantVehicles *[]models.TenantVehicle, tenantID uuid.UUID) error
+}
+
+type tenantVehicleRepository struct {
+	db *gorm.DB
+}
+
+func NewTenantVehicleRepository(db *gorm.DB) TenantVehicleRepository {
+	return &tenantVehicleRepository{db: db}
+}
+
+func (r *tenantVehicleRepository) CreateTenantVehicles(ctx context.Context, tenantVehicles *[]models.TenantVehicle) error {
+	if err := r.db.WithContext(ctx).Create(tenantVehicles).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *tenantVehicleRepository) UpdateTenantVehiclesByTenantID(ctx context.Context, tenantVehicles *[]models.TenantVehicle, tenantID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.TenantVehicle{}, "tenant_id = ?", tenantID).Error; err != nil {
+			return err
+		}
+
+		if len(*tenantVehicles) == 0 {
+			return nil
+		}
+
+		return tx.Create(tenantVehicles).Error
+	})
+}
@@ -1,17 +1,24 @@
 package repositories
 
 import (
+	"context"
+	"fmt"
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/models"
+	"strings"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type TransactionRepository interface {
-	CreateTransaction(transaction *models.Transaction) error
+	CreateTransaction(ctx context.Context, transaction *models.Transaction) error
 	FindAllTransactions(roomingHouseIDs []uuid.UUID, year int) (*[]models.TransactionResponse, error)
 	FindTransactionByID(id uuid.UUID) (*models.Transaction, error)
-	DeleteTransactionByID(id uuid.UUID) error
+	FindTransactionsByTenantID(tenantID uuid.UUID) (*[]models.TransactionResponse, error)
+	FindTenantReceipt(transactionID uuid.UUID, tenantID uuid.UUID) (*models.TenantReceiptResponse, error)
+	SumTransactionsByCategory(roomingHouseID uuid.UUID, year int, month int) (*[]models.TransactionCategoryTotal, error)
+	DeleteTransactionByID(ctx context.Context, id uuid.UUID) error
 }
 
 type transactionRepository struct {
@@ -22,8 +29,8 @@ func NewTransactionRepository(db *gorm.DB) TransactionRepository {
 	return &transactionRepository{db: db}
 }
 
-func (t *transactionRepository) CreateTransaction(transaction *models.Transaction) error {
-	if err := t.db.Create(transaction).Error; err != nil {
+func (t *transactionRepository) CreateTransaction(ctx context.Context, transaction *models.Transaction) error {
+	if err := t.db.WithContext(ctx).Create(transaction).Error; err != nil {
 		return err
 	}
 	return nil
@@ -33,10 +40,10 @@ func (t *transactionRepository) FindAllTransactions(roomingHouseIDs []uuid.UUID,
 	var transactions []models.TransactionResponse
 
 	query := t.db.Table("transactions t").
-		Select("t.id, t.day, t.month, t.year, t.amount, t.rooming_house_id AS rooming_house_id, rh.name AS rooming_house_name, tc.name AS transaction_category_name, tc.is_expense AS transaction_category_is_expense").
+		Select("t.id, t.day, t.month, t.year, t.amount, t.transaction_category_id, t.rooming_house_id AS rooming_house_id, rh.name AS rooming_house_name, tc.name AS transaction_category_name, tc.is_expense AS transaction_category_is_expense, COALESCE(tc.code, '') AS transaction_category_code").
 		Joins("JOIN rooming_houses rh ON t.rooming_house_id = rh.id").
 		Joins("JOIN transaction_categories tc ON t.transaction_category_id = tc.id").
-		Where("t.rooming_house_id IN (?)", roomingHouseIDs)
+		Where("t.rooming_house_id IN (?) AND t.deleted_at IS NULL", roomingHouseIDs)
 
 	if year != 0 {
 		query = query.Where("t.year = ?", year)
@@ -57,8 +64,63 @@ func (t *transactionRepository) FindTransactionByID(id uuid.UUID) (*models.Trans
 	return &transaction, nil
 }
 
-func (t *transactionRepository) DeleteTransactionByID(id uuid.UUID) error {
-	if err := t.db.Where("id = ?", id).Delete(&models.Transaction{}).Error; err != nil {
+func (t *transactionRepository) FindTransactionsByTenantID(tenantID uuid.UUID) (*[]models.TransactionResponse, error) {
+	var transactions []models.TransactionResponse
+
+	if err := t.db.Table("transactions t").
+		Select("t.id, t.day, t.month, t.year, t.amount, t.transaction_category_id, t.rooming_house_id AS rooming_house_id, rh.name AS rooming_house_name, tc.name AS transaction_category_name, tc.is_expense AS transaction_category_is_expense, COALESCE(tc.code, '') AS transaction_category_code").
+		Joins("JOIN rooming_houses rh ON t.rooming_house_id = rh.id").
+		Joins("JOIN transaction_categories tc ON t.transaction_category_id = tc.id").
+		Where("t.tenant_id = ? AND t.deleted_at IS NULL", tenantID).
+		Order("t.year DESC, t.month DESC, t.day DESC").
+		Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+
+	return &transactions, nil
+}
+
+func (t *transactionRepository) FindTenantReceipt(transactionID uuid.UUID, tenantID uuid.UUID) (*models.TenantReceiptResponse, error) {
+	var receipt models.TenantReceiptResponse
+
+	if err := t.db.Table("transactions t").
+		Select("t.id AS transaction_id, t.day, t.month, t.year, t.amount, t.description, t.created_at AS issued_at, tc.name AS category, tn.name AS tenant_name, r.name AS room_name, rh.id AS rooming_house_id, rh.name AS rooming_house_name").
+		Joins("JOIN transaction_categories tc ON t.transaction_category_id = tc.id").
+		Joins("JOIN tenants tn ON t.tenant_id = tn.id").
+		Joins("LEFT JOIN rooms r ON t.room_id = r.id").
+		Joins("JOIN rooming_houses rh ON t.rooming_house_id = rh.id").
+		Where("t.id = ? AND t.tenant_id = ? AND t.deleted_at IS NULL AND tc.is_expense = false", transactionID, tenantID).
+		Take(&receipt).Error; err != nil {
+		return nil, err
+	}
+
+	receipt.ReceiptNumber = fmt.Sprintf("RCPT-%04d%02d%02d-%s", receipt.Year, receipt.Month, receipt.Day, strings.ToUpper(receipt.TransactionID.String()[:8]))
+
+	return &receipt, nil
+}
+
+// SumTransactionsByCategory totals the rooming house's transactions of a month
+// per category, income categories first. Deposits and their paybacks are held
+// for the tenant, not earned or spent by the house, so they are left out.
+func (t *transactionRepository) SumTransactionsByCategory(roomingHouseID uuid.UUID, year int, month int) (*[]models.TransactionCategoryTotal, error) {
+	totals := []models.TransactionCategoryTotal{}
+
+	if err := t.db.Table("transactions t").
+		Select("tc.id AS transaction_category_id, tc.name AS category_name, tc.is_expense, SUM(t.amount) AS amount").
+		Joins("JOIN transaction_categories tc ON t.transaction_category_id = tc.id").
+		Where("t.rooming_house_id = ? AND t.year = ? AND t.month = ? AND t.deleted_at IS NULL", roomingHouseID, year, month).
+		Where("tc.code IS NULL OR tc.code NOT IN (?)", constants.DepositTransactionCategoryCodes).
+		Group("tc.id, tc.name, tc.is_expense").
+		Order("tc.is_expense, tc.name").
+		Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+
+	return &totals, nil
+}
+
+func (t *transactionRepository) DeleteTransactionByID(ctx context.Context, id uuid.UUID) error {
+	if err := t.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Transaction{}).Error; err != nil {
 		return err
 	}
 	return nil
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"rooming-house-cms-be/models"
 
 	"github.com/google/uuid"
@@ -8,11 +9,13 @@ import (
 )
 
 type TransactionCategoryRepository interface {
-	CreateTransactionCategory(transactionCategory *models.TransactionCategory) error
+	CreateTransactionCategory(ctx context.Context, transactionCategory *models.TransactionCategory) error
 	FindTransactionCategoryByID(id uuid.UUID) (*models.TransactionCategory, error)
-	FindAllTransactionCategories() (*[]models.TransactionCategory, error)
-	UpdateTransactionCategoryByID(transaction *models.TransactionCategory, id uuid.UUID) error
-	DeleteTransactionCategoryByID(id uuid.UUID) error
+	FindSystemTransactionCategory(code string) (*models.TransactionCategory, error)
+	FindAllTransactionCategories(ownerID uuid.UUID, roomingHouseIDs []uuid.UUID) (*[]models.TransactionCategory, error)
+	CountTransactionsByCategoryID(id uuid.UUID) (int64, error)
+	UpdateTransactionCategoryByID(ctx context.Context, transaction *models.TransactionCategory, id uuid.UUID) error
+	DeleteTransactionCategoryByID(ctx context.Context, id uuid.UUID) error
 }
 
 type transactionCategoryRepository struct {
@@ -23,8 +26,8 @@ func NewTransactionCategoryRepository(db *gorm.DB) TransactionCategoryRepository
 	return &transactionCategoryRepository{db: db}
 }
 
-func (r *transactionCategoryRepository) CreateTransactionCategory(transactionCategory *models.TransactionCategory) error {
-	if err := r.db.Create(transactionCategory).Error; err != nil {
+func (r *transactionCategoryRepository) CreateTransactionCategory(ctx context.Context, transactionCategory *models.TransactionCategory) error {
+	if err := r.db.WithContext(ctx).Create(transactionCategory).Error; err != nil {
 		return err
 	}
 	return nil
@@ -38,16 +41,43 @@ func (r *transactionCategoryRepository) FindTransactionCategoryByID(id uuid.UUID
 	return &transactionCategory, nil
 }
 
-func (r *transactionCategoryRepository) FindAllTransactionCategories() (*[]models.TransactionCategory, error) {
+// FindSystemTransactionCategory returns the global category with the system code.
+func (r *transactionCategoryRepository) FindSystemTransactionCategory(code string) (*models.TransactionCategory, error) {
+	var transactionCategory models.TransactionCategory
+	if err := r.db.Where("code = ? AND owner_id IS NULL", code).First(&transactionCategory).Error; err != nil {
+		return nil, err
+	}
+	return &transactionCategory, nil
+}
+
+// FindAllTransactionCategories returns the global categories, the owner-wide
+// categories of the owner and those of the given rooming houses.
+func (r *transactionCategoryRepository) FindAllTransactionCategories(ownerID uuid.UUID, roomingHouseIDs []uuid.UUID) (*[]models.TransactionCategory, error) {
 	var transactionCategories []models.TransactionCategory
-	if err := r.db.Find(&transactionCategories).Error; err != nil {
+
+	query := r.db.Where("owner_id IS NULL")
+	if len(roomingHouseIDs) > 0 {
+		query = query.Or("owner_id = ? AND (rooming_house_id IS NULL OR rooming_house_id IN (?))", ownerID, roomingHouseIDs)
+	} else {
+		query = query.Or("owner_id = ? AND rooming_house_id IS NULL", ownerID)
+	}
+
+	if err := query.Order("is_expense, name").Find(&transactionCategories).Error; err != nil {
 		return nil, err
 	}
 	return &transactionCategories, nil
 }
 
-func (r *transactionCategoryRepository) UpdateTransactionCategoryByID(transactionCategory *models.TransactionCategory, id uuid.UUID) error {
-	res := r.db.Where("id = ?", id).Updates(transactionCategory)
+func (r *transactionCategoryRepository) CountTransactionsByCategoryID(id uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.Transaction{}).Where("transaction_category_id = ?", id).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *transactionCategoryRepository) UpdateTransactionCategoryByID(ctx context.Context, transactionCategory *models.TransactionCategory, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Model(&models.TransactionCategory{}).Where("id = ?", id).Select("name", "is_expense").Updates(transactionCategory)
 	if res.Error != nil {
 		return res.Error
 	}
@@ -59,8 +89,8 @@ func (r *transactionCategoryRepository) UpdateTransactionCategoryByID(transactio
 	return nil
 }
 
-func (r *transactionCategoryRepository) DeleteTransactionCategoryByID(id uuid.UUID) error {
-	res := r.db.Where("id = ?", id).Delete(&models.TransactionCategory{})
+func (r *transactionCategoryRepository) DeleteTransactionCategoryByID(ctx context.Context, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.TransactionCategory{})
 	if res.Error != nil {
 		return res.Error
 	}
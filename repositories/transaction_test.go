@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"rooming-house-cms-be/constants"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// recordingDriver is a database/sql driver that records the queries it is
+// given and answers every query with an empty result set.
+type recordingDriver struct {
+	queries []recordedQuery
+}
+
+type recordedQuery struct {
+	sql  string
+	args []driver.NamedValue
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) {
+	return &recordingConn{driver: d}, nil
+}
+
+type recordingConn struct {
+	driver *recordingDriver
+}
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c *recordingConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.driver.queries = append(c.driver.queries, recordedQuery{sql: query, args: args})
+	return emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string {
+	return nil
+}
+
+func (emptyRows) Close() error {
+	return nil
+}
+
+func (emptyRows) Next([]driver.Value) error {
+	return io.EOF
+}
+
+type recordingConnector struct {
+	driver *recordingDriver
+}
+
+func (c recordingConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open("")
+}
+
+func (c recordingConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+func newRecordingDB(t *testing.T) (*gorm.DB, *recordingDriver) {
+	t.Helper()
+
+	recorder := &recordingDriver{}
+	sqlDB := sql.OpenDB(recordingConnector{driver: recorder})
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+
+	return db, recorder
+}
+
+func TestSumTransactionsByCategoryExcludesDeposits(t *testing.T) {
+	db, recorder := newRecordingDB(t)
+
+	totals, err := NewTransactionRepository(db).SumTransactionsByCategory(uuid.New(), 2026, 2)
+	if err != nil {
+		t.Fatalf("SumTransactionsByCategory: %v", err)
+	}
+
+	if len(*totals) != 0 {
+		t.Fatalf("got %d totals, want 0", len(*totals))
+	}
+
+	if len(recorder.queries) != 1 {
+		t.Fatalf("got %d queries, want 1", len(recorder.queries))
+	}
+
+	query := recorder.queries[0]
+	if !strings.Contains(query.sql, "tc.code NOT IN (?,?)") {
+		t.Fatalf("query does not exclude category codes: %s", query.sql)
+	}
+
+	excluded := map[string]bool{}
+	for _, arg := range query.args {
+		if code, ok := arg.Value.(string); ok {
+			excluded[code] = true
+		}
+	}
+
+	for _, code := range []string{constants.TransactionCategoryCodeDeposit, constants.TransactionCategoryCodeDepositPayback} {
+		if !excluded[code] {
+			t.Errorf("category code %q is not excluded", code)
+		}
+	}
+}
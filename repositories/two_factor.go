@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"context"
+	"rooming-house-cms-be/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TwoFactorRepository interface {
+	FindTwoFactorByUser(userID uuid.UUID, role string) (*models.TwoFactor, error)
+	CreateTwoFactor(ctx context.Context, twoFactor *models.TwoFactor) error
+	EnableTwoFactor(ctx context.Context, id uuid.UUID, step int64, codeHashes []string) error
+	UseTwoFactorStep(ctx context.Context, id uuid.UUID, step int64) error
+	ReplaceRecoveryCodes(ctx context.Context, id uuid.UUID, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, id uuid.UUID, codeHash string) error
+	CountUnusedRecoveryCodes(id uuid.UUID) (int64, error)
+	DeleteTwoFactor(ctx context.Context, id uuid.UUID) error
+}
+
+type twoFactorRepository struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorRepository(db *gorm.DB) TwoFactorRepository {
+	return &twoFactorRepository{db: db}
+}
+
+func (r *twoFactorRepository) FindTwoFactorByUser(userID uuid.UUID, role string) (*models.TwoFactor, error) {
+	var twoFactor models.TwoFactor
+	if err := r.db.Where("user_id = ? AND role = ?", userID, role).First(&twoFactor).Error; err != nil {
+		return nil, err
+	}
+	return &twoFactor, nil
+}
+
+// CreateTwoFactor starts a new enrollment, replacing any pending one of the
+// same user.
+func (r *twoFactorRepository) CreateTwoFactor(ctx context.Context, twoFactor *models.TwoFactor) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND role = ? AND enabled_at IS NULL", twoFactor.UserID, twoFactor.Role).
+			Delete(&models.TwoFactor{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(twoFactor).Error
+	})
+}
+
+// EnableTwoFactor confirms a pending enrollment with the step of its first
+// code and stores its recovery codes.
+func (r *twoFactorRepository) EnableTwoFactor(ctx context.Context, id uuid.UUID, step int64, codeHashes []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.TwoFactor{}).
+			Where("id = ? AND enabled_at IS NULL", id).
+			Updates(map[string]interface{}{"enabled_at": time.Now(), "last_used_step": step})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return createRecoveryCodes(tx, id, codeHashes)
+	})
+}
+
+// UseTwoFactorStep records that the code of step was used, failing if that
+// step or a later one was already used so a code cannot be replayed.
+func (r *twoFactorRepository) UseTwoFactorStep(ctx context.Context, id uuid.UUID, step int64) error {
+	res := r.db.WithContext(ctx).Model(&models.TwoFactor{}).
+		Where("id = ? AND last_used_step < ?", id, step).
+		Update("last_used_step", step)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes discards the remaining recovery codes and stores new ones.
+func (r *twoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, id uuid.UUID, codeHashes []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("two_factor_id = ?", id).Delete(&models.TwoFactorRecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		return createRecoveryCodes(tx, id, codeHashes)
+	})
+}
+
+// UseRecoveryCode marks the matching unused recovery code as used.
+func (r *twoFactorRepository) UseRecoveryCode(ctx context.Context, id uuid.UUID, codeHash string) error {
+	res := r.db.WithContext(ctx).Model(&models.TwoFactorRecoveryCode{}).
+		Where("two_factor_id = ? AND code_hash = ? AND used_at IS NULL", id, codeHash).
+		Update("used_at", time.Now())
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *twoFactorRepository) CountUnusedRecoveryCodes(id uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.TwoFactorRecoveryCode{}).
+		Where("two_factor_id = ? AND used_at IS NULL", id).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteTwoFactor removes the enrollment together with its recovery codes.
+func (r *twoFactorRepository) DeleteTwoFactor(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("two_factor_id = ?", id).Delete(&models.TwoFactorRecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", id).Delete(&models.TwoFactor{}).Error
+	})
+}
+
+func createRecoveryCodes(tx *gorm.DB, id uuid.UUID, codeHashes []string) error {
+	recoveryCodes := make([]models.TwoFactorRecoveryCode, 0, len(codeHashes))
+	for _, codeHash := range codeHashes {
+		recoveryCodes = append(recoveryCodes, models.TwoFactorRecoveryCode{TwoFactorID: id, CodeHash: codeHash})
+	}
+
+	return tx.Create(&recoveryCodes).Error
+}
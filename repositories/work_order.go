@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"rooming-house-cms-be/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WorkOrderRepository interface {
+	CreateWorkOrder(ctx context.Context, workOrder *models.WorkOrder) error
+	FindWorkOrderByID(id uuid.UUID) (*models.WorkOrder, error)
+	FindAllWorkOrders(roomingHouseIDs []uuid.UUID, status string, reportedBy uuid.UUID) (*[]models.WorkOrderResponse, error)
+	UpdateWorkOrderByID(ctx context.Context, workOrder *models.WorkOrder, id uuid.UUID) error
+	UpdateWorkOrderStatus(ctx context.Context, workOrder *models.WorkOrder) error
+	CompleteWorkOrder(ctx context.Context, workOrder *models.WorkOrder, transaction *models.Transaction) error
+	DeleteWorkOrderByID(ctx context.Context, id uuid.UUID) error
+}
+
+type workOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkOrderRepository(db *gorm.DB) WorkOrderRepository {
+	return &workOrderRepository{db: db}
+}
+
+func (r *workOrderRepository) CreateWorkOrder(ctx context.Context, workOrder *models.WorkOrder) error {
+	if err := r.db.WithContext(ctx).Create(workOrder).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *workOrderRepository) FindWorkOrderByID(id uuid.UUID) (*models.WorkOrder, error) {
+	var workOrder models.WorkOrder
+	if err := r.db.Where("id = ?", id).First(&workOrder).Error; err != nil {
+		return nil, err
+	}
+	return &workOrder, nil
+}
+
+func (r *workOrderRepository) FindAllWorkOrders(roomingHouseIDs []uuid.UUID, status string, reportedBy uuid.UUID) (*[]models.WorkOrderResponse, error) {
+	var workOrders []models.WorkOrderResponse
+
+	query := r.db.Table("work_orders wo").
+		Select("wo.id, wo.title, wo.description, wo.status, wo.priority, wo.assignee, wo.room_id, r.name AS room_name, wo.rooming_house_facility_id, f.name AS facility_name, wo.reported_by, wo.reporter_role, wo.resolution_note, wo.cost, wo.transaction_id, wo.maintenance_schedule_id, wo.assigned_at, wo.started_at, wo.completed_at, wo.created_at, rh.id AS rooming_house_id, rh.name AS rooming_house_name").
+		Joins("JOIN rooming_houses rh ON wo.rooming_house_id = rh.id").
+		Joins("LEFT JOIN rooms r ON wo.room_id = r.id").
+		Joins("LEFT JOIN rooming_house_facilities rhf ON wo.rooming_house_facility_id = rhf.id").
+		Joins("LEFT JOIN facilities f ON rhf.facility_id = f.id").
+		Where("wo.rooming_house_id IN (?) AND wo.deleted_at IS NULL", roomingHouseIDs)
+
+	if status != "" {
+		query = query.Where("wo.status = ?", status)
+	}
+
+	if reportedBy != uuid.Nil {
+		query = query.Where("wo.reported_by = ?", reportedBy)
+	}
+
+	if err := query.Order("wo.created_at DESC").Scan(&workOrders).Error; err != nil {
+		return nil, err
+	}
+
+	return &workOrders, nil
+}
+
+func (r *workOrderRepository) UpdateWorkOrderByID(ctx context.Context, workOrder *models.WorkOrder, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Model(&models.WorkOrder{}).Where("id = ?", id).Updates(workOrder)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// UpdateWorkOrderStatus writes the status with its assignee and timestamps,
+// including empty ones, so moving back a step clears what no longer applies.
+func (r *workOrderRepository) UpdateWorkOrderStatus(ctx context.Context, workOrder *models.WorkOrder) error {
+	res := r.db.WithContext(ctx).Model(&models.WorkOrder{}).
+		Where("id = ?", workOrder.ID).
+		Select("status", "assignee", "assigned_at", "started_at").
+		Updates(workOrder)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// CompleteWorkOrder marks the work order done and, when given, records its
+// expense transaction in the same database transaction.
+func (r *workOrderRepository) CompleteWorkOrder(ctx context.Context, workOrder *models.WorkOrder, transaction *models.Transaction) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if transaction != nil {
+			if err := tx.Create(transaction).Error; err != nil {
+				return err
+			}
+			workOrder.TransactionID = &transaction.ID
+		}
+
+		return tx.Model(&models.WorkOrder{}).Where("id = ?", workOrder.ID).Updates(workOrder).Error
+	})
+}
+
+// DeleteWorkOrderByID deletes the work order and releases the maintenance
+// schedule that opened it, so the schedule can open its next work order.
+func (r *workOrderRepository) DeleteWorkOrderByID(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ?", id).Delete(&models.WorkOrder{})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Model(&models.MaintenanceSchedule{}).
+			Where("open_work_order_id = ?", id).
+			Update("open_work_order_id", nil).Error
+	})
+}
@@ -0,0 +1,35 @@
+package seeders
+
+import (
+	"rooming-house-cms-be/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SeedAdminRoomingHouses moves the single rooming house of admins created
+// before staff assignments existed into admin_rooming_houses and drops the
+// old column. Safe to run on every start.
+func SeedAdminRoomingHouses(db *gorm.DB) {
+	if !db.Migrator().HasColumn(&models.Admin{}, "rooming_house_id") {
+		return
+	}
+
+	var admins []struct {
+		ID             uuid.UUID
+		RoomingHouseID uuid.UUID
+	}
+	if err := db.Table("admins").Select("id, rooming_house_id").Where("rooming_house_id IS NOT NULL").Scan(&admins).Error; err != nil {
+		return
+	}
+
+	for _, admin := range admins {
+		// Keep the old column until every admin has been moved over
+		if err := db.Where(models.AdminRoomingHouse{AdminID: admin.ID, RoomingHouseID: admin.RoomingHouseID}).
+			FirstOrCreate(&models.AdminRoomingHouse{AdminID: admin.ID, RoomingHouseID: admin.RoomingHouseID}).Error; err != nil {
+			return
+		}
+	}
+
+	db.Migrator().DropColumn(&models.Admin{}, "rooming_house_id")
+}
@@ -6,27 +6,49 @@ import (
 	"gorm.io/gorm"
 )
 
+// SeedPeriod creates the system periods that are missing, matched by name and
+// unit, so periods added later reach existing databases. Safe to run on every
+// start.
 func SeedPeriod(db *gorm.DB) {
 	periods := []models.Period{
 		{
-			Name: "Daily",
-			Unit: "day",
+			Name:  "Daily",
+			Unit:  "day",
+			Count: 1,
 		},
 		{
-			Name: "Weekly",
-			Unit: "week",
+			Name:  "Weekly",
+			Unit:  "week",
+			Count: 1,
 		},
 		{
-			Name: "Monthly",
-			Unit: "month",
+			Name:  "Monthly",
+			Unit:  "month",
+			Count: 1,
 		},
 		{
-			Name: "Annually",
-			Unit: "year",
+			Name:  "Quarterly",
+			Unit:  "month",
+			Count: 3,
+		},
+		{
+			Name:  "Semester",
+			Unit:  "month",
+			Count: 6,
+		},
+		{
+			Name:  "Annually",
+			Unit:  "year",
+			Count: 1,
 		},
 	}
 
 	for _, period := range periods {
-		db.Create(&period)
+		var existing models.Period
+		if err := db.Where("owner_id IS NULL AND name = ? AND unit = ?", period.Name, period.Unit).
+			Attrs(models.Period{Name: period.Name, Unit: period.Unit, Count: period.Count}).
+			FirstOrCreate(&existing).Error; err != nil {
+			return
+		}
 	}
 }
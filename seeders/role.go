@@ -0,0 +1,42 @@
+package seeders
+
+import (
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// SeedRoles creates the system roles, keeps their permissions in sync with
+// constants.SystemRolePermissions and gives assignments made before roles
+// existed the manager role. Safe to run on every start.
+func SeedRoles(db *gorm.DB) {
+	for code, name := range constants.SystemRoleNames {
+		var role models.Role
+		if err := db.Where("owner_id IS NULL AND code = ?", code).
+			Attrs(models.Role{Code: code, Name: name}).
+			FirstOrCreate(&role).Error; err != nil {
+			return
+		}
+
+		db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Unscoped().Where("role_id = ?", role.ID).Delete(&models.RolePermission{}).Error; err != nil {
+				return err
+			}
+
+			for _, permission := range constants.SystemRolePermissions[code] {
+				resource, action, _ := strings.Cut(permission, ":")
+				if err := tx.Create(&models.RolePermission{RoleID: role.ID, Resource: resource, Action: action}).Error; err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+
+		if code == constants.RoleCodeManager {
+			db.Model(&models.AdminRoomingHouse{}).Where("role_id IS NULL").Update("role_id", role.ID)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package seeders
+
+import (
+	"rooming-house-cms-be/constants"
+	"rooming-house-cms-be/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SeedRoomingHouseOwners gives the primary owner of every rooming house
+// created before co-ownership existed the full share. Safe to run on every start.
+func SeedRoomingHouseOwners(db *gorm.DB) {
+	var roomingHouses []struct {
+		ID      uuid.UUID
+		OwnerID uuid.UUID
+	}
+	if err := db.Table("rooming_houses").
+		Select("id, owner_id").
+		Where("deleted_at IS NULL AND id NOT IN (?)", db.Model(&models.RoomingHouseOwner{}).Select("rooming_house_id")).
+		Scan(&roomingHouses).Error; err != nil {
+		return
+	}
+
+	for _, roomingHouse := range roomingHouses {
+		if err := db.Create(&models.RoomingHouseOwner{
+			RoomingHouseID: roomingHouse.ID,
+			OwnerID:        roomingHouse.OwnerID,
+			Percentage:     constants.OwnershipTotalPercentage,
+		}).Error; err != nil {
+			return
+		}
+	}
+}
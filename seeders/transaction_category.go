@@ -1,6 +1,7 @@
 package seeders
 
 import (
+	"rooming-house-cms-be/constants"
 	"rooming-house-cms-be/models"
 
 	"gorm.io/gorm"
@@ -10,10 +11,12 @@ func SeedTransactionCategory(db *gorm.DB) {
 	transactionCategories := []models.TransactionCategory{
 		{
 			Name:      "Deposit",
+			Code:      constants.TransactionCategoryCodeDeposit,
 			IsExpense: false,
 		},
 		{
 			Name:      "Rent",
+			Code:      constants.TransactionCategoryCodeRent,
 			IsExpense: false,
 		},
 		{
@@ -22,6 +25,7 @@ func SeedTransactionCategory(db *gorm.DB) {
 		},
 		{
 			Name:      "Salary",
+			Code:      constants.TransactionCategoryCodeSalary,
 			IsExpense: true,
 		},
 		{
@@ -34,6 +38,7 @@ func SeedTransactionCategory(db *gorm.DB) {
 		},
 		{
 			Name:      "Deposit Payback",
+			Code:      constants.TransactionCategoryCodeDepositPayback,
 			IsExpense: true,
 		},
 	}
@@ -42,3 +47,13 @@ func SeedTransactionCategory(db *gorm.DB) {
 		db.Create(&transactionCategory)
 	}
 }
+
+// SeedTransactionCategoryCodes assigns the system codes to global categories
+// seeded before codes existed. Safe to run on every start.
+func SeedTransactionCategoryCodes(db *gorm.DB) {
+	for code, name := range constants.SystemTransactionCategoryNames {
+		db.Model(&models.TransactionCategory{}).
+			Where("name = ? AND owner_id IS NULL AND (code IS NULL OR code = '')", name).
+			Update("code", code)
+	}
+}
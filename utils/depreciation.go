@@ -0,0 +1,35 @@
+package utils
+
+import "time"
+
+// MonthsBetween counts the whole months elapsed from start to end, or 0 when end is before start.
+func MonthsBetween(start time.Time, end time.Time) int {
+	if end.Before(start) {
+		return 0
+	}
+
+	months := (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month())
+	if end.Day() < start.Day() {
+		months--
+	}
+
+	if months < 0 {
+		return 0
+	}
+	return months
+}
+
+// StraightLineDepreciation returns the depreciation accumulated by asOf when
+// cost minus salvage is spread evenly over usefulLifeMonths from purchaseDate.
+func StraightLineDepreciation(cost float64, salvage float64, usefulLifeMonths int, purchaseDate time.Time, asOf time.Time) float64 {
+	if usefulLifeMonths <= 0 || cost <= salvage {
+		return 0
+	}
+
+	elapsed := MonthsBetween(purchaseDate, asOf)
+	if elapsed > usefulLifeMonths {
+		elapsed = usefulLifeMonths
+	}
+
+	return (cost - salvage) * float64(elapsed) / float64(usefulLifeMonths)
+}
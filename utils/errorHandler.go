@@ -57,6 +57,22 @@ func NewForbiddenError(message string) *APIError {
 	}
 }
 
+func NewConflictError(message string) *APIError {
+	return &APIError{
+		Code:    http.StatusConflict,
+		Message: message,
+		Detail:  "Resource Conflict",
+	}
+}
+
+func NewTooManyRequestsError(message string) *APIError {
+	return &APIError{
+		Code:    http.StatusTooManyRequests,
+		Message: message,
+		Detail:  "Too Many Requests",
+	}
+}
+
 func HandlerError(c echo.Context, err *APIError) error {
 	return c.JSON(err.Code, err)
 }
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"rooming-house-cms-be/constants"
+	"time"
+)
+
+// AddPeriod advances date by count units of time, where unit is one of
+// constants.PeriodUnits. A period of N units repeated M times is advanced
+// with count N*M.
+func AddPeriod(date time.Time, unit string, count int) time.Time {
+	switch unit {
+	case constants.PeriodUnitDay:
+		return date.AddDate(0, 0, count)
+	case constants.PeriodUnitWeek:
+		return date.AddDate(0, 0, count*7)
+	case constants.PeriodUnitMonth:
+		return date.AddDate(0, count, 0)
+	case constants.PeriodUnitYear:
+		return date.AddDate(count, 0, 0)
+	}
+	return date
+}
+
+// MonthDay returns the day of the month, moved back to the month's last day
+// when the month is shorter, so day 31 falls on 28 February.
+func MonthDay(year int, month time.Month, day int) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.Local).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
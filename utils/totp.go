@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"rooming-house-cms-be/constants"
+	"strings"
+	"time"
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret returns a random base32 encoded secret for an authenticator app.
+func GenerateTOTPSecret() (string, error) {
+	buf := make([]byte, constants.TOTPSecretLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(buf), nil
+}
+
+// TOTPProvisioningURI builds the otpauth:// URI that authenticator apps read
+// from a QR code.
+func TOTPProvisioningURI(accountName string, secret string) string {
+	label := url.PathEscape(constants.TOTPIssuer + ":" + accountName)
+
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", constants.TOTPIssuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(constants.TOTPDigits))
+	query.Set("period", fmt.Sprint(int(constants.TOTPPeriod.Seconds())))
+
+	// Authenticator apps expect spaces as %20 rather than +
+	return "otpauth://totp/" + label + "?" + strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+// ValidateTOTP checks code against the secret at t, allowing constants.TOTPSkew
+// steps of clock drift either way. It returns the time step the code belongs
+// to so callers can refuse to accept the same code twice.
+func ValidateTOTP(secret string, code string, t time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != constants.TOTPDigits {
+		return 0, false
+	}
+
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+
+	current := t.Unix() / int64(constants.TOTPPeriod.Seconds())
+	for offset := int64(-constants.TOTPSkew); offset <= constants.TOTPSkew; offset++ {
+		step := current + offset
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// totpCode computes the RFC 6238 code of the given time step.
+func totpCode(key []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < constants.TOTPDigits; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", constants.TOTPDigits, value%modulo)
+}
+
+// GenerateRecoveryCode returns a random single-use code formatted as two
+// groups of five characters.
+func GenerateRecoveryCode() (string, error) {
+	token, err := GenerateRandomToken(5)
+	if err != nil {
+		return "", err
+	}
+	return token[:5] + "-" + token[5:], nil
+}
+
+// NormalizeRecoveryCode strips the formatting users may type around a
+// recovery code before it is hashed.
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA1 key of the RFC 6238 test vectors, base32 encoded.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidateTOTP(t *testing.T) {
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		at       time.Time
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "rfc vector at 59", secret: rfc6238Secret, code: "287082", at: time.Unix(59, 0), wantStep: 1, wantOK: true},
+		{name: "rfc vector at 1111111109", secret: rfc6238Secret, code: "081804", at: time.Unix(1111111109, 0), wantStep: 37037036, wantOK: true},
+		{name: "rfc vector at 1234567890", secret: rfc6238Secret, code: "005924", at: time.Unix(1234567890, 0), wantStep: 41152263, wantOK: true},
+		{name: "rfc vector at 2000000000", secret: rfc6238Secret, code: "279037", at: time.Unix(2000000000, 0), wantStep: 66666666, wantOK: true},
+		{name: "lower case secret", secret: "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", code: "287082", at: time.Unix(59, 0), wantStep: 1, wantOK: true},
+		{name: "surrounding spaces", secret: rfc6238Secret, code: " 287082 ", at: time.Unix(59, 0), wantStep: 1, wantOK: true},
+		{name: "previous step within skew returns its own step", secret: rfc6238Secret, code: "081804", at: time.Unix(1111111109+30, 0), wantStep: 37037036, wantOK: true},
+		{name: "next step within skew returns its own step", secret: rfc6238Secret, code: "081804", at: time.Unix(1111111109-30, 0), wantStep: 37037036, wantOK: true},
+		{name: "two steps late", secret: rfc6238Secret, code: "081804", at: time.Unix(1111111109+60, 0)},
+		{name: "wrong code", secret: rfc6238Secret, code: "000000", at: time.Unix(59, 0)},
+		{name: "short code", secret: rfc6238Secret, code: "28708", at: time.Unix(59, 0)},
+		{name: "invalid secret", secret: "not base32!", code: "287082", at: time.Unix(59, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := ValidateTOTP(tt.secret, tt.code, tt.at)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("ValidateTOTP() = %d, %v; want %d, %v", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+var nikPattern = regexp.MustCompile(`^\d{16}$`)
+
+var platePattern = regexp.MustCompile(`^[A-Z]{1,2} \d{1,4}( [A-Z]{1,3})?$`)
+
+var plateSpacing = regexp.MustCompile(`^([A-Z]{1,2})\s*(\d{1,4})\s*([A-Z]{0,3})$`)
+
+// IsValidNIK reports whether id is a 16-digit Indonesian national identity number.
+func IsValidNIK(id string) bool {
+	return nikPattern.MatchString(id)
+}
+
+// NormalizePlateNumber upper-cases a vehicle plate and normalises its spacing
+// to the "B 1234 XYZ" form. It returns false when the plate is not recognised.
+func NormalizePlateNumber(plate string) (string, bool) {
+	plate = strings.ToUpper(strings.TrimSpace(plate))
+
+	parts := plateSpacing.FindStringSubmatch(plate)
+	if parts == nil {
+		return "", false
+	}
+
+	normalized := parts[1] + " " + parts[2]
+	if parts[3] != "" {
+		normalized += " " + parts[3]
+	}
+
+	return normalized, platePattern.MatchString(normalized)
+}